@@ -0,0 +1,118 @@
+package catalog
+
+import (
+	"bytes"
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func readAll(t *testing.T, reader service.ProductRecordReader) ([]*dto.ProductRecord, []error) {
+	t.Helper()
+
+	var records []*dto.ProductRecord
+	var errs []error
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return records, errs
+		}
+		records = append(records, record)
+		errs = append(errs, err)
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	input := "\ufeffSKU,name,price,currency,stock\n" +
+		"MUG-1,Mug,900,USD,3\n" +
+		"CUP-1,\"Cup, small\",abc,USD,\n" +
+		"TEA-1,Tea,100\n" +
+		"BOWL-1,Bowl,450,EUR,\n"
+
+	reader, err := NewCSVReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, errs := readAll(t, reader)
+	if len(records) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(records))
+	}
+
+	if errs[0] != nil || records[0].SKU != "MUG-1" || records[0].Price != 900 || *records[0].Stock != 3 {
+		t.Errorf("unexpected first record: %+v, %v", records[0], errs[0])
+	}
+	if !errors.Is(errs[1], service.ErrMalformedRecord) {
+		t.Errorf("expected malformed price error, got %v", errs[1])
+	}
+	if !errors.Is(errs[2], service.ErrMalformedRecord) {
+		t.Errorf("expected malformed field count error, got %v", errs[2])
+	}
+	if errs[3] != nil || records[3].Stock != nil {
+		t.Errorf("expected empty stock to be nil, got %+v, %v", records[3], errs[3])
+	}
+}
+
+func TestCSVReader_RejectsBadHeader(t *testing.T) {
+	if _, err := NewCSVReader(strings.NewReader("name,colour\n")); err == nil {
+		t.Error("expected error for unknown column")
+	}
+	if _, err := NewCSVReader(strings.NewReader("sku,price\n")); err == nil {
+		t.Error("expected error for missing name column")
+	}
+	if _, err := NewCSVReader(strings.NewReader("")); err == nil {
+		t.Error("expected error for empty file")
+	}
+}
+
+func TestNDJSONReader(t *testing.T) {
+	input := `{"sku":"MUG-1","name":"Mug","price":900,"currency":"USD"}
+
+{"name":"Cup","unknown":true}
+{"name":`
+
+	records, errs := readAll(t, NewNDJSONReader(strings.NewReader(input)))
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	if errs[0] != nil || records[0].SKU != "MUG-1" {
+		t.Errorf("unexpected first record: %+v, %v", records[0], errs[0])
+	}
+	if !errors.Is(errs[1], service.ErrMalformedRecord) || !errors.Is(errs[2], service.ErrMalformedRecord) {
+		t.Errorf("expected malformed records, got %v and %v", errs[1], errs[2])
+	}
+}
+
+func TestWriters_RoundTrip(t *testing.T) {
+	stock := 5
+	record := &dto.ProductRecord{ID: "p1", SKU: "MUG-1", Name: "Mug, large", Price: 900, Currency: "USD", Stock: &stock}
+
+	for _, format := range []string{FormatCSV, FormatNDJSON} {
+		var buf bytes.Buffer
+		writer, err := NewWriter(format, &buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := writer.Write(record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		reader, err := NewReader(format, &buf)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+		got, err := reader.Next()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+		if got.ID != record.ID || got.Name != record.Name || got.Price != record.Price || *got.Stock != stock {
+			t.Errorf("%s: round trip mismatch: %+v", format, got)
+		}
+	}
+}
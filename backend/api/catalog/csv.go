@@ -0,0 +1,165 @@
+package catalog
+
+import (
+	"bufio"
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvColumns are the CSV columns in export order. Options and variants are not
+// representable in CSV; use NDJSON to import or export them.
+var csvColumns = []string{"id", "sku", "name", "description", "category", "price", "currency", "stock"}
+
+// CSVReader reads catalog records from CSV with a header row
+type CSVReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+// NewCSVReader creates a CSVReader, reading and validating the header row
+func NewCSVReader(r io.Reader) (*CSVReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("CSV file is empty")
+		}
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	known := make(map[string]bool, len(csvColumns))
+	for _, c := range csvColumns {
+		known[c] = true
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !known[name] {
+			return nil, fmt.Errorf("unknown CSV column %q", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("duplicate CSV column %q", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("CSV header must include a name column")
+	}
+
+	return &CSVReader{reader: reader, columns: columns}, nil
+}
+
+// Next reads the next record
+func (r *CSVReader) Next() (*dto.ProductRecord, error) {
+	fields, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("%w: %v", service.ErrMalformedRecord, parseErr.Err)
+		}
+		return nil, err
+	}
+
+	record := &dto.ProductRecord{
+		ID:          r.field(fields, "id"),
+		SKU:         r.field(fields, "sku"),
+		Name:        r.field(fields, "name"),
+		Description: r.field(fields, "description"),
+		Category:    r.field(fields, "category"),
+		Currency:    r.field(fields, "currency"),
+	}
+
+	if price := r.field(fields, "price"); price != "" {
+		record.Price, err = strconv.ParseInt(price, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid price %q", service.ErrMalformedRecord, price)
+		}
+	}
+	if stock := r.field(fields, "stock"); stock != "" {
+		n, err := strconv.Atoi(stock)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid stock %q", service.ErrMalformedRecord, stock)
+		}
+		record.Stock = &n
+	}
+
+	return record, nil
+}
+
+// field returns the trimmed value of a column, or "" if the column is absent
+func (r *CSVReader) field(fields []string, column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(fields) {
+		return ""
+	}
+	return strings.TrimSpace(fields[i])
+}
+
+// CSVWriter writes catalog records as CSV with a header row
+type CSVWriter struct {
+	out           io.Writer
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+// NewCSVWriter creates a new CSVWriter
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{
+		out:    w,
+		writer: csv.NewWriter(bufio.NewWriter(w)),
+	}
+}
+
+// Write writes a record, preceded by the header on first use
+func (w *CSVWriter) Write(record *dto.ProductRecord) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	stock := ""
+	if record.Stock != nil {
+		stock = strconv.Itoa(*record.Stock)
+	}
+
+	return w.writer.Write([]string{
+		record.ID,
+		record.SKU,
+		record.Name,
+		record.Description,
+		record.Category,
+		strconv.FormatInt(record.Price, 10),
+		record.Currency,
+		stock,
+	})
+}
+
+// Flush writes any buffered data, including the header for an empty export
+func (w *CSVWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return err
+	}
+	flushUnderlying(w.out)
+	return nil
+}
+
+// writeHeader writes the header row once
+func (w *CSVWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return w.writer.Write(csvColumns)
+}
@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	"ecom-backend/application/service"
+	"errors"
+	"io"
+	"mime"
+	"strings"
+)
+
+// Supported catalog file formats
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// ErrUnsupportedFormat is returned for formats other than CSV and NDJSON
+var ErrUnsupportedFormat = errors.New("unsupported format: expected csv or ndjson")
+
+// ContentType returns the MIME type for a format
+func ContentType(format string) string {
+	if format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// FormatFromContentType maps a request Content-Type to a format, returning "" if it is not recognised
+func FormatFromContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	switch strings.ToLower(mediaType) {
+	case "text/csv", "application/csv":
+		return FormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return FormatNDJSON
+	default:
+		return ""
+	}
+}
+
+// NewReader creates a record reader for the given format
+func NewReader(format string, r io.Reader) (service.ProductRecordReader, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVReader(r)
+	case FormatNDJSON:
+		return NewNDJSONReader(r), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// NewWriter creates a record writer for the given format
+func NewWriter(format string, w io.Writer) (service.ProductRecordWriter, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatNDJSON:
+		return NewNDJSONWriter(w), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// flushUnderlying flushes w if it supports flushing, such as an http.ResponseWriter
+func flushUnderlying(w io.Writer) {
+	if f, ok := w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,85 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxNDJSONLine is the longest accepted NDJSON line in bytes
+const maxNDJSONLine = 1 << 20
+
+// NDJSONReader reads catalog records from newline-delimited JSON, skipping blank lines
+type NDJSONReader struct {
+	scanner *bufio.Scanner
+}
+
+// NewNDJSONReader creates a new NDJSONReader
+func NewNDJSONReader(r io.Reader) *NDJSONReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+	return &NDJSONReader{scanner: scanner}
+}
+
+// Next reads the next record
+func (r *NDJSONReader) Next() (*dto.ProductRecord, error) {
+	for r.scanner.Scan() {
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+
+		var record dto.ProductRecord
+		if err := decoder.Decode(&record); err != nil {
+			return nil, fmt.Errorf("%w: %v", service.ErrMalformedRecord, err)
+		}
+		return &record, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, fmt.Errorf("line exceeds %d bytes", maxNDJSONLine)
+		}
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// NDJSONWriter writes catalog records as newline-delimited JSON
+type NDJSONWriter struct {
+	out     io.Writer
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+// NewNDJSONWriter creates a new NDJSONWriter
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	buf := bufio.NewWriter(w)
+	return &NDJSONWriter{
+		out:     w,
+		buf:     buf,
+		encoder: json.NewEncoder(buf),
+	}
+}
+
+// Write writes a record as a single JSON line
+func (w *NDJSONWriter) Write(record *dto.ProductRecord) error {
+	return w.encoder.Encode(record)
+}
+
+// Flush writes any buffered data
+func (w *NDJSONWriter) Flush() error {
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	flushUnderlying(w.out)
+	return nil
+}
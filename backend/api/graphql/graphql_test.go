@@ -0,0 +1,431 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"ecom-backend/application/service"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/domain/value"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// productRepo is an in-memory product repository that counts batch lookups
+type productRepo struct {
+	products map[string]*entity.Product
+	batches  int
+	lookups  int
+}
+
+func (m *productRepo) Save(ctx context.Context, p *entity.Product) error {
+	m.products[p.ID()] = p
+	return nil
+}
+
+func (m *productRepo) FindByID(ctx context.Context, id string) (*entity.Product, error) {
+	m.lookups++
+	if p, ok := m.products[id]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("product %w", repository.ErrNotFound)
+}
+
+func (m *productRepo) FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error) {
+	m.batches++
+	products := make([]*entity.Product, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := m.products[id]; ok {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+func (m *productRepo) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0, len(m.products))
+	for _, p := range m.products {
+		products = append(products, p)
+	}
+	return products, nil
+}
+
+func (m *productRepo) FindArchived(ctx context.Context) ([]*entity.Product, error) { return nil, nil }
+func (m *productRepo) FindBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	return nil, fmt.Errorf("product %w", repository.ErrNotFound)
+}
+func (m *productRepo) FindAfter(ctx context.Context, afterID string, limit int) ([]*entity.Product, error) {
+	return nil, nil
+}
+func (m *productRepo) FindLowStock(ctx context.Context) ([]*entity.Product, error) {
+	return nil, nil
+}
+func (m *productRepo) Update(ctx context.Context, p *entity.Product) error { return m.Save(ctx, p) }
+func (m *productRepo) Archive(ctx context.Context, p *entity.Product) (int, error) {
+	return 0, m.Save(ctx, p)
+}
+func (m *productRepo) AddVariant(ctx context.Context, productID string, v *entity.ProductVariant) error {
+	return nil
+}
+func (m *productRepo) UpdateVariant(ctx context.Context, productID string, v *entity.ProductVariant) error {
+	return nil
+}
+func (m *productRepo) UpdateVariantStock(ctx context.Context, productID string, v *entity.ProductVariant) error {
+	return nil
+}
+func (m *productRepo) RemoveVariant(ctx context.Context, productID, variantID string) error {
+	return nil
+}
+func (m *productRepo) UpdateReorderPolicy(ctx context.Context, p *entity.Product) error {
+	return m.Save(ctx, p)
+}
+func (m *productRepo) AddImage(ctx context.Context, productID string, img *entity.ProductImage) error {
+	return nil
+}
+func (m *productRepo) DeleteImage(ctx context.Context, productID, imageID string) error { return nil }
+func (m *productRepo) ReorderImages(ctx context.Context, productID string, imageIDs []string) error {
+	return nil
+}
+func (m *productRepo) SetPrimaryImage(ctx context.Context, productID, imageID string) error {
+	return nil
+}
+func (m *productRepo) Delete(ctx context.Context, id string) error {
+	delete(m.products, id)
+	return nil
+}
+func (m *productRepo) ExistsByID(ctx context.Context, id string) (bool, error) {
+	_, ok := m.products[id]
+	return ok, nil
+}
+func (m *productRepo) IsReferencedByOrders(ctx context.Context, id string) (bool, error) {
+	return false, nil
+}
+func (m *productRepo) ExistsBySKU(ctx context.Context, sku string) (bool, error) { return false, nil }
+
+// basketRepo is an in-memory basket repository
+type basketRepo struct {
+	baskets map[string]*entity.Basket
+}
+
+func (m *basketRepo) Save(ctx context.Context, b *entity.Basket) error {
+	m.baskets[b.ID()] = b
+	return nil
+}
+func (m *basketRepo) FindByID(ctx context.Context, id string) (*entity.Basket, error) {
+	if b, ok := m.baskets[id]; ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("basket %w", repository.ErrNotFound)
+}
+func (m *basketRepo) Update(ctx context.Context, b *entity.Basket) error { return m.Save(ctx, b) }
+func (m *basketRepo) Delete(ctx context.Context, id string) error {
+	delete(m.baskets, id)
+	return nil
+}
+func (m *basketRepo) UpdateMerged(ctx context.Context, b *entity.Basket, mergedID string) error {
+	if _, ok := m.baskets[mergedID]; !ok {
+		return fmt.Errorf("basket %w", repository.ErrNotFound)
+	}
+	delete(m.baskets, mergedID)
+	m.baskets[b.ID()] = b
+	return nil
+}
+func (m *basketRepo) DeleteIdle(ctx context.Context, before time.Time, limit int) ([]*entity.Basket, error) {
+	return nil, nil
+}
+func (m *basketRepo) ExistsByID(ctx context.Context, id string) (bool, error) {
+	_, ok := m.baskets[id]
+	return ok, nil
+}
+
+type fixture struct {
+	handler  *Handler
+	products *productRepo
+	baskets  *basketRepo
+	basketID string
+}
+
+// newFixture serves a basket holding three different products
+func newFixture(t *testing.T, limits Limits) *fixture {
+	t.Helper()
+	products := &productRepo{products: make(map[string]*entity.Product)}
+	baskets := &basketRepo{baskets: make(map[string]*entity.Basket)}
+
+	basket := entity.NewBasket()
+	for _, name := range []string{"Mug", "Shirt", "Poster"} {
+		price, _ := value.NewMoney(1500, "USD")
+		stock, _ := value.NewQuantity(10)
+		p, err := entity.NewProduct(name, "", price, stock)
+		if err != nil {
+			t.Fatalf("NewProduct: %v", err)
+		}
+		products.products[p.ID()] = p
+
+		qty, _ := value.NewQuantity(2)
+		if err := basket.AddVariantItem(p.ID(), "", qty, price); err != nil {
+			t.Fatalf("AddVariantItem: %v", err)
+		}
+	}
+	baskets.baskets[basket.ID()] = basket
+
+	metrics := service.NopMetrics{}
+	h, err := NewHandler(
+		service.NewProductService(products, nil, service.NopEvents{}, service.NopAlerts{}),
+		service.NewBasketService(baskets, products, metrics, service.NopEvents{}),
+		service.NewOrderService(nil, baskets, products, metrics, service.NopEvents{}, service.NopAlerts{}),
+		service.NewSearchService(nil),
+		limits,
+	)
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+	return &fixture{handler: h, products: products, baskets: baskets, basketID: basket.ID()}
+}
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func (f *fixture) post(t *testing.T, query string, variables map[string]interface{}) (int, response) {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return f.serve(t, req)
+}
+
+func (f *fixture) serve(t *testing.T, req *http.Request) (int, response) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	f.handler.ServeHTTP(w, req)
+
+	var resp response
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	return w.Code, resp
+}
+
+var defaultLimits = Limits{MaxDepth: 10, MaxComplexity: 2000}
+
+func TestBasketItemsLoadProductsInOneBatch(t *testing.T) {
+	f := newFixture(t, defaultLimits)
+
+	code, resp := f.post(t, `query($id: ID!) { basket(id: $id) { itemCount items { quantity priceChanged outOfStock product { name price } } } }`,
+		map[string]interface{}{"id": f.basketID})
+	if code != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("status %d, errors %+v", code, resp.Errors)
+	}
+
+	var basket struct {
+		ItemCount int `json:"itemCount"`
+		Items     []struct {
+			PriceChanged bool `json:"priceChanged"`
+			OutOfStock   bool `json:"outOfStock"`
+			Product      struct {
+				Name string `json:"name"`
+			} `json:"product"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(resp.Data["basket"], &basket); err != nil {
+		t.Fatal(err)
+	}
+	if len(basket.Items) != 3 || basket.ItemCount != 6 {
+		t.Fatalf("got %d items, count %d", len(basket.Items), basket.ItemCount)
+	}
+	for _, item := range basket.Items {
+		if item.Product.Name == "" {
+			t.Errorf("item product was not resolved: %+v", item)
+		}
+		if item.PriceChanged || item.OutOfStock {
+			t.Errorf("expected an unchanged, in-stock item: %+v", item)
+		}
+	}
+
+	// One batch for the basket service's product details and one for the loader,
+	// however many items the basket holds
+	if f.products.batches != 2 {
+		t.Errorf("expected 2 product batches, got %d", f.products.batches)
+	}
+	if f.products.lookups != 0 {
+		t.Errorf("expected no single product lookups, got %d", f.products.lookups)
+	}
+}
+
+func TestMergeBaskets(t *testing.T) {
+	f := newFixture(t, defaultLimits)
+
+	// A guest basket holding more of the first product than is in stock
+	guest := entity.NewBasket()
+	item := f.baskets.baskets[f.basketID].Items()[0]
+	qty, _ := value.NewQuantity(9)
+	guest.AddItem(item.ProductID(), qty, item.Price())
+	f.baskets.baskets[guest.ID()] = guest
+
+	code, resp := f.post(t, `mutation($id: ID!, $guest: ID!) {
+		mergeBaskets(basketId: $id, sourceBasketId: $guest) {
+			strategy basket { itemCount } adjustments { productId reason requested quantity stock }
+		}
+	}`, map[string]interface{}{"id": f.basketID, "guest": guest.ID()})
+	if code != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("status %d, errors %+v", code, resp.Errors)
+	}
+
+	var merged struct {
+		Strategy string `json:"strategy"`
+		Basket   struct {
+			ItemCount int `json:"itemCount"`
+		} `json:"basket"`
+		Adjustments []struct {
+			ProductID string `json:"productId"`
+			Reason    string `json:"reason"`
+			Requested int    `json:"requested"`
+			Quantity  int    `json:"quantity"`
+		} `json:"adjustments"`
+	}
+	if err := json.Unmarshal(resp.Data["mergeBaskets"], &merged); err != nil {
+		t.Fatal(err)
+	}
+	if merged.Strategy != "sum" || merged.Basket.ItemCount != 14 {
+		t.Errorf("expected 11 units limited to 10 plus 4 others, got %+v", merged)
+	}
+	if len(merged.Adjustments) != 1 || merged.Adjustments[0].Reason != "limited_by_stock" ||
+		merged.Adjustments[0].Requested != 11 || merged.Adjustments[0].Quantity != 10 {
+		t.Errorf("unexpected adjustments %+v", merged.Adjustments)
+	}
+	if _, ok := f.baskets.baskets[guest.ID()]; ok {
+		t.Error("expected the guest basket to be deleted")
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		limits Limits
+		query  string
+		code   string
+	}{
+		{
+			name:   "too deep",
+			limits: Limits{MaxDepth: 3, MaxComplexity: 2000},
+			query:  `{ basket(id: "x") { items { product { variants { options { name } } } } } }`,
+			code:   "QUERY_TOO_DEEP",
+		},
+		{
+			name:   "too complex",
+			limits: Limits{MaxDepth: 10, MaxComplexity: 50},
+			query:  `{ products { id variants { id sku } } }`,
+			code:   "QUERY_TOO_COMPLEX",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, tt.limits)
+			code, resp := f.post(t, tt.query, nil)
+			if code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", code)
+			}
+			if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != tt.code {
+				t.Fatalf("expected %s error, got %+v", tt.code, resp.Errors)
+			}
+		})
+	}
+
+	t.Run("within limits", func(t *testing.T) {
+		f := newFixture(t, Limits{MaxDepth: 3, MaxComplexity: 50})
+		code, resp := f.post(t, `{ products { id name } }`, nil)
+		if code != http.StatusOK || len(resp.Errors) > 0 {
+			t.Fatalf("status %d, errors %+v", code, resp.Errors)
+		}
+	})
+}
+
+func TestCreateProduct(t *testing.T) {
+	f := newFixture(t, defaultLimits)
+
+	code, resp := f.post(t, `mutation($input: CreateProductInput!) { createProduct(input: $input) { id name price currency stock } }`,
+		map[string]interface{}{"input": map[string]interface{}{"name": "Lamp", "price": 4999, "currency": "EUR", "stock": 3}})
+	if code != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("status %d, errors %+v", code, resp.Errors)
+	}
+
+	var product struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Price    int    `json:"price"`
+		Currency string `json:"currency"`
+	}
+	if err := json.Unmarshal(resp.Data["createProduct"], &product); err != nil {
+		t.Fatal(err)
+	}
+	if product.Name != "Lamp" || product.Price != 4999 || product.Currency != "EUR" {
+		t.Errorf("unexpected product %+v", product)
+	}
+	if _, ok := f.products.products[product.ID]; !ok {
+		t.Error("product was not saved")
+	}
+}
+
+func TestValidationErrorExtensions(t *testing.T) {
+	f := newFixture(t, defaultLimits)
+
+	code, resp := f.post(t, `mutation { createProduct(input: {name: "Lamp", price: -1, currency: "euro"}) { id } }`, nil)
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if len(resp.Errors) != 1 {
+		t.Fatalf("expected one error, got %+v", resp.Errors)
+	}
+
+	ext := resp.Errors[0].Extensions
+	if ext["code"] != "VALIDATION_FAILED" {
+		t.Fatalf("expected VALIDATION_FAILED, got %v", ext["code"])
+	}
+	fields, _ := ext["fields"].([]interface{})
+	if len(fields) != 2 {
+		t.Fatalf("expected 2 field errors, got %v", ext["fields"])
+	}
+}
+
+func TestGetRequests(t *testing.T) {
+	f := newFixture(t, defaultLimits)
+
+	t.Run("query", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/graphql?query="+url.QueryEscape(`{ products { name } }`), nil)
+		code, resp := f.serve(t, req)
+		if code != http.StatusOK || len(resp.Errors) > 0 {
+			t.Fatalf("status %d, errors %+v", code, resp.Errors)
+		}
+	})
+
+	t.Run("mutation is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/graphql?query="+url.QueryEscape(`mutation { createBasket { id } }`), nil)
+		w := httptest.NewRecorder()
+		f.handler.ServeHTTP(w, req)
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("expected 405, got %d", w.Code)
+		}
+		if allow := w.Header().Get("Allow"); allow != http.MethodPost {
+			t.Errorf("expected Allow: POST, got %q", allow)
+		}
+	})
+}
+
+func TestInvalidDocument(t *testing.T) {
+	f := newFixture(t, defaultLimits)
+
+	code, resp := f.post(t, `{ products { nope } }`, nil)
+	if code != http.StatusBadRequest || len(resp.Errors) == 0 {
+		t.Fatalf("expected 400 with errors, got %d %+v", code, resp.Errors)
+	}
+}
@@ -0,0 +1,193 @@
+// Package graphql serves a GraphQL API over the application services.
+package graphql
+
+import (
+	"ecom-backend/application/service"
+	"ecom-backend/pkg/tracing"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// maxBodyBytes caps the size of a GraphQL request body
+const maxBodyBytes = 1 << 20
+
+// Handler serves GraphQL requests
+type Handler struct {
+	schema   gql.Schema
+	products *service.ProductService
+	limits   Limits
+}
+
+// NewHandler creates a Handler resolving operations with the given services
+func NewHandler(
+	productService *service.ProductService,
+	basketService *service.BasketService,
+	orderService *service.OrderService,
+	searchService *service.SearchService,
+	limits Limits,
+) (*Handler, error) {
+	schema, err := newSchema(&resolver{
+		products: productService,
+		baskets:  basketService,
+		orders:   orderService,
+		search:   searchService,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build GraphQL schema: %w", err)
+	}
+
+	return &Handler{schema: schema, products: productService, limits: limits}, nil
+}
+
+// request is a GraphQL request as sent over HTTP
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	Extensions    map[string]interface{} `json:"extensions"`
+}
+
+// ServeHTTP handles GET and POST GraphQL requests. Queries may use either method;
+// mutations require POST.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := parseRequest(w, r)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			respondWithErrors(w, http.StatusRequestEntityTooLarge, gqlerrors.NewFormattedError("request body is too large"))
+			return
+		}
+		respondWithErrors(w, http.StatusBadRequest, gqlerrors.NewFormattedError(err.Error()))
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		respondWithErrors(w, http.StatusBadRequest, gqlerrors.FormatError(err))
+		return
+	}
+
+	if validation := gql.ValidateDocument(&h.schema, doc, nil); !validation.IsValid {
+		respondWithErrors(w, http.StatusBadRequest, validation.Errors...)
+		return
+	}
+
+	op, err := operation(doc, req.OperationName)
+	if err != nil {
+		respondWithErrors(w, http.StatusBadRequest, gqlerrors.NewFormattedError(err.Error()))
+		return
+	}
+	if r.Method == http.MethodGet && op.Operation != ast.OperationTypeQuery {
+		w.Header().Set("Allow", http.MethodPost)
+		respondWithErrors(w, http.StatusMethodNotAllowed, gqlerrors.NewFormattedError("mutations must be sent with POST"))
+		return
+	}
+
+	c, err := checkLimits(h.schema, doc, op, h.limits)
+	if err != nil {
+		// FormatError only copies extensions from wrapped errors, so add the limit's code here
+		formatted := gqlerrors.FormatError(err)
+		var extended gqlerrors.ExtendedError
+		if errors.As(err, &extended) {
+			formatted.Extensions = extended.Extensions()
+		}
+		respondWithErrors(w, http.StatusBadRequest, formatted)
+		return
+	}
+
+	opName := ""
+	if op.Name != nil {
+		opName = op.Name.Value
+	}
+	ctx, span := tracing.Start(r.Context(), "GraphQL "+op.Operation,
+		tracing.WithAttributes(
+			"graphql.operation.type", op.Operation,
+			"graphql.operation.name", opName,
+			"graphql.depth", c.depth,
+			"graphql.complexity", c.complexity,
+		))
+	defer span.End()
+
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, newLoaders(ctx, h.products)),
+	})
+	if result.HasErrors() {
+		span.SetAttribute("graphql.errors", len(result.Errors))
+	}
+
+	respondWithResult(w, http.StatusOK, result)
+}
+
+// parseRequest reads a request from the query string (GET) or body (POST)
+func parseRequest(w http.ResponseWriter, r *http.Request) (*request, error) {
+	req := &request{}
+
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				return nil, errors.New("variables must be a JSON object")
+			}
+		}
+
+	case http.MethodPost:
+		body := http.MaxBytesReader(w, r.Body, maxBodyBytes)
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "application/graphql":
+			query, err := io.ReadAll(body)
+			if err != nil {
+				return nil, err
+			}
+			req.Query = string(query)
+		case "application/json", "":
+			if err := json.NewDecoder(body).Decode(req); err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					return nil, err
+				}
+				return nil, errors.New("request body must be a JSON object with a query")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported Content-Type %q: use application/json or application/graphql", mediaType)
+		}
+
+	default:
+		return nil, fmt.Errorf("method %s is not supported", r.Method)
+	}
+
+	if req.Query == "" {
+		return nil, errors.New("query is required")
+	}
+	return req, nil
+}
+
+// respondWithErrors sends a response carrying only errors
+func respondWithErrors(w http.ResponseWriter, code int, errs ...gqlerrors.FormattedError) {
+	respondWithResult(w, code, &gql.Result{Errors: errs})
+}
+
+func respondWithResult(w http.ResponseWriter, code int, result *gql.Result) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(result)
+}
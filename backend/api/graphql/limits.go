@@ -0,0 +1,183 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limits bound the cost of a single operation
+type Limits struct {
+	// MaxDepth is the deepest field nesting allowed; root fields are at depth 1
+	MaxDepth int
+
+	// MaxComplexity is the highest allowed estimated cost. Every field costs 1 and the
+	// cost of a list field's selections is multiplied by listMultiplier.
+	MaxComplexity int
+}
+
+// listMultiplier is the number of items assumed for a list field when estimating cost
+const listMultiplier = 10
+
+// limitError rejects an operation that exceeds a limit
+type limitError struct {
+	code    string
+	message string
+}
+
+func (e limitError) Error() string { return e.message }
+
+// Extensions implements gqlerrors.ExtendedError
+func (e limitError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// cost is the measured size of a selection set
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// analyzer measures an operation against the schema. Introspection fields are not counted.
+type analyzer struct {
+	schema    gql.Schema
+	fragments map[string]*ast.FragmentDefinition
+}
+
+// checkLimits measures op and returns a limitError when it exceeds limits. Zero limits are not enforced.
+func checkLimits(schema gql.Schema, doc *ast.Document, op *ast.OperationDefinition, limits Limits) (cost, error) {
+	a := &analyzer{schema: schema, fragments: make(map[string]*ast.FragmentDefinition)}
+	for _, def := range doc.Definitions {
+		if frag, ok := def.(*ast.FragmentDefinition); ok {
+			a.fragments[frag.Name.Value] = frag
+		}
+	}
+
+	root := schema.QueryType()
+	if op.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+
+	c := a.measure(op.SelectionSet, root, 1, map[string]bool{})
+	if limits.MaxDepth > 0 && c.depth > limits.MaxDepth {
+		return c, limitError{code: "QUERY_TOO_DEEP", message: fmt.Sprintf("query depth %d exceeds the limit of %d", c.depth, limits.MaxDepth)}
+	}
+	if limits.MaxComplexity > 0 && c.complexity > limits.MaxComplexity {
+		return c, limitError{code: "QUERY_TOO_COMPLEX", message: fmt.Sprintf("query complexity %d exceeds the limit of %d", c.complexity, limits.MaxComplexity)}
+	}
+	return c, nil
+}
+
+// measure returns the depth and complexity of the fields selected on parent at depth
+func (a *analyzer) measure(set *ast.SelectionSet, parent *gql.Object, depth int, visiting map[string]bool) cost {
+	var total cost
+	if set == nil || parent == nil {
+		return total
+	}
+
+	add := func(c cost) {
+		total.complexity += c.complexity
+		if c.depth > total.depth {
+			total.depth = c.depth
+		}
+	}
+
+	for _, selection := range set.Selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			name := sel.Name.Value
+			if strings.HasPrefix(name, "__") {
+				continue
+			}
+			def, ok := parent.Fields()[name]
+			if !ok {
+				continue
+			}
+
+			named, isList := unwrap(def.Type)
+			field := cost{depth: depth, complexity: 1}
+			if obj, ok := named.(*gql.Object); ok && sel.SelectionSet != nil {
+				child := a.measure(sel.SelectionSet, obj, depth+1, visiting)
+				if isList {
+					child.complexity *= listMultiplier
+				}
+				field.complexity += child.complexity
+				if child.depth > field.depth {
+					field.depth = child.depth
+				}
+			}
+			add(field)
+
+		case *ast.InlineFragment:
+			add(a.measure(sel.SelectionSet, a.typeCondition(sel.TypeCondition, parent), depth, visiting))
+
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			frag, ok := a.fragments[name]
+			if !ok || visiting[name] {
+				continue
+			}
+			visiting[name] = true
+			add(a.measure(frag.SelectionSet, a.typeCondition(frag.TypeCondition, parent), depth, visiting))
+			delete(visiting, name)
+		}
+	}
+
+	return total
+}
+
+// typeCondition returns the object type a fragment applies to
+func (a *analyzer) typeCondition(cond *ast.Named, parent *gql.Object) *gql.Object {
+	if cond == nil {
+		return parent
+	}
+	if obj, ok := a.schema.Type(cond.Name.Value).(*gql.Object); ok {
+		return obj
+	}
+	return parent
+}
+
+// unwrap strips non-null and list wrappers, reporting whether the type is a list
+func unwrap(t gql.Type) (gql.Type, bool) {
+	isList := false
+	for {
+		switch wrapped := t.(type) {
+		case *gql.NonNull:
+			t = wrapped.OfType
+		case *gql.List:
+			isList = true
+			t = wrapped.OfType
+		default:
+			return t, isList
+		}
+	}
+}
+
+// operation returns the operation to execute: the one named, or the only one in the document
+func operation(doc *ast.Document, name string) (*ast.OperationDefinition, error) {
+	var found *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if found != nil {
+				return nil, errors.New("operationName is required when the document contains several operations")
+			}
+			found = op
+		} else if op.Name != nil && op.Name.Value == name {
+			found = op
+		}
+	}
+	if found == nil {
+		if name != "" {
+			return nil, fmt.Errorf("unknown operation %q", name)
+		}
+		return nil, errors.New("the document contains no operation")
+	}
+	return found, nil
+}
@@ -0,0 +1,31 @@
+package graphql
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"ecom-backend/pkg/dataloader"
+)
+
+// loaders batch the lookups made while resolving one request
+type loaders struct {
+	products *dataloader.Loader[string, *dto.ProductResponse]
+}
+
+type loadersKey struct{}
+
+func newLoaders(ctx context.Context, products *service.ProductService) *loaders {
+	return &loaders{
+		products: dataloader.New(ctx, products.GetProductsByIDs),
+	}
+}
+
+// withLoaders returns a context carrying l
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// loadersFrom returns the request's loaders
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
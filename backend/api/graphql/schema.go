@@ -0,0 +1,446 @@
+package graphql
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"ecom-backend/pkg/validate"
+	"errors"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+// resolver resolves queries and mutations with the application services
+type resolver struct {
+	products *service.ProductService
+	baskets  *service.BasketService
+	orders   *service.OrderService
+	search   *service.SearchService
+}
+
+// newSchema builds the GraphQL schema over the application services
+func newSchema(r *resolver) (gql.Schema, error) {
+	t := newTypes()
+
+	optionInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "ProductOptionInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"name":   &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"values": &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(gql.String)))},
+		},
+	})
+	variantOptionInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "VariantOptionInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"name":  &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"value": &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+		},
+	})
+	variantInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "VariantInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"sku":     &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"barcode": &gql.InputObjectFieldConfig{Type: gql.String},
+			"options": &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(variantOptionInput)))},
+			"price":   &gql.InputObjectFieldConfig{Type: gql.Int, Description: "Price override in cents"},
+			"stock":   &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+	createProductInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "CreateProductInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"sku":         &gql.InputObjectFieldConfig{Type: gql.String},
+			"name":        &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"description": &gql.InputObjectFieldConfig{Type: gql.String},
+			"category":    &gql.InputObjectFieldConfig{Type: gql.String},
+			"price":       &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.Int), Description: "Price in cents"},
+			"currency":    &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"stock":       &gql.InputObjectFieldConfig{Type: gql.Int},
+			"options":     &gql.InputObjectFieldConfig{Type: gql.NewList(gql.NewNonNull(optionInput))},
+			"variants":    &gql.InputObjectFieldConfig{Type: gql.NewList(gql.NewNonNull(variantInput))},
+		},
+	})
+	updateProductInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "UpdateProductInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"name":        &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"description": &gql.InputObjectFieldConfig{Type: gql.String},
+			"category":    &gql.InputObjectFieldConfig{Type: gql.String, Description: "Omit to keep the current category"},
+			"price":       &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.Int), Description: "Price in cents"},
+			"currency":    &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+		},
+	})
+	addItemInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "AddBasketItemInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"productId": &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.ID)},
+			"variantId": &gql.InputObjectFieldConfig{Type: gql.ID},
+			"quantity":  &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+
+	id := &gql.ArgumentConfig{Type: gql.NewNonNull(gql.ID)}
+	basketID := gql.FieldConfigArgument{"basketId": id}
+	byID := gql.FieldConfigArgument{"id": id}
+
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"product": &gql.Field{
+				Type: t.product,
+				Args: byID,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return result(r.products.GetProduct(p.Context, str(p.Args, "id")))
+				},
+			},
+			"products": &gql.Field{
+				Type:        gql.NewNonNull(gql.NewList(gql.NewNonNull(t.product))),
+				Description: "Active products",
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return result(r.products.GetAllProducts(p.Context))
+				},
+			},
+			"searchProducts": &gql.Field{
+				Type: gql.NewNonNull(t.searchResult),
+				Args: gql.FieldConfigArgument{
+					"query":    &gql.ArgumentConfig{Type: gql.String},
+					"category": &gql.ArgumentConfig{Type: gql.String},
+					"minPrice": &gql.ArgumentConfig{Type: gql.Int},
+					"maxPrice": &gql.ArgumentConfig{Type: gql.Int},
+					"inStock":  &gql.ArgumentConfig{Type: gql.Boolean},
+					"limit":    &gql.ArgumentConfig{Type: gql.Int},
+					"offset":   &gql.ArgumentConfig{Type: gql.Int},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					req := &dto.ProductSearchRequest{
+						Query:    str(p.Args, "query"),
+						Category: str(p.Args, "category"),
+						MinPrice: int64Ptr(p.Args, "minPrice"),
+						MaxPrice: int64Ptr(p.Args, "maxPrice"),
+						Limit:    integer(p.Args, "limit"),
+						Offset:   integer(p.Args, "offset"),
+					}
+					if v, ok := p.Args["inStock"].(bool); ok {
+						req.InStock = &v
+					}
+					return result(r.search.SearchProducts(p.Context, req))
+				},
+			},
+			"basket": &gql.Field{
+				Type: t.basket,
+				Args: byID,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return result(r.baskets.GetBasket(p.Context, str(p.Args, "id")))
+				},
+			},
+			"basketRevalidation": &gql.Field{
+				Type:        t.basketRevalidation,
+				Description: "Compare basket items with current prices and stock before checkout",
+				Args:        byID,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return result(r.baskets.RevalidateBasket(p.Context, str(p.Args, "id")))
+				},
+			},
+			"order": &gql.Field{
+				Type: t.order,
+				Args: byID,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return result(r.orders.GetOrder(p.Context, str(p.Args, "id")))
+				},
+			},
+			"orders": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(t.order))),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return result(r.orders.GetAllOrders(p.Context))
+				},
+			},
+		},
+	})
+
+	// orderTransition builds a mutation that moves an order to its next status
+	orderTransition := func(description string, fn func(context.Context, string) (*dto.OrderResponse, error)) *gql.Field {
+		return &gql.Field{
+			Type:        gql.NewNonNull(t.order),
+			Description: description,
+			Args:        byID,
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return result(fn(p.Context, str(p.Args, "id")))
+			},
+		}
+	}
+
+	mutation := gql.NewObject(gql.ObjectConfig{
+		Name: "Mutation",
+		Fields: gql.Fields{
+			"createProduct": &gql.Field{
+				Type: gql.NewNonNull(t.product),
+				Args: gql.FieldConfigArgument{"input": &gql.ArgumentConfig{Type: gql.NewNonNull(createProductInput)}},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return result(r.products.CreateProduct(p.Context, newCreateProductRequest(obj(p.Args, "input"))))
+				},
+			},
+			"updateProduct": &gql.Field{
+				Type: gql.NewNonNull(t.product),
+				Args: gql.FieldConfigArgument{"id": id, "input": &gql.ArgumentConfig{Type: gql.NewNonNull(updateProductInput)}},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					in := obj(p.Args, "input")
+					req := &dto.UpdateProductRequest{
+						Name:        str(in, "name"),
+						Description: str(in, "description"),
+						Price:       int64(integer(in, "price")),
+						Currency:    str(in, "currency"),
+					}
+					if v, ok := in["category"].(string); ok {
+						req.Category = &v
+					}
+					return result(r.products.UpdateProduct(p.Context, str(p.Args, "id"), req))
+				},
+			},
+			"updateStock": &gql.Field{
+				Type: gql.NewNonNull(t.product),
+				Args: gql.FieldConfigArgument{"id": id, "stock": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int)}},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					req := &dto.UpdateStockRequest{Stock: integer(p.Args, "stock")}
+					return result(r.products.UpdateStock(p.Context, str(p.Args, "id"), req))
+				},
+			},
+			"deleteProduct": &gql.Field{
+				Type:        gql.NewNonNull(gql.Boolean),
+				Description: "Archive a product and remove it from baskets",
+				Args:        byID,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					if err := r.products.DeleteProduct(p.Context, str(p.Args, "id")); err != nil {
+						return nil, resolveError(err)
+					}
+					return true, nil
+				},
+			},
+			"createBasket": &gql.Field{
+				Type: gql.NewNonNull(t.basket),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return result(r.baskets.CreateBasket(p.Context))
+				},
+			},
+			"addBasketItem": &gql.Field{
+				Type: gql.NewNonNull(t.basket),
+				Args: gql.FieldConfigArgument{"basketId": id, "input": &gql.ArgumentConfig{Type: gql.NewNonNull(addItemInput)}},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					in := obj(p.Args, "input")
+					req := &dto.AddItemRequest{
+						ProductID: str(in, "productId"),
+						VariantID: str(in, "variantId"),
+						Quantity:  integer(in, "quantity"),
+					}
+					return result(r.baskets.AddItem(p.Context, str(p.Args, "basketId"), req))
+				},
+			},
+			"updateBasketItem": &gql.Field{
+				Type:        gql.NewNonNull(t.basket),
+				Description: "Change an item's quantity; zero removes it",
+				Args: gql.FieldConfigArgument{
+					"basketId":  id,
+					"productId": id,
+					"variantId": &gql.ArgumentConfig{Type: gql.ID},
+					"quantity":  &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int)},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					req := &dto.UpdateItemQuantityRequest{Quantity: integer(p.Args, "quantity")}
+					return result(r.baskets.UpdateItemQuantity(p.Context,
+						str(p.Args, "basketId"), str(p.Args, "productId"), str(p.Args, "variantId"), req))
+				},
+			},
+			"removeBasketItem": &gql.Field{
+				Type: gql.NewNonNull(t.basket),
+				Args: gql.FieldConfigArgument{
+					"basketId":  id,
+					"productId": id,
+					"variantId": &gql.ArgumentConfig{Type: gql.ID},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return result(r.baskets.RemoveItem(p.Context,
+						str(p.Args, "basketId"), str(p.Args, "productId"), str(p.Args, "variantId")))
+				},
+			},
+			"clearBasket": &gql.Field{
+				Type: gql.NewNonNull(t.basket),
+				Args: basketID,
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return result(r.baskets.ClearBasket(p.Context, str(p.Args, "basketId")))
+				},
+			},
+			"mergeBaskets": &gql.Field{
+				Type:        gql.NewNonNull(t.basketMerge),
+				Description: "Merge a basket, such as a guest basket at login, into another and delete it. Strategy is sum (default), max or newest.",
+				Args: gql.FieldConfigArgument{
+					"basketId":       id,
+					"sourceBasketId": id,
+					"strategy":       &gql.ArgumentConfig{Type: gql.String},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					req := &dto.MergeBasketRequest{
+						SourceBasketID: str(p.Args, "sourceBasketId"),
+						Strategy:       str(p.Args, "strategy"),
+					}
+					return result(r.baskets.MergeBaskets(p.Context, str(p.Args, "basketId"), req))
+				},
+			},
+			"createOrder": &gql.Field{
+				Type:        gql.NewNonNull(t.order),
+				Description: "Check out a basket. A basket with changed prices needs confirmedTotal set to its revalidated total.",
+				Args: gql.FieldConfigArgument{
+					"basketId":       id,
+					"confirmedTotal": &gql.ArgumentConfig{Type: gql.Int},
+				},
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					req := &dto.CreateOrderRequest{
+						BasketID:       str(p.Args, "basketId"),
+						ConfirmedTotal: int64Ptr(p.Args, "confirmedTotal"),
+					}
+					return result(r.orders.CreateOrder(p.Context, req))
+				},
+			},
+			"confirmOrder": orderTransition("Confirm a pending order", r.orders.ConfirmOrder),
+			"shipOrder":    orderTransition("Mark a confirmed order as shipped", r.orders.ShipOrder),
+			"deliverOrder": orderTransition("Mark a shipped order as delivered", r.orders.DeliverOrder),
+			"cancelOrder":  orderTransition("Cancel an order", r.orders.CancelOrder),
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// newCreateProductRequest converts a CreateProductInput into a request
+func newCreateProductRequest(in map[string]interface{}) *dto.CreateProductRequest {
+	req := &dto.CreateProductRequest{
+		SKU:         str(in, "sku"),
+		Name:        str(in, "name"),
+		Description: str(in, "description"),
+		Category:    str(in, "category"),
+		Price:       int64(integer(in, "price")),
+		Currency:    str(in, "currency"),
+		Stock:       integer(in, "stock"),
+	}
+	for _, o := range objs(in, "options") {
+		option := dto.ProductOptionRequest{Name: str(o, "name")}
+		for _, v := range o["values"].([]interface{}) {
+			option.Values = append(option.Values, v.(string))
+		}
+		req.Options = append(req.Options, option)
+	}
+	for _, v := range objs(in, "variants") {
+		variant := dto.AddVariantRequest{
+			SKU:     str(v, "sku"),
+			Barcode: str(v, "barcode"),
+			Options: make(map[string]string),
+			Price:   int64Ptr(v, "price"),
+			Stock:   integer(v, "stock"),
+		}
+		for _, o := range objs(v, "options") {
+			variant.Options[str(o, "name")] = str(o, "value")
+		}
+		req.Variants = append(req.Variants, variant)
+	}
+	return req
+}
+
+// Argument helpers; the executor has already coerced arguments to their declared types
+
+func str(args map[string]interface{}, key string) string {
+	v, _ := args[key].(string)
+	return v
+}
+
+func integer(args map[string]interface{}, key string) int {
+	v, _ := args[key].(int)
+	return v
+}
+
+func int64Ptr(args map[string]interface{}, key string) *int64 {
+	v, ok := args[key].(int)
+	if !ok {
+		return nil
+	}
+	n := int64(v)
+	return &n
+}
+
+func obj(args map[string]interface{}, key string) map[string]interface{} {
+	v, _ := args[key].(map[string]interface{})
+	return v
+}
+
+func objs(args map[string]interface{}, key string) []map[string]interface{} {
+	items, _ := args[key].([]interface{})
+	out := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// result adapts a service call to a resolver result
+func result[T any](v T, err error) (interface{}, error) {
+	if err != nil {
+		return nil, resolveError(err)
+	}
+	return v, nil
+}
+
+// validationError exposes field errors in the GraphQL error's extensions
+type validationError struct {
+	errs validate.Errors
+}
+
+func (e validationError) Error() string {
+	return "Validation failed: " + e.errs.Error()
+}
+
+// Extensions implements gqlerrors.ExtendedError
+func (e validationError) Extensions() map[string]interface{} {
+	fields := make([]map[string]string, len(e.errs))
+	for i, fe := range e.errs {
+		fields[i] = map[string]string{"field": camelCase(fe.Field), "rule": fe.Rule, "message": fe.Message}
+	}
+	return map[string]interface{}{"code": "VALIDATION_FAILED", "fields": fields}
+}
+
+// staleBasketError exposes the revalidated total of a refused checkout in the error's extensions
+type staleBasketError struct {
+	err *service.StaleBasketError
+}
+
+func (e staleBasketError) Error() string {
+	return e.err.Error()
+}
+
+// Extensions implements gqlerrors.ExtendedError
+func (e staleBasketError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "STALE_BASKET", "revalidatedTotal": e.err.Revalidation.RevalidatedTotal}
+}
+
+// resolveError converts a service error into a GraphQL error
+func resolveError(err error) error {
+	var errs validate.Errors
+	if errors.As(err, &errs) {
+		return validationError{errs: errs}
+	}
+	var stale *service.StaleBasketError
+	if errors.As(err, &stale) {
+		return staleBasketError{err: stale}
+	}
+	return err
+}
+
+// camelCase converts a DTO field path such as variants[0].product_id to the schema's naming
+func camelCase(field string) string {
+	parts := strings.Split(field, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,390 @@
+package graphql
+
+import (
+	"ecom-backend/application/dto"
+	"ecom-backend/pkg/dataloader"
+	"errors"
+	"reflect"
+	"sort"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+// Output types mirror the DTOs; field names are the camelCase form of the DTO fields,
+// which graphql-go's default resolver matches case-insensitively.
+
+// keyValue is a map entry exposed as an object, since GraphQL has no map type
+type keyValue struct {
+	Name  string
+	Value string
+}
+
+// rendition is a named image rendition
+type rendition struct {
+	Name   string
+	URL    string
+	Width  int
+	Height int
+}
+
+// types holds the schema's object types
+type types struct {
+	product, productVariant, basket, basketRevalidation, basketMerge, order, searchResult *gql.Object
+}
+
+func newTypes() *types {
+	productOption := gql.NewObject(gql.ObjectConfig{
+		Name: "ProductOption",
+		Fields: gql.Fields{
+			"name":   &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"values": list(gql.String),
+		},
+	})
+
+	variantOption := gql.NewObject(gql.ObjectConfig{
+		Name:        "VariantOption",
+		Description: "The value a variant takes for one product option",
+		Fields: gql.Fields{
+			"name":  &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"value": &gql.Field{Type: gql.NewNonNull(gql.String)},
+		},
+	})
+
+	productVariant := gql.NewObject(gql.ObjectConfig{
+		Name: "ProductVariant",
+		Fields: gql.Fields{
+			"id":      &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"sku":     &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"barcode": &gql.Field{Type: gql.String},
+			"options": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(variantOption))),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return sortedOptions(p.Source.(dto.ProductVariantResponse).Options), nil
+				},
+			},
+			"price":         &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Effective price in cents"},
+			"priceOverride": &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+			"currency":      &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"stock":         &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+
+	imageRendition := gql.NewObject(gql.ObjectConfig{
+		Name: "ImageRendition",
+		Fields: gql.Fields{
+			"name":   &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"url":    &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"width":  &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"height": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+
+	productImage := gql.NewObject(gql.ObjectConfig{
+		Name: "ProductImage",
+		Fields: gql.Fields{
+			"id":          &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"url":         &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"altText":     &gql.Field{Type: gql.String},
+			"contentType": &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"size":        &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Size in bytes"},
+			"width":       &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"height":      &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"primary":     &gql.Field{Type: gql.NewNonNull(gql.Boolean)},
+			"thumbnails": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(imageRendition))),
+				Resolve: func(p gql.ResolveParams) (interface{}, error) {
+					return sortedRenditions(imageOf(p.Source).Thumbnails), nil
+				},
+			},
+		},
+	})
+
+	product := gql.NewObject(gql.ObjectConfig{
+		Name: "Product",
+		Fields: gql.Fields{
+			"id":            &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"sku":           &gql.Field{Type: gql.String},
+			"name":          &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"description":   &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"category":      &gql.Field{Type: gql.String},
+			"price":         &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Price in cents"},
+			"currency":      &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"stock":         &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"options":       list(productOption),
+			"variants":      list(productVariant),
+			"images":        list(productImage),
+			"primaryImage":  &gql.Field{Type: productImage},
+			"archivedAt":    &gql.Field{Type: gql.DateTime},
+			"averageRating": &gql.Field{Type: gql.NewNonNull(gql.Float), Description: "Mean rating of approved reviews, zero without reviews"},
+			"reviewCount":   &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Number of approved reviews"},
+			"createdAt":     &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+			"updatedAt":     &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+		},
+	})
+
+	// Basket and order lines resolve their product through the request's loader, so a
+	// basket with many items costs one product lookup
+	lineFields := func() gql.Fields {
+		return gql.Fields{
+			"productId": &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"variantId": &gql.Field{Type: gql.ID},
+			"quantity":  &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"price":     &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Unit price in cents"},
+			"currency":  &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"subtotal":  &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Subtotal in cents"},
+			"product": &gql.Field{
+				Type:        product,
+				Description: "The product, or null if it has since been purged",
+				Resolve:     resolveLineProduct,
+			},
+			"variant": &gql.Field{
+				Type:        productVariant,
+				Description: "The variant, or null for products without variants",
+				Resolve:     resolveLineVariant,
+			},
+		}
+	}
+
+	basketItemFields := lineFields()
+	basketItemFields["priceChanged"] = &gql.Field{
+		Type:        gql.NewNonNull(gql.Boolean),
+		Description: "Whether the product's current price differs from the price when the item was added",
+	}
+	basketItemFields["outOfStock"] = &gql.Field{
+		Type:        gql.NewNonNull(gql.Boolean),
+		Description: "Whether the quantity can no longer be ordered",
+	}
+	basketItem := gql.NewObject(gql.ObjectConfig{Name: "BasketItem", Fields: basketItemFields})
+	basket := gql.NewObject(gql.ObjectConfig{
+		Name: "Basket",
+		Fields: gql.Fields{
+			"id":        &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"items":     list(basketItem),
+			"total":     &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Total in cents"},
+			"currency":  &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"itemCount": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"createdAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+			"updatedAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+		},
+	})
+
+	basketChange := gql.NewObject(gql.ObjectConfig{
+		Name:        "BasketChange",
+		Description: "A basket item that no longer matches its product",
+		Fields: gql.Fields{
+			"productId": &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"variantId": &gql.Field{Type: gql.ID},
+			"name":      &gql.Field{Type: gql.String, Description: "Null once the product is purged"},
+			"type":      &gql.Field{Type: gql.NewNonNull(gql.String), Description: "price_changed, insufficient_stock or unavailable"},
+			"quantity":  &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"oldPrice":  &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Price in cents in the basket"},
+			"newPrice":  &gql.Field{Type: gql.Int, Description: "Current price in cents, for price_changed"},
+			"currency":  &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"stock":     &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+	basketRevalidation := gql.NewObject(gql.ObjectConfig{
+		Name: "BasketRevalidation",
+		Fields: gql.Fields{
+			"basketId":         &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"changes":          list(basketChange),
+			"stale":            &gql.Field{Type: gql.NewNonNull(gql.Boolean), Description: "Prices changed since items were added"},
+			"canCheckout":      &gql.Field{Type: gql.NewNonNull(gql.Boolean), Description: "Every item can still be ordered"},
+			"total":            &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Basket total in cents"},
+			"revalidatedTotal": &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Total in cents at current prices; pass as confirmedTotal to createOrder"},
+			"currency":         &gql.Field{Type: gql.NewNonNull(gql.String)},
+		},
+	})
+
+	basketMergeAdjustment := gql.NewObject(gql.ObjectConfig{
+		Name:        "BasketMergeAdjustment",
+		Description: "A merged basket item that was reduced or left out",
+		Fields: gql.Fields{
+			"productId": &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"variantId": &gql.Field{Type: gql.ID},
+			"name":      &gql.Field{Type: gql.String, Description: "Null once the product is purged"},
+			"reason":    &gql.Field{Type: gql.NewNonNull(gql.String), Description: "limited_by_stock, out_of_stock or unavailable"},
+			"requested": &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Quantity the strategy asked for"},
+			"quantity":  &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Quantity in the merged basket"},
+			"stock":     &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+	basketMerge := gql.NewObject(gql.ObjectConfig{
+		Name: "BasketMerge",
+		Fields: gql.Fields{
+			"basket":      &gql.Field{Type: gql.NewNonNull(basket)},
+			"strategy":    &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"adjustments": list(basketMergeAdjustment),
+		},
+	})
+
+	orderItemFields := lineFields()
+	orderItemFields["name"] = &gql.Field{Type: gql.NewNonNull(gql.String), Description: "Product name at checkout"}
+	orderItemFields["sku"] = &gql.Field{Type: gql.String, Description: "Product or variant SKU at checkout"}
+	orderItemFields["description"] = &gql.Field{Type: gql.NewNonNull(gql.String), Description: "Product description at checkout"}
+	orderItem := gql.NewObject(gql.ObjectConfig{Name: "OrderItem", Fields: orderItemFields})
+	order := gql.NewObject(gql.ObjectConfig{
+		Name: "Order",
+		Fields: gql.Fields{
+			"id":        &gql.Field{Type: gql.NewNonNull(gql.ID)},
+			"items":     list(orderItem),
+			"total":     &gql.Field{Type: gql.NewNonNull(gql.Int), Description: "Total in cents"},
+			"currency":  &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"status":    &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"createdAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+			"updatedAt": &gql.Field{Type: gql.NewNonNull(gql.DateTime)},
+		},
+	})
+
+	highlights := gql.NewObject(gql.ObjectConfig{
+		Name:        "SearchHighlights",
+		Description: "HTML-escaped snippets with matched terms wrapped in <mark></mark>",
+		Fields: gql.Fields{
+			"name":        &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"description": &gql.Field{Type: gql.NewNonNull(gql.String)},
+		},
+	})
+	searchHit := gql.NewObject(gql.ObjectConfig{
+		Name: "SearchHit",
+		Fields: gql.Fields{
+			"product":    &gql.Field{Type: gql.NewNonNull(product)},
+			"rank":       &gql.Field{Type: gql.NewNonNull(gql.Float)},
+			"highlights": &gql.Field{Type: gql.NewNonNull(highlights)},
+		},
+	})
+	facetCount := gql.NewObject(gql.ObjectConfig{
+		Name: "FacetCount",
+		Fields: gql.Fields{
+			"value": &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"count": &gql.Field{Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+	facets := gql.NewObject(gql.ObjectConfig{
+		Name: "SearchFacets",
+		Fields: gql.Fields{
+			"categories":   list(facetCount),
+			"priceBands":   list(facetCount),
+			"availability": list(facetCount),
+		},
+	})
+	searchResult := gql.NewObject(gql.ObjectConfig{
+		Name: "SearchResult",
+		Fields: gql.Fields{
+			"query":   &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"total":   &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"limit":   &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"offset":  &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"results": list(searchHit),
+			"facets":  &gql.Field{Type: gql.NewNonNull(facets)},
+		},
+	})
+
+	return &types{
+		product:            product,
+		productVariant:     productVariant,
+		basket:             basket,
+		basketRevalidation: basketRevalidation,
+		basketMerge:        basketMerge,
+		order:              order,
+		searchResult:       searchResult,
+	}
+}
+
+// lineOf returns the product and variant IDs of a basket or order line
+func lineOf(source interface{}) (productID, variantID string) {
+	switch line := source.(type) {
+	case dto.BasketItemResponse:
+		return line.ProductID, line.VariantID
+	case dto.OrderItemResponse:
+		return line.ProductID, line.VariantID
+	}
+	return "", ""
+}
+
+// loadProduct returns a thunk resolving a product through the request's loader.
+// Products that no longer exist resolve to null.
+func loadProduct(p gql.ResolveParams, productID string) func() (*dto.ProductResponse, error) {
+	thunk := loadersFrom(p.Context).products.Load(productID)
+	return func() (*dto.ProductResponse, error) {
+		product, err := thunk()
+		if errors.Is(err, dataloader.ErrNotFound) {
+			return nil, nil
+		}
+		return product, err
+	}
+}
+
+func resolveLineProduct(p gql.ResolveParams) (interface{}, error) {
+	productID, _ := lineOf(p.Source)
+	load := loadProduct(p, productID)
+	return func() (interface{}, error) {
+		product, err := load()
+		if product == nil {
+			return nil, err
+		}
+		return product, nil
+	}, nil
+}
+
+func resolveLineVariant(p gql.ResolveParams) (interface{}, error) {
+	productID, variantID := lineOf(p.Source)
+	if variantID == "" {
+		return nil, nil
+	}
+	load := loadProduct(p, productID)
+	return func() (interface{}, error) {
+		product, err := load()
+		if product == nil {
+			return nil, err
+		}
+		for _, v := range product.Variants {
+			if v.ID == variantID {
+				return v, nil
+			}
+		}
+		return nil, nil
+	}, nil
+}
+
+func imageOf(source interface{}) dto.ProductImageResponse {
+	if img, ok := source.(*dto.ProductImageResponse); ok {
+		return *img
+	}
+	return source.(dto.ProductImageResponse)
+}
+
+func sortedOptions(options map[string]string) []keyValue {
+	out := make([]keyValue, 0, len(options))
+	for name, value := range options {
+		out = append(out, keyValue{Name: name, Value: value})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func sortedRenditions(renditions map[string]dto.ImageRenditionResponse) []rendition {
+	out := make([]rendition, 0, len(renditions))
+	for name, r := range renditions {
+		out = append(out, rendition{Name: name, URL: r.URL, Width: r.Width, Height: r.Height})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// list is a non-null list field of non-null items. Empty slices omitted from a DTO
+// resolve to an empty list rather than null.
+func list(of gql.Output) *gql.Field {
+	return &gql.Field{
+		Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(of))),
+		Resolve: func(p gql.ResolveParams) (interface{}, error) {
+			v, err := gql.DefaultResolveFn(p)
+			if err != nil {
+				return nil, err
+			}
+			if rv := reflect.ValueOf(v); !rv.IsValid() || (rv.Kind() == reflect.Slice && rv.IsNil()) {
+				return []interface{}{}, nil
+			}
+			return v, nil
+		},
+	}
+}
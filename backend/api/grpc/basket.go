@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"context"
+	"ecom-backend/api/grpc/pb"
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// basketServer implements pb.BasketServiceServer with the basket service
+type basketServer struct {
+	pb.UnimplementedBasketServiceServer
+	baskets *service.BasketService
+}
+
+func (s *basketServer) CreateBasket(ctx context.Context, req *pb.CreateBasketRequest) (*pb.Basket, error) {
+	return toBasket(s.baskets.CreateBasket(ctx))
+}
+
+func (s *basketServer) GetBasket(ctx context.Context, req *pb.GetBasketRequest) (*pb.Basket, error) {
+	basket, err := s.baskets.GetBasket(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err, codes.Internal)
+	}
+	return toBasket(basket, nil)
+}
+
+func (s *basketServer) AddItem(ctx context.Context, req *pb.AddItemRequest) (*pb.Basket, error) {
+	return toBasket(s.baskets.AddItem(ctx, req.GetBasketId(), &dto.AddItemRequest{
+		ProductID: req.GetProductId(),
+		VariantID: req.GetVariantId(),
+		Quantity:  int(req.GetQuantity()),
+	}))
+}
+
+func (s *basketServer) UpdateItemQuantity(ctx context.Context, req *pb.UpdateItemQuantityRequest) (*pb.Basket, error) {
+	return toBasket(s.baskets.UpdateItemQuantity(ctx, req.GetBasketId(), req.GetProductId(), req.GetVariantId(),
+		&dto.UpdateItemQuantityRequest{Quantity: int(req.GetQuantity())}))
+}
+
+func (s *basketServer) RemoveItem(ctx context.Context, req *pb.RemoveItemRequest) (*pb.Basket, error) {
+	return toBasket(s.baskets.RemoveItem(ctx, req.GetBasketId(), req.GetProductId(), req.GetVariantId()))
+}
+
+func (s *basketServer) ClearBasket(ctx context.Context, req *pb.ClearBasketRequest) (*pb.Basket, error) {
+	return toBasket(s.baskets.ClearBasket(ctx, req.GetBasketId()))
+}
+
+func (s *basketServer) GetBasketRevalidation(ctx context.Context, req *pb.GetBasketRequest) (*pb.BasketRevalidation, error) {
+	r, err := s.baskets.RevalidateBasket(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err, codes.Internal)
+	}
+
+	changes := make([]*pb.BasketChange, len(r.Changes))
+	for i, c := range r.Changes {
+		changes[i] = &pb.BasketChange{
+			ProductId: c.ProductID,
+			VariantId: c.VariantID,
+			Name:      c.Name,
+			Type:      c.Type,
+			Quantity:  int32(c.Quantity),
+			OldPrice:  c.OldPrice,
+			NewPrice:  c.NewPrice,
+			Currency:  c.Currency,
+			Stock:     int32(c.Stock),
+		}
+	}
+
+	return &pb.BasketRevalidation{
+		BasketId:         r.BasketID,
+		Changes:          changes,
+		Stale:            r.Stale,
+		CanCheckout:      r.CanCheckout,
+		Total:            r.Total,
+		RevalidatedTotal: r.RevalidatedTotal,
+		Currency:         r.Currency,
+	}, nil
+}
+
+func (s *basketServer) MergeBaskets(ctx context.Context, req *pb.MergeBasketsRequest) (*pb.BasketMerge, error) {
+	merged, err := s.baskets.MergeBaskets(ctx, req.GetBasketId(), &dto.MergeBasketRequest{
+		SourceBasketID: req.GetSourceBasketId(),
+		Strategy:       req.GetStrategy(),
+	})
+	if err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	}
+
+	basket, err := toBasket(merged.Basket, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustments := make([]*pb.BasketMergeAdjustment, len(merged.Adjustments))
+	for i, a := range merged.Adjustments {
+		adjustments[i] = &pb.BasketMergeAdjustment{
+			ProductId: a.ProductID,
+			VariantId: a.VariantID,
+			Name:      a.Name,
+			Reason:    a.Reason,
+			Requested: int32(a.Requested),
+			Quantity:  int32(a.Quantity),
+			Stock:     int32(a.Stock),
+		}
+	}
+
+	return &pb.BasketMerge{
+		Basket:      basket,
+		Strategy:    merged.Strategy,
+		Adjustments: adjustments,
+	}, nil
+}
+
+// toBasket converts the result of a basket change to its protobuf message.
+// Errors are reported as InvalidArgument unless their message says otherwise.
+func toBasket(b *dto.BasketResponse, err error) (*pb.Basket, error) {
+	if err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	}
+
+	items := make([]*pb.BasketItem, len(b.Items))
+	for i, item := range b.Items {
+		items[i] = &pb.BasketItem{
+			ProductId:    item.ProductID,
+			VariantId:    item.VariantID,
+			Quantity:     int32(item.Quantity),
+			Price:        item.Price,
+			Currency:     item.Currency,
+			Subtotal:     item.Subtotal,
+			PriceChanged: item.PriceChanged,
+			OutOfStock:   item.OutOfStock,
+		}
+		if p := item.Product; p != nil {
+			items[i].Product = &pb.BasketProduct{
+				Name:        p.Name,
+				Sku:         p.SKU,
+				Description: p.Description,
+				Options:     p.Options,
+				Price:       p.Price,
+				Currency:    p.Currency,
+				Stock:       int32(p.Stock),
+				Archived:    p.Archived,
+			}
+		}
+	}
+
+	return &pb.Basket{
+		Id:        b.ID,
+		Items:     items,
+		Total:     b.Total,
+		Currency:  b.Currency,
+		ItemCount: int32(b.ItemCount),
+		CreatedAt: timestamppb.New(b.CreatedAt),
+		UpdatedAt: timestamppb.New(b.UpdatedAt),
+	}, nil
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"ecom-backend/pkg/validate"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError converts a service error into a gRPC status error. Services report most
+// failures as plain errors, so the well-known ones are classified by message; anything
+// else gets the fallback code chosen by the caller.
+func statusError(err error, fallback codes.Code) error {
+	if err == nil {
+		return nil
+	}
+
+	var fields validate.Errors
+	if errors.As(err, &fields) {
+		return validationStatus(fields)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	return status.Error(classify(err.Error(), fallback), err.Error())
+}
+
+// classify picks the status code for a service error message
+func classify(msg string, fallback codes.Code) codes.Code {
+	switch {
+	case strings.HasSuffix(msg, "not found"), strings.HasPrefix(msg, "item not found"):
+		return codes.NotFound
+	case strings.HasPrefix(msg, "SKU already in use"), strings.HasPrefix(msg, "duplicate SKU"),
+		strings.HasPrefix(msg, "a variant with these options already exists"):
+		return codes.AlreadyExists
+	case strings.HasPrefix(msg, "insufficient stock"),
+		strings.HasPrefix(msg, "product is no longer available"),
+		strings.HasPrefix(msg, "product is already archived"),
+		strings.HasPrefix(msg, "cannot create order from empty basket"),
+		strings.HasPrefix(msg, "basket prices have changed"),
+		strings.HasPrefix(msg, "only "), strings.HasSuffix(msg, "cannot be cancelled"),
+		strings.HasPrefix(msg, "order is already"):
+		return codes.FailedPrecondition
+	}
+	return fallback
+}
+
+// validationStatus reports every invalid field as a BadRequest violation
+func validationStatus(fields validate.Errors) error {
+	st := status.New(codes.InvalidArgument, "Validation failed: "+fields.Error())
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(fields))
+	for i, f := range fields {
+		violations[i] = &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message}
+	}
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,416 @@
+package grpc
+
+import (
+	"context"
+	"ecom-backend/api/grpc/pb"
+	"ecom-backend/application/service"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// productRepo is an in-memory product repository
+type productRepo struct {
+	products map[string]*entity.Product
+}
+
+func (m *productRepo) Save(ctx context.Context, p *entity.Product) error {
+	m.products[p.ID()] = p
+	return nil
+}
+func (m *productRepo) FindByID(ctx context.Context, id string) (*entity.Product, error) {
+	if p, ok := m.products[id]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("product %w", repository.ErrNotFound)
+}
+func (m *productRepo) FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := m.products[id]; ok {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+func (m *productRepo) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0, len(m.products))
+	for _, p := range m.products {
+		if !p.IsArchived() {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+func (m *productRepo) FindArchived(ctx context.Context) ([]*entity.Product, error) { return nil, nil }
+func (m *productRepo) FindBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	return nil, fmt.Errorf("product %w", repository.ErrNotFound)
+}
+func (m *productRepo) FindAfter(ctx context.Context, afterID string, limit int) ([]*entity.Product, error) {
+	return nil, nil
+}
+func (m *productRepo) FindLowStock(ctx context.Context) ([]*entity.Product, error) {
+	return nil, nil
+}
+func (m *productRepo) Update(ctx context.Context, p *entity.Product) error { return m.Save(ctx, p) }
+func (m *productRepo) Archive(ctx context.Context, p *entity.Product) (int, error) {
+	return 0, m.Save(ctx, p)
+}
+func (m *productRepo) AddVariant(ctx context.Context, productID string, v *entity.ProductVariant) error {
+	return nil
+}
+func (m *productRepo) UpdateVariant(ctx context.Context, productID string, v *entity.ProductVariant) error {
+	return nil
+}
+func (m *productRepo) UpdateVariantStock(ctx context.Context, productID string, v *entity.ProductVariant) error {
+	return nil
+}
+func (m *productRepo) RemoveVariant(ctx context.Context, productID, variantID string) error {
+	return nil
+}
+func (m *productRepo) UpdateReorderPolicy(ctx context.Context, p *entity.Product) error {
+	return m.Save(ctx, p)
+}
+func (m *productRepo) AddImage(ctx context.Context, productID string, img *entity.ProductImage) error {
+	return nil
+}
+func (m *productRepo) DeleteImage(ctx context.Context, productID, imageID string) error { return nil }
+func (m *productRepo) ReorderImages(ctx context.Context, productID string, imageIDs []string) error {
+	return nil
+}
+func (m *productRepo) SetPrimaryImage(ctx context.Context, productID, imageID string) error {
+	return nil
+}
+func (m *productRepo) Delete(ctx context.Context, id string) error {
+	delete(m.products, id)
+	return nil
+}
+func (m *productRepo) ExistsByID(ctx context.Context, id string) (bool, error) {
+	_, ok := m.products[id]
+	return ok, nil
+}
+func (m *productRepo) IsReferencedByOrders(ctx context.Context, id string) (bool, error) {
+	return false, nil
+}
+func (m *productRepo) ExistsBySKU(ctx context.Context, sku string) (bool, error) { return false, nil }
+
+// basketRepo is an in-memory basket repository
+type basketRepo struct {
+	baskets map[string]*entity.Basket
+}
+
+func (m *basketRepo) Save(ctx context.Context, b *entity.Basket) error {
+	m.baskets[b.ID()] = b
+	return nil
+}
+func (m *basketRepo) FindByID(ctx context.Context, id string) (*entity.Basket, error) {
+	if b, ok := m.baskets[id]; ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("basket %w", repository.ErrNotFound)
+}
+func (m *basketRepo) Update(ctx context.Context, b *entity.Basket) error { return m.Save(ctx, b) }
+func (m *basketRepo) Delete(ctx context.Context, id string) error {
+	delete(m.baskets, id)
+	return nil
+}
+func (m *basketRepo) UpdateMerged(ctx context.Context, b *entity.Basket, mergedID string) error {
+	if _, ok := m.baskets[mergedID]; !ok {
+		return fmt.Errorf("basket %w", repository.ErrNotFound)
+	}
+	delete(m.baskets, mergedID)
+	m.baskets[b.ID()] = b
+	return nil
+}
+func (m *basketRepo) DeleteIdle(ctx context.Context, before time.Time, limit int) ([]*entity.Basket, error) {
+	return nil, nil
+}
+func (m *basketRepo) ExistsByID(ctx context.Context, id string) (bool, error) {
+	_, ok := m.baskets[id]
+	return ok, nil
+}
+
+// orderRepo is an in-memory order repository
+type orderRepo struct {
+	orders map[string]*entity.Order
+}
+
+func (m *orderRepo) Save(ctx context.Context, o *entity.Order) error {
+	m.orders[o.ID()] = o
+	return nil
+}
+func (m *orderRepo) Place(ctx context.Context, o *entity.Order) error {
+	m.orders[o.ID()] = o
+	return nil
+}
+func (m *orderRepo) FindByID(ctx context.Context, id string) (*entity.Order, error) {
+	if o, ok := m.orders[id]; ok {
+		return o, nil
+	}
+	return nil, errors.New("order not found")
+}
+func (m *orderRepo) FindAll(ctx context.Context) ([]*entity.Order, error) {
+	orders := make([]*entity.Order, 0, len(m.orders))
+	for _, o := range m.orders {
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+func (m *orderRepo) Update(ctx context.Context, o *entity.Order) error { return m.Save(ctx, o) }
+func (m *orderRepo) ExistsByID(ctx context.Context, id string) (bool, error) {
+	_, ok := m.orders[id]
+	return ok, nil
+}
+
+type clients struct {
+	products pb.ProductServiceClient
+	baskets  pb.BasketServiceClient
+	orders   pb.OrderServiceClient
+}
+
+// dial serves the services over an in-process bufconn listener
+func dial(t *testing.T, opts Options) clients {
+	t.Helper()
+	products := &productRepo{products: make(map[string]*entity.Product)}
+	baskets := &basketRepo{baskets: make(map[string]*entity.Basket)}
+	orders := &orderRepo{orders: make(map[string]*entity.Order)}
+	metrics := service.NopMetrics{}
+
+	srv := NewServer(
+		service.NewProductService(products, nil, service.NopEvents{}, service.NopAlerts{}),
+		service.NewBasketService(baskets, products, metrics, service.NopEvents{}),
+		service.NewOrderService(orders, baskets, products, metrics, service.NopEvents{}, service.NopAlerts{}),
+		opts,
+	)
+
+	ln := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		Serve(ctx, srv, ln, context.Background())
+		close(done)
+	}()
+
+	conn, err := grpclib.NewClient("passthrough:///bufnet",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dialing bufconn: %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		cancel()
+		<-done
+	})
+
+	return clients{
+		products: pb.NewProductServiceClient(conn),
+		baskets:  pb.NewBasketServiceClient(conn),
+		orders:   pb.NewOrderServiceClient(conn),
+	}
+}
+
+func expectCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Fatalf("expected %s, got %s (%v)", want, got, err)
+	}
+}
+
+func TestProductService(t *testing.T) {
+	c := dial(t, Options{})
+	ctx := context.Background()
+
+	created, err := c.products.CreateProduct(ctx, &pb.CreateProductRequest{Name: "Mug", Price: 1200, Currency: "USD", Stock: 5})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+
+	got, err := c.products.GetProduct(ctx, &pb.GetProductRequest{Id: created.GetId()})
+	if err != nil {
+		t.Fatalf("GetProduct: %v", err)
+	}
+	if got.GetName() != "Mug" || got.GetPrice() != 1200 || got.GetStock() != 5 || got.GetCreatedAt() == nil {
+		t.Errorf("unexpected product %v", got)
+	}
+	if got.GetArchivedAt() != nil {
+		t.Errorf("expected no archived_at, got %v", got.GetArchivedAt())
+	}
+
+	updated, err := c.products.UpdateStock(ctx, &pb.UpdateStockRequest{Id: created.GetId(), Stock: 9})
+	if err != nil {
+		t.Fatalf("UpdateStock: %v", err)
+	}
+	if updated.GetStock() != 9 {
+		t.Errorf("expected stock 9, got %d", updated.GetStock())
+	}
+
+	if _, err := c.products.DeleteProduct(ctx, &pb.DeleteProductRequest{Id: created.GetId()}); err != nil {
+		t.Fatalf("DeleteProduct: %v", err)
+	}
+	list, err := c.products.ListProducts(ctx, &pb.ListProductsRequest{})
+	if err != nil {
+		t.Fatalf("ListProducts: %v", err)
+	}
+	if len(list.GetProducts()) != 0 {
+		t.Errorf("expected archived product to be hidden, got %d products", len(list.GetProducts()))
+	}
+
+	_, err = c.products.DeleteProduct(ctx, &pb.DeleteProductRequest{Id: created.GetId()})
+	expectCode(t, err, codes.FailedPrecondition)
+
+	_, err = c.products.GetProduct(ctx, &pb.GetProductRequest{Id: "missing"})
+	expectCode(t, err, codes.NotFound)
+}
+
+func TestValidationDetails(t *testing.T) {
+	c := dial(t, Options{})
+
+	_, err := c.products.CreateProduct(context.Background(), &pb.CreateProductRequest{Price: -1, Currency: "usd"})
+	expectCode(t, err, codes.InvalidArgument)
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			violations = br.GetFieldViolations()
+		}
+	}
+	fields := make(map[string]bool)
+	for _, v := range violations {
+		fields[v.GetField()] = true
+	}
+	for _, f := range []string{"name", "price", "currency"} {
+		if !fields[f] {
+			t.Errorf("expected a violation for %s, got %v", f, violations)
+		}
+	}
+}
+
+func TestCheckout(t *testing.T) {
+	c := dial(t, Options{})
+	ctx := context.Background()
+
+	product, err := c.products.CreateProduct(ctx, &pb.CreateProductRequest{Name: "Mug", Price: 1200, Currency: "USD", Stock: 3})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	basket, err := c.baskets.CreateBasket(ctx, &pb.CreateBasketRequest{})
+	if err != nil {
+		t.Fatalf("CreateBasket: %v", err)
+	}
+
+	_, err = c.baskets.AddItem(ctx, &pb.AddItemRequest{BasketId: basket.GetId(), ProductId: product.GetId(), Quantity: 4})
+	expectCode(t, err, codes.FailedPrecondition)
+
+	basket, err = c.baskets.AddItem(ctx, &pb.AddItemRequest{BasketId: basket.GetId(), ProductId: product.GetId(), Quantity: 2})
+	if err != nil {
+		t.Fatalf("AddItem: %v", err)
+	}
+	if basket.GetTotal() != 2400 || basket.GetItemCount() != 2 {
+		t.Errorf("unexpected basket %v", basket)
+	}
+	if item := basket.GetItems()[0]; item.GetProduct().GetName() != "Mug" || item.GetOutOfStock() {
+		t.Errorf("unexpected basket item %v", item)
+	}
+
+	order, err := c.orders.CreateOrder(ctx, &pb.CreateOrderRequest{BasketId: basket.GetId()})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.GetStatus() != "PENDING" || order.GetTotal() != 2400 || len(order.GetItems()) != 1 {
+		t.Errorf("unexpected order %v", order)
+	}
+	if order.GetItems()[0].GetName() != "Mug" {
+		t.Errorf("expected the product name in the order item, got %v", order.GetItems()[0])
+	}
+
+	_, err = c.orders.ShipOrder(ctx, &pb.OrderActionRequest{Id: order.GetId()})
+	expectCode(t, err, codes.FailedPrecondition)
+
+	_, err = c.orders.CreateOrder(ctx, &pb.CreateOrderRequest{BasketId: basket.GetId()})
+	expectCode(t, err, codes.FailedPrecondition)
+
+	_, err = c.orders.GetOrder(ctx, &pb.GetOrderRequest{Id: "missing"})
+	expectCode(t, err, codes.NotFound)
+}
+
+func TestMergeBaskets(t *testing.T) {
+	c := dial(t, Options{})
+	ctx := context.Background()
+
+	product, err := c.products.CreateProduct(ctx, &pb.CreateProductRequest{Name: "Mug", Price: 1200, Currency: "USD", Stock: 5})
+	if err != nil {
+		t.Fatalf("CreateProduct: %v", err)
+	}
+	account, _ := c.baskets.CreateBasket(ctx, &pb.CreateBasketRequest{})
+	guest, _ := c.baskets.CreateBasket(ctx, &pb.CreateBasketRequest{})
+	c.baskets.AddItem(ctx, &pb.AddItemRequest{BasketId: account.GetId(), ProductId: product.GetId(), Quantity: 2})
+	c.baskets.AddItem(ctx, &pb.AddItemRequest{BasketId: guest.GetId(), ProductId: product.GetId(), Quantity: 4})
+
+	merged, err := c.baskets.MergeBaskets(ctx, &pb.MergeBasketsRequest{BasketId: account.GetId(), SourceBasketId: guest.GetId(), Strategy: "max"})
+	if err != nil {
+		t.Fatalf("MergeBaskets: %v", err)
+	}
+	if merged.GetStrategy() != "max" || merged.GetBasket().GetItemCount() != 4 || len(merged.GetAdjustments()) != 0 {
+		t.Errorf("unexpected merge %v", merged)
+	}
+
+	_, err = c.baskets.MergeBaskets(ctx, &pb.MergeBasketsRequest{BasketId: account.GetId(), SourceBasketId: guest.GetId()})
+	expectCode(t, err, codes.NotFound)
+}
+
+func TestAPIKey(t *testing.T) {
+	c := dial(t, Options{APIKeys: []string{"secret"}, APIKeyHeader: "X-API-Key", RequireAPIKey: true})
+	ctx := context.Background()
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "x-api-key", key)
+	}
+
+	if _, err := c.products.ListProducts(ctx, &pb.ListProductsRequest{}); err != nil {
+		t.Errorf("expected reads without a key to pass, got %v", err)
+	}
+
+	_, err := c.baskets.CreateBasket(ctx, &pb.CreateBasketRequest{})
+	expectCode(t, err, codes.Unauthenticated)
+
+	_, err = c.products.ListProducts(withKey("wrong"), &pb.ListProductsRequest{})
+	expectCode(t, err, codes.Unauthenticated)
+
+	if _, err := c.baskets.CreateBasket(withKey("secret"), &pb.CreateBasketRequest{}); err != nil {
+		t.Errorf("expected a valid key to pass, got %v", err)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want codes.Code
+	}{
+		{"basket not found", codes.NotFound},
+		{"item not found in basket", codes.NotFound},
+		{"SKU already in use: MUG-1", codes.AlreadyExists},
+		{"insufficient stock for product: Mug", codes.FailedPrecondition},
+		{"only pending orders can be confirmed", codes.FailedPrecondition},
+		{"delivered orders cannot be cancelled", codes.FailedPrecondition},
+		{"currency cannot be empty", codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		if got := classify(tt.msg, codes.InvalidArgument); got != tt.want {
+			t.Errorf("classify(%q) = %s, want %s", tt.msg, got, tt.want)
+		}
+	}
+}
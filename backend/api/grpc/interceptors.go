@@ -0,0 +1,112 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"ecom-backend/pkg/tracing"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// recoverPanics turns a panicking handler into an Internal error
+func recoverPanics(ctx context.Context, req interface{}, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			slog.ErrorContext(ctx, "panic in gRPC handler", "method", info.FullMethod, "panic", p, "stack", string(debug.Stack()))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// observe traces and logs each call, continuing any W3C traceparent sent in the metadata
+func observe(ctx context.Context, req interface{}, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	if sc, err := tracing.ParseTraceparent(firstValue(ctx, tracing.TraceparentHeader)); err == nil {
+		ctx = tracing.ContextWithRemoteSpanContext(ctx, sc)
+	}
+	ctx, span := tracing.Start(ctx, "gRPC "+info.FullMethod,
+		tracing.WithKind(tracing.SpanKindServer),
+		tracing.WithAttributes("rpc.system", "grpc", "rpc.method", info.FullMethod),
+	)
+	defer span.End()
+
+	resp, err := handler(ctx, req)
+
+	code := status.Code(err)
+	span.SetAttribute("rpc.grpc.status_code", int(code))
+
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK:
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		level = slog.LevelError
+		span.SetStatus(tracing.StatusError, code.String())
+	default:
+		level = slog.LevelWarn
+	}
+
+	slog.LogAttrs(ctx, level, "grpc request",
+		slog.String("method", info.FullMethod),
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+	)
+
+	return resp, err
+}
+
+// apiKey applies the REST API's key rules: a key that is sent must be valid, and a
+// missing key is only rejected for calls that change data when keys are required
+func apiKey(opts Options) grpclib.UnaryServerInterceptor {
+	keys := make([][]byte, len(opts.APIKeys))
+	for i, key := range opts.APIKeys {
+		keys[i] = []byte(key)
+	}
+	header := strings.ToLower(opts.APIKeyHeader)
+
+	return func(ctx context.Context, req interface{}, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (interface{}, error) {
+		key := firstValue(ctx, header)
+
+		if key == "" {
+			if opts.RequireAPIKey && !readOnly(info.FullMethod) {
+				return nil, status.Error(codes.Unauthenticated, "API key required")
+			}
+			return handler(ctx, req)
+		}
+
+		match := 0
+		for _, k := range keys {
+			match |= subtle.ConstantTimeCompare(k, []byte(key))
+		}
+		if match != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// readOnly reports whether a method only reads data, judged by its Get or List prefix
+func readOnly(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return strings.HasPrefix(method, "Get") || strings.HasPrefix(method, "List")
+}
+
+// firstValue returns the first incoming metadata value for key, or ""
+func firstValue(ctx context.Context, key string) string {
+	if key == "" {
+		return ""
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
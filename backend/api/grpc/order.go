@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"ecom-backend/api/grpc/pb"
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// orderServer implements pb.OrderServiceServer with the order service
+type orderServer struct {
+	pb.UnimplementedOrderServiceServer
+	orders *service.OrderService
+}
+
+func (s *orderServer) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.Order, error) {
+	return toOrder(s.orders.CreateOrder(ctx, &dto.CreateOrderRequest{
+		BasketID:       req.GetBasketId(),
+		ConfirmedTotal: req.ConfirmedTotal,
+	}))
+}
+
+func (s *orderServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	order, err := s.orders.GetOrder(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err, codes.Internal)
+	}
+	return toOrder(order, nil)
+}
+
+func (s *orderServer) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	orders, err := s.orders.GetAllOrders(ctx)
+	if err != nil {
+		return nil, statusError(err, codes.Internal)
+	}
+
+	resp := &pb.ListOrdersResponse{Orders: make([]*pb.Order, len(orders))}
+	for i, o := range orders {
+		resp.Orders[i], _ = toOrder(o, nil)
+	}
+	return resp, nil
+}
+
+func (s *orderServer) ConfirmOrder(ctx context.Context, req *pb.OrderActionRequest) (*pb.Order, error) {
+	return toOrder(s.orders.ConfirmOrder(ctx, req.GetId()))
+}
+
+func (s *orderServer) ShipOrder(ctx context.Context, req *pb.OrderActionRequest) (*pb.Order, error) {
+	return toOrder(s.orders.ShipOrder(ctx, req.GetId()))
+}
+
+func (s *orderServer) DeliverOrder(ctx context.Context, req *pb.OrderActionRequest) (*pb.Order, error) {
+	return toOrder(s.orders.DeliverOrder(ctx, req.GetId()))
+}
+
+func (s *orderServer) CancelOrder(ctx context.Context, req *pb.OrderActionRequest) (*pb.Order, error) {
+	return toOrder(s.orders.CancelOrder(ctx, req.GetId()))
+}
+
+// toOrder converts the result of an order change to its protobuf message.
+// Errors are reported as InvalidArgument unless their message says otherwise.
+func toOrder(o *dto.OrderResponse, err error) (*pb.Order, error) {
+	if err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	}
+
+	items := make([]*pb.OrderItem, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = &pb.OrderItem{
+			ProductId:   item.ProductID,
+			VariantId:   item.VariantID,
+			Quantity:    int32(item.Quantity),
+			Price:       item.Price,
+			Currency:    item.Currency,
+			Subtotal:    item.Subtotal,
+			Name:        item.Name,
+			Sku:         item.SKU,
+			Description: item.Description,
+		}
+	}
+
+	return &pb.Order{
+		Id:        o.ID,
+		Items:     items,
+		Total:     o.Total,
+		Currency:  o.Currency,
+		Status:    o.Status,
+		CreatedAt: timestamppb.New(o.CreatedAt),
+		UpdatedAt: timestamppb.New(o.UpdatedAt),
+	}, nil
+}
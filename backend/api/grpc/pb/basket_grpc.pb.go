@@ -0,0 +1,397 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: ecom/v1/basket.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BasketService_CreateBasket_FullMethodName          = "/ecom.v1.BasketService/CreateBasket"
+	BasketService_GetBasket_FullMethodName             = "/ecom.v1.BasketService/GetBasket"
+	BasketService_GetBasketRevalidation_FullMethodName = "/ecom.v1.BasketService/GetBasketRevalidation"
+	BasketService_AddItem_FullMethodName               = "/ecom.v1.BasketService/AddItem"
+	BasketService_UpdateItemQuantity_FullMethodName    = "/ecom.v1.BasketService/UpdateItemQuantity"
+	BasketService_RemoveItem_FullMethodName            = "/ecom.v1.BasketService/RemoveItem"
+	BasketService_ClearBasket_FullMethodName           = "/ecom.v1.BasketService/ClearBasket"
+	BasketService_MergeBaskets_FullMethodName          = "/ecom.v1.BasketService/MergeBaskets"
+)
+
+// BasketServiceClient is the client API for BasketService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BasketService manages shopping baskets.
+type BasketServiceClient interface {
+	CreateBasket(ctx context.Context, in *CreateBasketRequest, opts ...grpc.CallOption) (*Basket, error)
+	GetBasket(ctx context.Context, in *GetBasketRequest, opts ...grpc.CallOption) (*Basket, error)
+	// GetBasketRevalidation compares items with current prices and stock before checkout.
+	GetBasketRevalidation(ctx context.Context, in *GetBasketRequest, opts ...grpc.CallOption) (*BasketRevalidation, error)
+	AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*Basket, error)
+	// UpdateItemQuantity sets an item's quantity; zero removes the item.
+	UpdateItemQuantity(ctx context.Context, in *UpdateItemQuantityRequest, opts ...grpc.CallOption) (*Basket, error)
+	RemoveItem(ctx context.Context, in *RemoveItemRequest, opts ...grpc.CallOption) (*Basket, error)
+	ClearBasket(ctx context.Context, in *ClearBasketRequest, opts ...grpc.CallOption) (*Basket, error)
+	// MergeBaskets merges a basket, such as a guest basket at login, into another and deletes it.
+	MergeBaskets(ctx context.Context, in *MergeBasketsRequest, opts ...grpc.CallOption) (*BasketMerge, error)
+}
+
+type basketServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBasketServiceClient(cc grpc.ClientConnInterface) BasketServiceClient {
+	return &basketServiceClient{cc}
+}
+
+func (c *basketServiceClient) CreateBasket(ctx context.Context, in *CreateBasketRequest, opts ...grpc.CallOption) (*Basket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Basket)
+	err := c.cc.Invoke(ctx, BasketService_CreateBasket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *basketServiceClient) GetBasket(ctx context.Context, in *GetBasketRequest, opts ...grpc.CallOption) (*Basket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Basket)
+	err := c.cc.Invoke(ctx, BasketService_GetBasket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *basketServiceClient) GetBasketRevalidation(ctx context.Context, in *GetBasketRequest, opts ...grpc.CallOption) (*BasketRevalidation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BasketRevalidation)
+	err := c.cc.Invoke(ctx, BasketService_GetBasketRevalidation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *basketServiceClient) AddItem(ctx context.Context, in *AddItemRequest, opts ...grpc.CallOption) (*Basket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Basket)
+	err := c.cc.Invoke(ctx, BasketService_AddItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *basketServiceClient) UpdateItemQuantity(ctx context.Context, in *UpdateItemQuantityRequest, opts ...grpc.CallOption) (*Basket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Basket)
+	err := c.cc.Invoke(ctx, BasketService_UpdateItemQuantity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *basketServiceClient) RemoveItem(ctx context.Context, in *RemoveItemRequest, opts ...grpc.CallOption) (*Basket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Basket)
+	err := c.cc.Invoke(ctx, BasketService_RemoveItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *basketServiceClient) ClearBasket(ctx context.Context, in *ClearBasketRequest, opts ...grpc.CallOption) (*Basket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Basket)
+	err := c.cc.Invoke(ctx, BasketService_ClearBasket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *basketServiceClient) MergeBaskets(ctx context.Context, in *MergeBasketsRequest, opts ...grpc.CallOption) (*BasketMerge, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BasketMerge)
+	err := c.cc.Invoke(ctx, BasketService_MergeBaskets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BasketServiceServer is the server API for BasketService service.
+// All implementations must embed UnimplementedBasketServiceServer
+// for forward compatibility.
+//
+// BasketService manages shopping baskets.
+type BasketServiceServer interface {
+	CreateBasket(context.Context, *CreateBasketRequest) (*Basket, error)
+	GetBasket(context.Context, *GetBasketRequest) (*Basket, error)
+	// GetBasketRevalidation compares items with current prices and stock before checkout.
+	GetBasketRevalidation(context.Context, *GetBasketRequest) (*BasketRevalidation, error)
+	AddItem(context.Context, *AddItemRequest) (*Basket, error)
+	// UpdateItemQuantity sets an item's quantity; zero removes the item.
+	UpdateItemQuantity(context.Context, *UpdateItemQuantityRequest) (*Basket, error)
+	RemoveItem(context.Context, *RemoveItemRequest) (*Basket, error)
+	ClearBasket(context.Context, *ClearBasketRequest) (*Basket, error)
+	// MergeBaskets merges a basket, such as a guest basket at login, into another and deletes it.
+	MergeBaskets(context.Context, *MergeBasketsRequest) (*BasketMerge, error)
+	mustEmbedUnimplementedBasketServiceServer()
+}
+
+// UnimplementedBasketServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBasketServiceServer struct{}
+
+func (UnimplementedBasketServiceServer) CreateBasket(context.Context, *CreateBasketRequest) (*Basket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBasket not implemented")
+}
+func (UnimplementedBasketServiceServer) GetBasket(context.Context, *GetBasketRequest) (*Basket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBasket not implemented")
+}
+func (UnimplementedBasketServiceServer) GetBasketRevalidation(context.Context, *GetBasketRequest) (*BasketRevalidation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBasketRevalidation not implemented")
+}
+func (UnimplementedBasketServiceServer) AddItem(context.Context, *AddItemRequest) (*Basket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddItem not implemented")
+}
+func (UnimplementedBasketServiceServer) UpdateItemQuantity(context.Context, *UpdateItemQuantityRequest) (*Basket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateItemQuantity not implemented")
+}
+func (UnimplementedBasketServiceServer) RemoveItem(context.Context, *RemoveItemRequest) (*Basket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveItem not implemented")
+}
+func (UnimplementedBasketServiceServer) ClearBasket(context.Context, *ClearBasketRequest) (*Basket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearBasket not implemented")
+}
+func (UnimplementedBasketServiceServer) MergeBaskets(context.Context, *MergeBasketsRequest) (*BasketMerge, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeBaskets not implemented")
+}
+func (UnimplementedBasketServiceServer) mustEmbedUnimplementedBasketServiceServer() {}
+func (UnimplementedBasketServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeBasketServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BasketServiceServer will
+// result in compilation errors.
+type UnsafeBasketServiceServer interface {
+	mustEmbedUnimplementedBasketServiceServer()
+}
+
+func RegisterBasketServiceServer(s grpc.ServiceRegistrar, srv BasketServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBasketServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BasketService_ServiceDesc, srv)
+}
+
+func _BasketService_CreateBasket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBasketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BasketServiceServer).CreateBasket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BasketService_CreateBasket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BasketServiceServer).CreateBasket(ctx, req.(*CreateBasketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BasketService_GetBasket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBasketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BasketServiceServer).GetBasket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BasketService_GetBasket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BasketServiceServer).GetBasket(ctx, req.(*GetBasketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BasketService_GetBasketRevalidation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBasketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BasketServiceServer).GetBasketRevalidation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BasketService_GetBasketRevalidation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BasketServiceServer).GetBasketRevalidation(ctx, req.(*GetBasketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BasketService_AddItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BasketServiceServer).AddItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BasketService_AddItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BasketServiceServer).AddItem(ctx, req.(*AddItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BasketService_UpdateItemQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItemQuantityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BasketServiceServer).UpdateItemQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BasketService_UpdateItemQuantity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BasketServiceServer).UpdateItemQuantity(ctx, req.(*UpdateItemQuantityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BasketService_RemoveItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BasketServiceServer).RemoveItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BasketService_RemoveItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BasketServiceServer).RemoveItem(ctx, req.(*RemoveItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BasketService_ClearBasket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearBasketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BasketServiceServer).ClearBasket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BasketService_ClearBasket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BasketServiceServer).ClearBasket(ctx, req.(*ClearBasketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BasketService_MergeBaskets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeBasketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BasketServiceServer).MergeBaskets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BasketService_MergeBaskets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BasketServiceServer).MergeBaskets(ctx, req.(*MergeBasketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BasketService_ServiceDesc is the grpc.ServiceDesc for BasketService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BasketService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ecom.v1.BasketService",
+	HandlerType: (*BasketServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBasket",
+			Handler:    _BasketService_CreateBasket_Handler,
+		},
+		{
+			MethodName: "GetBasket",
+			Handler:    _BasketService_GetBasket_Handler,
+		},
+		{
+			MethodName: "GetBasketRevalidation",
+			Handler:    _BasketService_GetBasketRevalidation_Handler,
+		},
+		{
+			MethodName: "AddItem",
+			Handler:    _BasketService_AddItem_Handler,
+		},
+		{
+			MethodName: "UpdateItemQuantity",
+			Handler:    _BasketService_UpdateItemQuantity_Handler,
+		},
+		{
+			MethodName: "RemoveItem",
+			Handler:    _BasketService_RemoveItem_Handler,
+		},
+		{
+			MethodName: "ClearBasket",
+			Handler:    _BasketService_ClearBasket_Handler,
+		},
+		{
+			MethodName: "MergeBaskets",
+			Handler:    _BasketService_MergeBaskets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ecom/v1/basket.proto",
+}
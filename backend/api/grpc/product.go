@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"ecom-backend/api/grpc/pb"
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// productServer implements pb.ProductServiceServer with the product service
+type productServer struct {
+	pb.UnimplementedProductServiceServer
+	products *service.ProductService
+}
+
+func (s *productServer) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.Product, error) {
+	options := make([]dto.ProductOptionRequest, len(req.GetOptions()))
+	for i, o := range req.GetOptions() {
+		options[i] = dto.ProductOptionRequest{Name: o.GetName(), Values: o.GetValues()}
+	}
+	variants := make([]dto.AddVariantRequest, len(req.GetVariants()))
+	for i, v := range req.GetVariants() {
+		variants[i] = dto.AddVariantRequest{
+			SKU:     v.GetSku(),
+			Barcode: v.GetBarcode(),
+			Options: v.GetOptions(),
+			Price:   v.Price,
+			Stock:   int(v.GetStock()),
+		}
+	}
+
+	product, err := s.products.CreateProduct(ctx, &dto.CreateProductRequest{
+		SKU:         req.GetSku(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Category:    req.GetCategory(),
+		Price:       req.GetPrice(),
+		Currency:    req.GetCurrency(),
+		Stock:       int(req.GetStock()),
+		Options:     options,
+		Variants:    variants,
+	})
+	if err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
+	product, err := s.products.GetProduct(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err, codes.Internal)
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	products, err := s.products.GetAllProducts(ctx)
+	if err != nil {
+		return nil, statusError(err, codes.Internal)
+	}
+
+	resp := &pb.ListProductsResponse{Products: make([]*pb.Product, len(products))}
+	for i, p := range products {
+		resp.Products[i] = toProduct(p)
+	}
+	return resp, nil
+}
+
+func (s *productServer) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.Product, error) {
+	product, err := s.products.UpdateProduct(ctx, req.GetId(), &dto.UpdateProductRequest{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Category:    req.Category,
+		Price:       req.GetPrice(),
+		Currency:    req.GetCurrency(),
+	})
+	if err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) UpdateStock(ctx context.Context, req *pb.UpdateStockRequest) (*pb.Product, error) {
+	product, err := s.products.UpdateStock(ctx, req.GetId(), &dto.UpdateStockRequest{Stock: int(req.GetStock())})
+	if err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) UpdateVariantStock(ctx context.Context, req *pb.UpdateVariantStockRequest) (*pb.Product, error) {
+	product, err := s.products.UpdateVariantStock(ctx, req.GetProductId(), req.GetVariantId(), &dto.UpdateStockRequest{Stock: int(req.GetStock())})
+	if err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	}
+	return toProduct(product), nil
+}
+
+func (s *productServer) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*emptypb.Empty, error) {
+	if err := s.products.DeleteProduct(ctx, req.GetId()); err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// toProduct converts a ProductResponse DTO to its protobuf message
+func toProduct(p *dto.ProductResponse) *pb.Product {
+	options := make([]*pb.ProductOption, len(p.Options))
+	for i, o := range p.Options {
+		options[i] = &pb.ProductOption{Name: o.Name, Values: o.Values}
+	}
+	variants := make([]*pb.ProductVariant, len(p.Variants))
+	for i, v := range p.Variants {
+		variants[i] = &pb.ProductVariant{
+			Id:            v.ID,
+			Sku:           v.SKU,
+			Barcode:       v.Barcode,
+			Options:       v.Options,
+			Price:         v.Price,
+			PriceOverride: v.PriceOverride,
+			Currency:      v.Currency,
+			Stock:         int32(v.Stock),
+		}
+	}
+
+	return &pb.Product{
+		Id:            p.ID,
+		Sku:           p.SKU,
+		Name:          p.Name,
+		Description:   p.Description,
+		Category:      p.Category,
+		Price:         p.Price,
+		Currency:      p.Currency,
+		Stock:         int32(p.Stock),
+		Options:       options,
+		Variants:      variants,
+		ArchivedAt:    optionalTimestamp(p.ArchivedAt),
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+		UpdatedAt:     timestamppb.New(p.UpdatedAt),
+		AverageRating: p.AverageRating,
+		ReviewCount:   int32(p.ReviewCount),
+	}
+}
+
+// optionalTimestamp converts a nullable time, leaving the field unset for nil
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
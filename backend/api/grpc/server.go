@@ -0,0 +1,74 @@
+// Package grpc serves the product, basket and order services over gRPC.
+// The protobuf definitions live in proto/ and the generated code in pb/.
+package grpc
+
+//go:generate protoc -I proto --go_out=../.. --go_opt=module=ecom-backend --go-grpc_out=../.. --go-grpc_opt=module=ecom-backend ecom/v1/product.proto ecom/v1/basket.proto ecom/v1/order.proto
+
+import (
+	"context"
+	"ecom-backend/api/grpc/pb"
+	"ecom-backend/application/service"
+	"log/slog"
+	"net"
+
+	grpclib "google.golang.org/grpc"
+)
+
+// Options configures the gRPC server
+type Options struct {
+	// APIKeys are the accepted API keys
+	APIKeys []string
+
+	// APIKeyHeader is the metadata key carrying the API key, matched case-insensitively
+	APIKeyHeader string
+
+	// RequireAPIKey rejects calls that change data when no key is sent
+	RequireAPIKey bool
+}
+
+// NewServer creates a gRPC server exposing the product, basket and order services
+func NewServer(products *service.ProductService, baskets *service.BasketService, orders *service.OrderService, opts Options) *grpclib.Server {
+	srv := grpclib.NewServer(grpclib.ChainUnaryInterceptor(
+		recoverPanics,
+		observe,
+		apiKey(opts),
+	))
+
+	pb.RegisterProductServiceServer(srv, &productServer{products: products})
+	pb.RegisterBasketServiceServer(srv, &basketServer{baskets: baskets})
+	pb.RegisterOrderServiceServer(srv, &orderServer{orders: orders})
+
+	return srv
+}
+
+// Serve serves srv on ln until ctx is cancelled, then stops gracefully.
+// In-flight calls may finish until drain is done and are then cancelled.
+func Serve(ctx context.Context, srv *grpclib.Server, ln net.Listener, drain context.Context) {
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("gRPC server starting", "addr", ln.Addr().String())
+		serveErr <- srv.Serve(ln)
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-serveErr:
+		if err != nil {
+			slog.Error("gRPC server failed", "error", err)
+		}
+		return
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-drain.Done():
+		slog.Warn("gRPC server did not drain in time")
+		srv.Stop()
+	}
+}
@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
 	"ecom-backend/application/dto"
 	"ecom-backend/application/service"
 	"net/http"
@@ -46,14 +45,27 @@ func (h *BasketHandler) GetBasket(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, basket)
 }
 
+// RevalidateBasket handles GET /baskets/{id}/revalidation
+func (h *BasketHandler) RevalidateBasket(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	revalidation, err := h.basketService.RevalidateBasket(r.Context(), id)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, revalidation)
+}
+
 // AddItem handles POST /baskets/{id}/items
 func (h *BasketHandler) AddItem(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	basketID := vars["id"]
 
 	var req dto.AddItemRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -66,13 +78,14 @@ func (h *BasketHandler) AddItem(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, basket)
 }
 
-// RemoveItem handles DELETE /baskets/{id}/items/{productId}
+// RemoveItem handles DELETE /baskets/{id}/items/{productId}?variant_id=
 func (h *BasketHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	basketID := vars["id"]
 	productID := vars["productId"]
+	variantID := r.URL.Query().Get("variant_id")
 
-	basket, err := h.basketService.RemoveItem(r.Context(), basketID, productID)
+	basket, err := h.basketService.RemoveItem(r.Context(), basketID, productID, variantID)
 	if err != nil {
 		respondWithError(w, http.StatusBadRequest, err.Error())
 		return
@@ -81,19 +94,19 @@ func (h *BasketHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, basket)
 }
 
-// UpdateItemQuantity handles PATCH /baskets/{id}/items/{productId}
+// UpdateItemQuantity handles PATCH /baskets/{id}/items/{productId}?variant_id=
 func (h *BasketHandler) UpdateItemQuantity(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	basketID := vars["id"]
 	productID := vars["productId"]
+	variantID := r.URL.Query().Get("variant_id")
 
 	var req dto.UpdateItemQuantityRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	basket, err := h.basketService.UpdateItemQuantity(r.Context(), basketID, productID, &req)
+	basket, err := h.basketService.UpdateItemQuantity(r.Context(), basketID, productID, variantID, &req)
 	if err != nil {
 		respondWithError(w, http.StatusBadRequest, err.Error())
 		return
@@ -115,3 +128,22 @@ func (h *BasketHandler) ClearBasket(w http.ResponseWriter, r *http.Request) {
 
 	respondWithJSON(w, http.StatusOK, basket)
 }
+
+// MergeBasket handles POST /baskets/{id}/merge
+func (h *BasketHandler) MergeBasket(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	basketID := vars["id"]
+
+	var req dto.MergeBasketRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	merged, err := h.basketService.MergeBaskets(r.Context(), basketID, &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, merged)
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"ecom-backend/pkg/events"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// retryMillis is the reconnection delay suggested to clients
+const retryMillis = 3000
+
+// eventTopics are the topics clients may subscribe to
+var eventTopics = []string{service.TopicOrders, service.TopicStock, service.TopicBaskets, service.TopicWishlists}
+
+// EventsHandler streams order, stock and basket changes as Server-Sent Events
+type EventsHandler struct {
+	broker    *events.Broker
+	heartbeat time.Duration
+}
+
+// NewEventsHandler creates a new EventsHandler sending a heartbeat at the given interval
+func NewEventsHandler(broker *events.Broker, heartbeat time.Duration) *EventsHandler {
+	return &EventsHandler{
+		broker:    broker,
+		heartbeat: heartbeat,
+	}
+}
+
+// Stream handles GET /events?topic=&resource_id=, resuming after Last-Event-ID when sent
+func (h *EventsHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEventFilter(r.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lastID, resuming, err := lastEventID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sub, replay, missed, err := h.broker.Subscribe(filter, lastID, resuming)
+	if err != nil {
+		respondWithError(w, http.StatusServiceUnavailable, "event stream is shutting down")
+		return
+	}
+	defer sub.Close()
+
+	// Streams stay open indefinitely, so lift the server's read and write timeouts;
+	// an expired read deadline would otherwise cancel the request context
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Time{})
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", retryMillis)
+	if missed {
+		// Events after Last-Event-ID are gone; the client should refetch current state
+		fmt.Fprint(w, "event: resync\ndata: {}\n\n")
+	}
+	for _, e := range replay {
+		if err := writeEvent(w, e); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				if sub.Dropped() {
+					slog.WarnContext(r.Context(), "event stream dropped a slow client")
+				}
+				return
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes one event in the text/event-stream format
+func writeEvent(w http.ResponseWriter, e events.Event) error {
+	data, err := json.Marshal(dto.EventResponse{
+		ID:         e.ID,
+		Topic:      e.Topic,
+		Type:       e.Type,
+		ResourceID: e.ResourceID,
+		Time:       e.Time,
+		Data:       e.Data,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
+
+// parseEventFilter reads the topic and resource_id filters. Each may be repeated or comma-separated.
+func parseEventFilter(q url.Values) (events.Filter, error) {
+	filter := events.Filter{
+		Topics:      listParam(q, "topic"),
+		ResourceIDs: listParam(q, "resource_id"),
+	}
+	for _, topic := range filter.Topics {
+		if !slices.Contains(eventTopics, topic) {
+			return filter, fmt.Errorf("unknown topic %q: expected %s", topic, strings.Join(eventTopics, ", "))
+		}
+	}
+	return filter, nil
+}
+
+// listParam collects the non-empty values of a repeated or comma-separated query parameter
+func listParam(q url.Values, key string) []string {
+	var values []string
+	for _, raw := range q[key] {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// lastEventID reads the Last-Event-ID header that browsers send on reconnect, falling back
+// to a last_event_id query parameter for clients that cannot set headers
+func lastEventID(r *http.Request) (uint64, bool, error) {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
+	}
+	if raw == "" {
+		return 0, false, nil
+	}
+
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, false, errors.New("Last-Event-ID must be a non-negative integer")
+	}
+	return id, true, nil
+}
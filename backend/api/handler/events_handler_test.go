@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"bufio"
+	"ecom-backend/pkg/events"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sseEvent is one parsed event from a text/event-stream response
+type sseEvent struct {
+	id, name, data string
+}
+
+// readEvents parses events from the stream, skipping comments and the retry field,
+// until n events have been read
+func readEvents(t *testing.T, sc *bufio.Scanner, n int) []sseEvent {
+	t.Helper()
+	var out []sseEvent
+	var cur sseEvent
+	for len(out) < n && sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "":
+			if cur != (sseEvent{}) {
+				out = append(out, cur)
+			}
+			cur = sseEvent{}
+		case strings.HasPrefix(line, "id: "):
+			cur.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			cur.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			cur.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	if len(out) < n {
+		t.Fatalf("expected %d events, got %d: %v", n, len(out), sc.Err())
+	}
+	return out
+}
+
+func openStream(t *testing.T, srv *httptest.Server, query, lastEventID string) *bufio.Scanner {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/events"+query, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+	return bufio.NewScanner(resp.Body)
+}
+
+func newEventsServer(t *testing.T, broker *events.Broker, heartbeat time.Duration) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(NewEventsHandler(broker, heartbeat).Stream))
+	t.Cleanup(func() {
+		broker.Close()
+		srv.Close()
+	})
+	return srv
+}
+
+func TestEventsHandler_StreamsFilteredEvents(t *testing.T) {
+	broker := events.NewBroker(10)
+	srv := newEventsServer(t, broker, time.Hour)
+
+	// The stream subscribes before sending headers, so these events are not missed
+	sc := openStream(t, srv, "?topic=orders&resource_id=o1,o2", "")
+
+	broker.Publish(events.Event{Topic: "stock", Type: "stock.changed", ResourceID: "o2"})
+	broker.Publish(events.Event{Topic: "orders", Type: "order.status_changed", ResourceID: "o3"})
+	broker.Publish(events.Event{Topic: "orders", Type: "order.status_changed", ResourceID: "o2", Data: map[string]string{"status": "SHIPPED"}})
+
+	got := readEvents(t, sc, 1)[0]
+	if got.id != "3" || got.name != "order.status_changed" {
+		t.Errorf("unexpected event %+v", got)
+	}
+	if !strings.Contains(got.data, `"resource_id":"o2"`) || !strings.Contains(got.data, `"status":"SHIPPED"`) {
+		t.Errorf("unexpected data %s", got.data)
+	}
+}
+
+func TestEventsHandler_ResumesFromLastEventID(t *testing.T) {
+	broker := events.NewBroker(2)
+	for _, id := range []string{"o1", "o2", "o3"} {
+		broker.Publish(events.Event{Topic: "orders", Type: "order.status_changed", ResourceID: id})
+	}
+	srv := newEventsServer(t, broker, time.Hour)
+
+	t.Run("buffered", func(t *testing.T) {
+		got := readEvents(t, openStream(t, srv, "", "2"), 1)
+		if got[0].id != "3" {
+			t.Errorf("expected replay of event 3, got %+v", got)
+		}
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		got := readEvents(t, openStream(t, srv, "", "0"), 3)
+		if got[0].name != "resync" || got[1].id != "2" || got[2].id != "3" {
+			t.Errorf("expected resync then events 2 and 3, got %+v", got)
+		}
+	})
+}
+
+func TestEventsHandler_Heartbeat(t *testing.T) {
+	broker := events.NewBroker(10)
+	srv := newEventsServer(t, broker, 10*time.Millisecond)
+
+	sc := openStream(t, srv, "", "")
+	for sc.Scan() {
+		if sc.Text() == ": heartbeat" {
+			return
+		}
+	}
+	t.Fatal("expected a heartbeat comment")
+}
+
+func TestEventsHandler_EndsWhenBrokerCloses(t *testing.T) {
+	broker := events.NewBroker(10)
+	srv := newEventsServer(t, broker, time.Hour)
+
+	sc := openStream(t, srv, "", "")
+	broker.Close()
+
+	for sc.Scan() {
+	}
+	if err := sc.Err(); err != nil {
+		t.Errorf("expected the stream to end cleanly, got %v", err)
+	}
+}
+
+func TestEventsHandler_RejectsBadRequests(t *testing.T) {
+	h := NewEventsHandler(events.NewBroker(10), time.Hour)
+
+	for _, tt := range []struct {
+		name   string
+		target string
+		lastID string
+	}{
+		{"unknown topic", "/events?topic=payments", ""},
+		{"malformed Last-Event-ID", "/events", "abc"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.lastID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastID)
+			}
+			w := httptest.NewRecorder()
+			h.Stream(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"ecom-backend/application/dto"
+	"ecom-backend/pkg/scheduler"
+	"net/http"
+)
+
+// JobsHandler reports the state of scheduled background jobs
+type JobsHandler struct {
+	scheduler *scheduler.Scheduler
+}
+
+// NewJobsHandler creates a new JobsHandler
+func NewJobsHandler(scheduler *scheduler.Scheduler) *JobsHandler {
+	return &JobsHandler{
+		scheduler: scheduler,
+	}
+}
+
+// GetJobs handles GET /jobs
+func (h *JobsHandler) GetJobs(w http.ResponseWriter, r *http.Request) {
+	stats := h.scheduler.Stats()
+
+	jobs := make([]dto.JobResponse, 0, len(stats))
+	for _, st := range stats {
+		jobs = append(jobs, toJobResponse(st))
+	}
+
+	respondWithJSON(w, http.StatusOK, jobs)
+}
+
+// toJobResponse converts job statistics to a response
+func toJobResponse(st scheduler.Stats) dto.JobResponse {
+	job := dto.JobResponse{
+		Name:           st.Name,
+		Interval:       st.Interval.String(),
+		Running:        st.Running,
+		Runs:           st.Runs,
+		Failures:       st.Failures,
+		TotalProcessed: st.TotalProcessed,
+		LastDurationMS: st.LastDuration.Milliseconds(),
+		LastProcessed:  st.LastProcessed,
+		LastError:      st.LastError,
+	}
+	if !st.LastRun.IsZero() {
+		job.LastRun = &st.LastRun
+		job.NextRun = &st.NextRun
+	}
+	return job
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"ecom-backend/domain/repository"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/gorilla/mux"
+)
+
+// multipartOverhead is the allowance for form fields and boundaries on top of the image size
+const multipartOverhead = 1 << 20
+
+// mediaCacheControl is sent with media files; keys are unique per upload so files never change
+const mediaCacheControl = "public, max-age=31536000, immutable"
+
+// MediaHandler handles product image and media HTTP requests
+type MediaHandler struct {
+	mediaService *service.MediaService
+}
+
+// NewMediaHandler creates a new MediaHandler
+func NewMediaHandler(mediaService *service.MediaService) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+	}
+}
+
+// UploadImage handles POST /products/{id}/images (multipart form with an "image" file and optional "alt_text")
+func (h *MediaHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	maxBytes := h.mediaService.MaxUploadBytes()
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes+multipartOverhead)
+
+	if err := r.ParseMultipartForm(maxBytes + multipartOverhead); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			respondWithError(w, http.StatusRequestEntityTooLarge, service.ErrImageTooLarge.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "image file is required")
+		return
+	}
+	defer file.Close()
+
+	// Read one byte past the limit so oversized files are detected without buffering them entirely
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid image upload")
+		return
+	}
+
+	product, err := h.mediaService.UploadImage(r.Context(), id, data, r.FormValue("alt_text"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrImageTooLarge):
+			respondWithError(w, http.StatusRequestEntityTooLarge, err.Error())
+		case errors.Is(err, repository.ErrUnsupportedImage):
+			respondWithError(w, http.StatusUnsupportedMediaType, err.Error())
+		default:
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, product)
+}
+
+// ListImages handles GET /products/{id}/images
+func (h *MediaHandler) ListImages(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	images, err := h.mediaService.ListImages(r.Context(), id)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, images)
+}
+
+// ReorderImages handles PUT /products/{id}/images/order
+func (h *MediaHandler) ReorderImages(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var req dto.ReorderImagesRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	product, err := h.mediaService.ReorderImages(r.Context(), id, &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, product)
+}
+
+// SetPrimaryImage handles PUT /products/{id}/images/{imageId}/primary
+func (h *MediaHandler) SetPrimaryImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	imageID := vars["imageId"]
+
+	product, err := h.mediaService.SetPrimaryImage(r.Context(), id, imageID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, product)
+}
+
+// DeleteImage handles DELETE /products/{id}/images/{imageId}
+func (h *MediaHandler) DeleteImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	imageID := vars["imageId"]
+
+	product, err := h.mediaService.DeleteImage(r.Context(), id, imageID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, product)
+}
+
+// ServeMedia handles GET /media/{key} with long-lived cache headers
+func (h *MediaHandler) ServeMedia(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	key := vars["key"]
+
+	file, info, err := h.mediaService.OpenMedia(r.Context(), key)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "media not found")
+		return
+	}
+	defer file.Close()
+
+	if info.ContentType != "" {
+		w.Header().Set("Content-Type", info.ContentType)
+	}
+	w.Header().Set("Cache-Control", mediaCacheControl)
+	w.Header().Set("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime.UnixNano(), info.Size))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(w, r, path.Base(key), info.ModTime, file)
+}
@@ -1,9 +1,9 @@
 package handler
 
 import (
-	"encoding/json"
 	"ecom-backend/application/dto"
 	"ecom-backend/application/service"
+	"errors"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -24,13 +24,17 @@ func NewOrderHandler(orderService *service.OrderService) *OrderHandler {
 // CreateOrder handles POST /orders (checkout)
 func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateOrderRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
 	order, err := h.orderService.CreateOrder(r.Context(), &req)
 	if err != nil {
+		var stale *service.StaleBasketError
+		if errors.As(err, &stale) {
+			respondWithJSON(w, http.StatusConflict, StaleBasketResponse{Error: err.Error(), Revalidation: stale.Revalidation})
+			return
+		}
 		respondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -1,10 +1,14 @@
 package handler
 
 import (
-	"encoding/json"
+	"ecom-backend/api/catalog"
 	"ecom-backend/application/dto"
 	"ecom-backend/application/service"
+	"errors"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -24,8 +28,7 @@ func NewProductHandler(productService *service.ProductService) *ProductHandler {
 // CreateProduct handles POST /products
 func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -69,8 +72,7 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	id := vars["id"]
 
 	var req dto.UpdateProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -89,8 +91,7 @@ func (h *ProductHandler) UpdateStock(w http.ResponseWriter, r *http.Request) {
 	id := vars["id"]
 
 	var req dto.UpdateStockRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
@@ -103,7 +104,37 @@ func (h *ProductHandler) UpdateStock(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, product)
 }
 
-// DeleteProduct handles DELETE /products/{id}
+// UpdateReorderPolicy handles PUT /products/{id}/reorder-policy
+func (h *ProductHandler) UpdateReorderPolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var req dto.UpdateReorderPolicyRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	product, err := h.productService.UpdateReorderPolicy(r.Context(), id, &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, product)
+}
+
+// GetLowStockReport handles GET /inventory/low-stock
+func (h *ProductHandler) GetLowStockReport(w http.ResponseWriter, r *http.Request) {
+	report, err := h.productService.GetLowStockReport(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+// DeleteProduct handles DELETE /products/{id} by archiving the product
 func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
@@ -115,3 +146,216 @@ func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 
 	respondWithJSON(w, http.StatusNoContent, nil)
 }
+
+// GetArchivedProducts handles GET /products/archived
+func (h *ProductHandler) GetArchivedProducts(w http.ResponseWriter, r *http.Request) {
+	products, err := h.productService.GetArchivedProducts(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, products)
+}
+
+// RestoreProduct handles POST /products/{id}/restore
+func (h *ProductHandler) RestoreProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	product, err := h.productService.RestoreProduct(r.Context(), id)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, product)
+}
+
+// PurgeProduct handles DELETE /products/{id}/purge
+func (h *ProductHandler) PurgeProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if err := h.productService.PurgeProduct(r.Context(), id); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusNoContent, nil)
+}
+
+// AddVariant handles POST /products/{id}/variants
+func (h *ProductHandler) AddVariant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var req dto.AddVariantRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	product, err := h.productService.AddVariant(r.Context(), id, &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, product)
+}
+
+// UpdateVariant handles PUT /products/{id}/variants/{variantId}
+func (h *ProductHandler) UpdateVariant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	variantID := vars["variantId"]
+
+	var req dto.UpdateVariantRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	product, err := h.productService.UpdateVariant(r.Context(), id, variantID, &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, product)
+}
+
+// UpdateVariantStock handles PATCH /products/{id}/variants/{variantId}/stock
+func (h *ProductHandler) UpdateVariantStock(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	variantID := vars["variantId"]
+
+	var req dto.UpdateStockRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	product, err := h.productService.UpdateVariantStock(r.Context(), id, variantID, &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, product)
+}
+
+// RemoveVariant handles DELETE /products/{id}/variants/{variantId}
+func (h *ProductHandler) RemoveVariant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	variantID := vars["variantId"]
+
+	product, err := h.productService.RemoveVariant(r.Context(), id, variantID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, product)
+}
+
+// maxImportBytes caps the size of a bulk import request body
+const maxImportBytes = 64 << 20
+
+// importTimeout bounds how long an import may take to upload and apply
+const importTimeout = 5 * time.Minute
+
+// ImportProducts handles POST /products/import?format=csv|ndjson&dry_run=true
+func (h *ProductHandler) ImportProducts(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = catalog.FormatFromContentType(r.Header.Get("Content-Type"))
+	}
+
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "dry_run must be true or false")
+			return
+		}
+		dryRun = parsed
+	}
+
+	// Large files may take longer to upload and process than the server's default timeouts allow
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Now().Add(importTimeout))
+	rc.SetWriteDeadline(time.Now().Add(importTimeout))
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	reader, err := catalog.NewReader(format, r.Body)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.productService.ImportProducts(r.Context(), reader, dryRun)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			respondWithError(w, http.StatusRequestEntityTooLarge, "import file is too large")
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// ExportProducts handles GET /products/export?format=csv|ndjson, streaming the catalog
+func (h *ProductHandler) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = catalog.FormatNDJSON
+	}
+
+	out := &trackingWriter{ResponseWriter: w}
+	writer, err := catalog.NewWriter(format, out)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Exports stream for as long as the catalog takes, so lift the server's write timeout
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", catalog.ContentType(format))
+	w.Header().Set("Content-Disposition", `attachment; filename="products.`+format+`"`)
+
+	if err := h.productService.ExportProducts(r.Context(), writer); err != nil {
+		if !out.written {
+			w.Header().Del("Content-Disposition")
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		// The status line has already been sent, so the client sees a truncated file
+		slog.ErrorContext(r.Context(), "product export aborted", "format", format, "error", err)
+	}
+}
+
+// trackingWriter records whether any part of the response has been written
+type trackingWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+// Write marks the response as started and writes b
+func (t *trackingWriter) Write(b []byte) (int, error) {
+	t.written = true
+	return t.ResponseWriter.Write(b)
+}
+
+// Flush sends buffered data to the client
+func (t *trackingWriter) Flush() {
+	t.written = true
+	if f, ok := t.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -5,9 +5,10 @@ import (
 	"ecom-backend/domain/entity"
 	"ecom-backend/domain/repository"
 	"encoding/json"
-	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"testing"
 )
 
@@ -38,30 +39,138 @@ func (m *mockProductRepository) FindByID(ctx context.Context, id string) (*entit
 	}
 	product, ok := m.products[id]
 	if !ok {
-		return nil, errors.New("product not found")
+		return nil, fmt.Errorf("product %w", repository.ErrNotFound)
 	}
 	return product, nil
 }
 
+func (m *mockProductRepository) FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := m.products[id]; ok {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
 func (m *mockProductRepository) FindAll(ctx context.Context) ([]*entity.Product, error) {
 	products := make([]*entity.Product, 0, len(m.products))
 	for _, p := range m.products {
-		products = append(products, p)
+		if !p.IsArchived() {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+func (m *mockProductRepository) FindArchived(ctx context.Context) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0)
+	for _, p := range m.products {
+		if p.IsArchived() {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+func (m *mockProductRepository) FindBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	for _, p := range m.products {
+		if p.SKU() == sku {
+			return p, nil
+		}
 	}
+	return nil, fmt.Errorf("product %w", repository.ErrNotFound)
+}
+
+func (m *mockProductRepository) FindAfter(ctx context.Context, afterID string, limit int) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0)
+	for _, p := range m.products {
+		if p.ID() > afterID && !p.IsArchived() {
+			products = append(products, p)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].ID() < products[j].ID() })
+	if len(products) > limit {
+		products = products[:limit]
+	}
+	return products, nil
+}
+
+func (m *mockProductRepository) FindLowStock(ctx context.Context) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0)
+	for _, p := range m.products {
+		low := p.IsLowStock("")
+		for _, v := range p.Variants() {
+			low = low || p.IsLowStock(v.ID())
+		}
+		if low {
+			products = append(products, p)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].Name() < products[j].Name() })
 	return products, nil
 }
 
 func (m *mockProductRepository) Update(ctx context.Context, product *entity.Product) error {
 	if _, ok := m.products[product.ID()]; !ok {
-		return errors.New("product not found")
+		return fmt.Errorf("product %w", repository.ErrNotFound)
 	}
 	m.products[product.ID()] = product
 	return nil
 }
 
+func (m *mockProductRepository) Archive(ctx context.Context, product *entity.Product) (int, error) {
+	return 0, m.Update(ctx, product)
+}
+
+func (m *mockProductRepository) AddVariant(ctx context.Context, productID string, variant *entity.ProductVariant) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepository) UpdateVariant(ctx context.Context, productID string, variant *entity.ProductVariant) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepository) UpdateVariantStock(ctx context.Context, productID string, variant *entity.ProductVariant) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepository) RemoveVariant(ctx context.Context, productID, variantID string) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepository) UpdateReorderPolicy(ctx context.Context, product *entity.Product) error {
+	return m.Update(ctx, product)
+}
+
+func (m *mockProductRepository) AddImage(ctx context.Context, productID string, image *entity.ProductImage) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepository) DeleteImage(ctx context.Context, productID, imageID string) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepository) ReorderImages(ctx context.Context, productID string, imageIDs []string) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepository) SetPrimaryImage(ctx context.Context, productID, imageID string) error {
+	return m.touch(productID)
+}
+
+// touch stands in for the variant and image writes: the stored product is the one the service changed
+func (m *mockProductRepository) touch(productID string) error {
+	if _, ok := m.products[productID]; !ok {
+		return fmt.Errorf("product %w", repository.ErrNotFound)
+	}
+	return nil
+}
+
 func (m *mockProductRepository) Delete(ctx context.Context, id string) error {
 	if _, ok := m.products[id]; !ok {
-		return errors.New("product not found")
+		return fmt.Errorf("product %w", repository.ErrNotFound)
 	}
 	delete(m.products, id)
 	return nil
@@ -72,6 +181,24 @@ func (m *mockProductRepository) ExistsByID(ctx context.Context, id string) (bool
 	return ok, nil
 }
 
+func (m *mockProductRepository) IsReferencedByOrders(ctx context.Context, id string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockProductRepository) ExistsBySKU(ctx context.Context, sku string) (bool, error) {
+	for _, p := range m.products {
+		if p.SKU() == sku {
+			return true, nil
+		}
+		for _, v := range p.Variants() {
+			if v.SKU() == sku {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 // Ensure mock implements the interface
 var _ repository.ProductRepository = (*mockProductRepository)(nil)
 
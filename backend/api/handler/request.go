@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"ecom-backend/pkg/validate"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// maxBodyBytes caps the size of a JSON request body
+const maxBodyBytes = 1 << 20
+
+// decodeJSON decodes a single JSON object from the request body into dst and validates it.
+// Unknown fields, trailing data and oversized bodies are rejected. On failure it writes the
+// error response and returns false.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(dst)
+	if err == nil && dec.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("request body must contain a single JSON object")
+	}
+	if err != nil {
+		respondWithDecodeError(w, err)
+		return false
+	}
+
+	if err := validate.Struct(dst); err != nil {
+		var fields validate.Errors
+		if errors.As(err, &fields) {
+			respondWithValidationError(w, fields)
+			return false
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+// respondWithDecodeError maps a JSON decoding error to a response
+func respondWithDecodeError(w http.ResponseWriter, err error) {
+	var (
+		maxErr    *http.MaxBytesError
+		typeErr   *json.UnmarshalTypeError
+		syntaxErr *json.SyntaxError
+	)
+
+	switch {
+	case errors.As(err, &maxErr):
+		respondWithError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must not exceed %d bytes", maxErr.Limit))
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		respondWithValidationError(w, []validate.FieldError{{
+			Field: field, Rule: "type", Message: "must be " + jsonType(typeErr.Type.Kind()),
+		}})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		respondWithValidationError(w, []validate.FieldError{{
+			Field: field, Rule: "unknown", Message: "is not a known field",
+		}})
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: malformed JSON")
+	default:
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+	}
+}
+
+// jsonType describes the JSON type expected for a Go kind
+func jsonType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
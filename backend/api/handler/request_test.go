@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"ecom-backend/application/dto"
+	"ecom-backend/pkg/validate"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func decodeRequest(t *testing.T, body string, dst interface{}) (*httptest.ResponseRecorder, bool) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(body))
+	return w, decodeJSON(w, r, dst)
+}
+
+func TestDecodeJSON_Valid(t *testing.T) {
+	var req dto.CreateProductRequest
+	w, ok := decodeRequest(t, `{"name":"Mug","price":900,"currency":"USD","stock":3}`, &req)
+	if !ok {
+		t.Fatalf("expected success, got %d %s", w.Code, w.Body)
+	}
+	if req.Name != "Mug" || req.Price != 900 {
+		t.Errorf("unexpected request: %+v", req)
+	}
+}
+
+func TestDecodeJSON_ReportsAllFieldErrors(t *testing.T) {
+	var req dto.CreateProductRequest
+	w, ok := decodeRequest(t, `{"name":"","price":-1,"currency":"usd","stock":-2,"variants":[{"sku":"","stock":1}]}`, &req)
+	if ok {
+		t.Fatal("expected validation to fail")
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+
+	var resp ValidationErrorResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	want := []validate.FieldError{
+		{Field: "name", Rule: "required", Message: "is required"},
+		{Field: "price", Rule: "min", Message: "must be at least 0"},
+		{Field: "currency", Rule: "currency", Message: "must be a three-letter ISO 4217 currency code"},
+		{Field: "stock", Rule: "min", Message: "must be at least 0"},
+		{Field: "variants[0].sku", Rule: "required", Message: "is required"},
+	}
+	if !reflect.DeepEqual(resp.Fields, want) {
+		t.Errorf("fields =\n%+v\nwant\n%+v", resp.Fields, want)
+	}
+}
+
+func TestDecodeJSON_Rejections(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantField  string
+		wantRule   string
+	}{
+		{"unknown field", `{"basket_id":"3f2b8c1e-9d4a-4b7e-8f00-0123456789ab","coupon":"X"}`, http.StatusBadRequest, "coupon", "unknown"},
+		{"wrong type", `{"basket_id":42}`, http.StatusBadRequest, "basket_id", "type"},
+		{"invalid uuid", `{"basket_id":"basket-1"}`, http.StatusBadRequest, "basket_id", "uuid"},
+		{"malformed", `{"basket_id":`, http.StatusBadRequest, "", ""},
+		{"trailing data", `{"basket_id":"3f2b8c1e-9d4a-4b7e-8f00-0123456789ab"} {}`, http.StatusBadRequest, "", ""},
+		{"too large", `{"basket_id":"` + strings.Repeat("a", maxBodyBytes) + `"}`, http.StatusRequestEntityTooLarge, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req dto.CreateOrderRequest
+			w, ok := decodeRequest(t, tt.body, &req)
+			if ok {
+				t.Fatal("expected the request to be rejected")
+			}
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body)
+			}
+
+			var resp ValidationErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error == "" {
+				t.Error("expected an error message")
+			}
+			if tt.wantField == "" {
+				return
+			}
+			if len(resp.Fields) != 1 || resp.Fields[0].Field != tt.wantField || resp.Fields[0].Rule != tt.wantRule {
+				t.Errorf("fields = %+v, want %s/%s", resp.Fields, tt.wantField, tt.wantRule)
+			}
+		})
+	}
+}
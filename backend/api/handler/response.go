@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"ecom-backend/application/dto"
+	"ecom-backend/pkg/validate"
 	"encoding/json"
 	"net/http"
 )
@@ -10,6 +12,18 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// ValidationErrorResponse represents a request rejected because of invalid fields
+type ValidationErrorResponse struct {
+	Error  string                `json:"error"`
+	Fields []validate.FieldError `json:"fields"`
+}
+
+// StaleBasketResponse represents a checkout refused because basket prices have changed
+type StaleBasketResponse struct {
+	Error        string                          `json:"error"`
+	Revalidation *dto.BasketRevalidationResponse `json:"revalidation"`
+}
+
 // respondWithError sends an error response
 func respondWithError(w http.ResponseWriter, code int, message string) {
 	respondWithJSON(w, code, ErrorResponse{Error: message})
@@ -24,3 +38,8 @@ func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 		json.NewEncoder(w).Encode(payload)
 	}
 }
+
+// respondWithValidationError sends every field error in one response
+func respondWithValidationError(w http.ResponseWriter, fields []validate.FieldError) {
+	respondWithJSON(w, http.StatusBadRequest, ValidationErrorResponse{Error: "Validation failed", Fields: fields})
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// ReviewHandler handles product review and moderation HTTP requests
+type ReviewHandler struct {
+	reviewService *service.ReviewService
+}
+
+// NewReviewHandler creates a new ReviewHandler
+func NewReviewHandler(reviewService *service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// CreateReview handles POST /products/{id}/reviews
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req dto.CreateReviewRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	review, err := h.reviewService.CreateReview(r.Context(), vars["id"], &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, review)
+}
+
+// ListProductReviews handles GET /products/{id}/reviews?sort=&limit=&offset=
+func (h *ReviewHandler) ListProductReviews(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	req, err := parseReviewListRequest(r.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reviews, err := h.reviewService.ListProductReviews(r.Context(), vars["id"], req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, reviews)
+}
+
+// ListReviews handles GET /reviews?status=&sort=&limit=&offset=
+func (h *ReviewHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	req, err := parseReviewListRequest(r.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reviews, err := h.reviewService.ListReviews(r.Context(), req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, reviews)
+}
+
+// ApproveReview handles POST /reviews/{id}/approve
+func (h *ReviewHandler) ApproveReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req dto.ModerateReviewRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	review, err := h.reviewService.ApproveReview(r.Context(), vars["id"], &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, review)
+}
+
+// RejectReview handles POST /reviews/{id}/reject
+func (h *ReviewHandler) RejectReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req dto.ModerateReviewRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	review, err := h.reviewService.RejectReview(r.Context(), vars["id"], &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, review)
+}
+
+// parseReviewListRequest builds a ReviewListRequest from query parameters
+func parseReviewListRequest(q url.Values) (*dto.ReviewListRequest, error) {
+	req := &dto.ReviewListRequest{
+		Status: q.Get("status"),
+		Sort:   q.Get("sort"),
+	}
+
+	var err error
+	if v := q.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			return nil, errors.New("limit must be an integer")
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if req.Offset, err = strconv.Atoi(v); err != nil {
+			return nil, errors.New("offset must be an integer")
+		}
+	}
+
+	return req, nil
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SearchHandler handles product search HTTP requests
+type SearchHandler struct {
+	searchService *service.SearchService
+}
+
+// NewSearchHandler creates a new SearchHandler
+func NewSearchHandler(searchService *service.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// SearchProducts handles GET /products/search?q=&category=&min_price=&max_price=&in_stock=&limit=&offset=
+func (h *SearchHandler) SearchProducts(w http.ResponseWriter, r *http.Request) {
+	req, err := parseSearchRequest(r.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := h.searchService.SearchProducts(r.Context(), req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, results)
+}
+
+// parseSearchRequest builds a ProductSearchRequest from query parameters
+func parseSearchRequest(q url.Values) (*dto.ProductSearchRequest, error) {
+	req := &dto.ProductSearchRequest{
+		Query:    q.Get("q"),
+		Category: q.Get("category"),
+	}
+
+	var err error
+	if req.MinPrice, err = optionalInt64(q, "min_price"); err != nil {
+		return nil, err
+	}
+	if req.MaxPrice, err = optionalInt64(q, "max_price"); err != nil {
+		return nil, err
+	}
+
+	if v := q.Get("in_stock"); v != "" {
+		inStock, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.New("in_stock must be true or false")
+		}
+		req.InStock = &inStock
+	}
+
+	if v := q.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			return nil, errors.New("limit must be an integer")
+		}
+	}
+	if v := q.Get("offset"); v != "" {
+		if req.Offset, err = strconv.Atoi(v); err != nil {
+			return nil, errors.New("offset must be an integer")
+		}
+	}
+
+	return req, nil
+}
+
+// optionalInt64 parses an optional integer query parameter
+func optionalInt64(q url.Values, key string) (*int64, error) {
+	v := q.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return nil, errors.New(key + " must be an integer")
+	}
+	return &n, nil
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"ecom-backend/application/dto"
+	"ecom-backend/application/service"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// WishlistHandler handles wishlist HTTP requests
+type WishlistHandler struct {
+	wishlistService *service.WishlistService
+}
+
+// NewWishlistHandler creates a new WishlistHandler
+func NewWishlistHandler(wishlistService *service.WishlistService) *WishlistHandler {
+	return &WishlistHandler{
+		wishlistService: wishlistService,
+	}
+}
+
+// CreateWishlist handles POST /wishlists
+func (h *WishlistHandler) CreateWishlist(w http.ResponseWriter, r *http.Request) {
+	wishlist, err := h.wishlistService.CreateWishlist(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, wishlist)
+}
+
+// GetWishlist handles GET /wishlists/{id}
+func (h *WishlistHandler) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	wishlist, err := h.wishlistService.GetWishlist(r.Context(), vars["id"])
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wishlist)
+}
+
+// CreateList handles POST /wishlists/{id}/lists
+func (h *WishlistHandler) CreateList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req dto.WishlistListRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	wishlist, err := h.wishlistService.CreateList(r.Context(), vars["id"], &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, wishlist)
+}
+
+// RenameList handles PATCH /wishlists/{id}/lists/{listId}
+func (h *WishlistHandler) RenameList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req dto.WishlistListRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	wishlist, err := h.wishlistService.RenameList(r.Context(), vars["id"], vars["listId"], &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wishlist)
+}
+
+// DeleteList handles DELETE /wishlists/{id}/lists/{listId}
+func (h *WishlistHandler) DeleteList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	wishlist, err := h.wishlistService.DeleteList(r.Context(), vars["id"], vars["listId"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wishlist)
+}
+
+// AddItem handles POST /wishlists/{id}/lists/{listId}/items
+func (h *WishlistHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req dto.AddWishlistItemRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	wishlist, err := h.wishlistService.AddItem(r.Context(), vars["id"], vars["listId"], &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wishlist)
+}
+
+// UpdateItem handles PATCH /wishlists/{id}/lists/{listId}/items/{productId}?variant_id=
+func (h *WishlistHandler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	variantID := r.URL.Query().Get("variant_id")
+
+	var req dto.UpdateWishlistItemRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	wishlist, err := h.wishlistService.UpdateItem(r.Context(), vars["id"], vars["listId"], vars["productId"], variantID, &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wishlist)
+}
+
+// RemoveItem handles DELETE /wishlists/{id}/lists/{listId}/items/{productId}?variant_id=
+func (h *WishlistHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	variantID := r.URL.Query().Get("variant_id")
+
+	wishlist, err := h.wishlistService.RemoveItem(r.Context(), vars["id"], vars["listId"], vars["productId"], variantID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wishlist)
+}
+
+// MoveToBasket handles POST /wishlists/{id}/lists/{listId}/move
+func (h *WishlistHandler) MoveToBasket(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var req dto.MoveToBasketRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	moved, err := h.wishlistService.MoveToBasket(r.Context(), vars["id"], vars["listId"], &req)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, moved)
+}
+
+// ShareList handles POST /wishlists/{id}/lists/{listId}/share
+func (h *WishlistHandler) ShareList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	wishlist, err := h.wishlistService.ShareList(r.Context(), vars["id"], vars["listId"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wishlist)
+}
+
+// UnshareList handles DELETE /wishlists/{id}/lists/{listId}/share
+func (h *WishlistHandler) UnshareList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	wishlist, err := h.wishlistService.UnshareList(r.Context(), vars["id"], vars["listId"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wishlist)
+}
+
+// GetSharedList handles GET /wishlists/shared/{token}
+func (h *WishlistHandler) GetSharedList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	list, err := h.wishlistService.GetSharedList(r.Context(), vars["token"])
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, list)
+}
@@ -1,19 +1,200 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
-// CORS adds CORS headers to responses
-func CORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	"github.com/gorilla/mux"
+)
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+// CORSConfig configures cross-origin resource sharing
+type CORSConfig struct {
+	// AllowedOrigins are exact origins such as https://shop.example.com, wildcard
+	// subdomains such as https://*.example.com, or "*" for any origin
+	AllowedOrigins []string
+
+	// AllowedMethods limits the methods offered in preflight responses
+	AllowedMethods []string
+
+	// AllowedHeaders are the request headers a browser may send
+	AllowedHeaders []string
+
+	// ExposedHeaders are the response headers scripts may read
+	ExposedHeaders []string
+
+	// AllowCredentials permits cookies and authorization headers on cross-origin requests
+	AllowCredentials bool
+
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+}
+
+// candidateMethods are the methods checked against the router when answering OPTIONS
+var candidateMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+// originPattern matches an exact origin or any subdomain of a wildcard origin
+type originPattern struct {
+	scheme string
+	host   string
+	suffix string
+}
+
+func (p originPattern) matches(scheme, host string) bool {
+	if scheme != p.scheme {
+		return false
+	}
+	if p.suffix != "" {
+		return strings.HasSuffix(host, p.suffix) && len(host) > len(p.suffix)
+	}
+	return host == p.host
+}
+
+// parseOrigin splits an origin into a lowercase scheme and host, rejecting paths and queries
+func parseOrigin(origin string) (scheme, host string, ok bool) {
+	u, err := url.Parse(strings.ToLower(origin))
+	if err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" || u.RawQuery != "" || u.User != nil {
+		return "", "", false
+	}
+	return u.Scheme, u.Host, true
+}
+
+// parseOriginPattern parses an exact origin or a wildcard-subdomain origin
+func parseOriginPattern(pattern string) (originPattern, bool) {
+	wildcard := false
+	if i := strings.Index(pattern, "://*."); i > 0 {
+		wildcard = true
+		pattern = pattern[:i+3] + pattern[i+5:]
+	}
+
+	scheme, host, ok := parseOrigin(pattern)
+	if !ok || strings.Contains(host, "*") {
+		return originPattern{}, false
+	}
+	if wildcard {
+		return originPattern{scheme: scheme, suffix: "." + host}, true
+	}
+	return originPattern{scheme: scheme, host: host}, true
+}
+
+// CORS applies the cross-origin policy to every routed request and answers OPTIONS
+// requests itself, advertising only the methods registered for the requested path
+func CORS(router *mux.Router, cfg CORSConfig) mux.MiddlewareFunc {
+	allowAny := false
+	var patterns []originPattern
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+			continue
+		}
+		if p, ok := parseOriginPattern(origin); ok {
+			patterns = append(patterns, p)
 		}
+	}
 
-		next.ServeHTTP(w, r)
-	})
+	allowedMethods := make(map[string]bool, len(cfg.AllowedMethods))
+	for _, m := range cfg.AllowedMethods {
+		allowedMethods[strings.ToUpper(m)] = true
+	}
+
+	allowedHeaders := strings.Join(cfg.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	// allowOrigin returns the Access-Control-Allow-Origin value for a request origin, or "" to deny
+	allowOrigin := func(origin string) string {
+		if origin == "" {
+			return ""
+		}
+		if allowAny && !cfg.AllowCredentials {
+			return "*"
+		}
+		scheme, host, ok := parseOrigin(origin)
+		if !ok {
+			return ""
+		}
+		if allowAny {
+			return origin
+		}
+		for _, p := range patterns {
+			if p.matches(scheme, host) {
+				return origin
+			}
+		}
+		return ""
+	}
+
+	// The response depends on the request origin unless every origin gets "*"
+	varyOrigin := !allowAny || cfg.AllowCredentials
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			allowed := allowOrigin(origin)
+
+			if varyOrigin {
+				w.Header().Add("Vary", "Origin")
+			}
+			if allowed != "" {
+				w.Header().Set("Access-Control-Allow-Origin", allowed)
+				if cfg.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+			}
+
+			if r.Method != http.MethodOptions {
+				if allowed != "" && exposedHeaders != "" {
+					w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Answer OPTIONS from the routing table rather than running a handler
+			methods := routeMethods(router, r)
+			w.Header().Set("Allow", strings.Join(append(methods, http.MethodOptions), ", "))
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+			requested := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+			if allowed != "" && requested != "" {
+				var offered []string
+				for _, m := range methods {
+					if allowedMethods[m] {
+						offered = append(offered, m)
+					}
+				}
+				if len(offered) > 0 {
+					w.Header().Set("Access-Control-Allow-Methods", strings.Join(offered, ", "))
+					if allowedHeaders != "" {
+						w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+					}
+					if cfg.MaxAge > 0 {
+						w.Header().Set("Access-Control-Max-Age", maxAge)
+					}
+				}
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// routeMethods returns the methods, other than OPTIONS, that the router would accept for the request's path
+func routeMethods(router *mux.Router, r *http.Request) []string {
+	var methods []string
+	for _, m := range candidateMethods {
+		probe := r.Clone(r.Context())
+		probe.Method = m
+
+		var match mux.RouteMatch
+		if router.Match(probe, &match) && match.MatchErr == nil {
+			methods = append(methods, m)
+		}
+	}
+	return methods
 }
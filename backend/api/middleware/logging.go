@@ -1,19 +1,24 @@
 package middleware
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"net"
 	"net/http"
 	"time"
+
+	"github.com/gorilla/mux"
 )
 
-// responseWriter wraps http.ResponseWriter to capture status code
+// responseWriter wraps http.ResponseWriter to capture status code and body size
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
+	bytes      int64
 }
 
 func newResponseWriter(w http.ResponseWriter) *responseWriter {
-	return &responseWriter{w, http.StatusOK}
+	return &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
@@ -21,20 +26,93 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-// Logging logs HTTP requests
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += int64(n)
+	return n, err
+}
+
+// Flush forwards flushes so streaming responses keep working
+func (rw *responseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// requestCaller collects who made a request as middleware further down the chain
+// identifies them, so the request log can record it once the handler returns
+type requestCaller struct {
+	apiKeyID string
+}
+
+type callerContextKey struct{}
+
+// setCallerAPIKeyID records the API key ID on the request's caller, if Logging is in the chain
+func setCallerAPIKeyID(ctx context.Context, id string) {
+	if c, ok := ctx.Value(callerContextKey{}).(*requestCaller); ok {
+		c.apiKeyID = id
+	}
+}
+
+// Logging logs each HTTP request as a structured record
 func Logging(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
+		caller := &requestCaller{}
+		r = r.WithContext(context.WithValue(r.Context(), callerContextKey{}, caller))
+
 		wrapped := newResponseWriter(w)
 		next.ServeHTTP(wrapped, r)
 
-		log.Printf(
-			"%s %s %d %s",
-			r.Method,
-			r.RequestURI,
-			wrapped.statusCode,
-			time.Since(start),
+		callerAttrs := []interface{}{
+			slog.String("ip", clientIP(r)),
+			slog.String("user_agent", r.UserAgent()),
+		}
+		if caller.apiKeyID != "" {
+			callerAttrs = append(callerAttrs, slog.String("api_key_id", caller.apiKeyID))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case wrapped.statusCode >= 500:
+			level = slog.LevelError
+		case wrapped.statusCode >= 400:
+			level = slog.LevelWarn
+		}
+
+		slog.LogAttrs(r.Context(), level, "http request",
+			slog.String("method", r.Method),
+			slog.String("route", routeTemplate(r)),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", wrapped.statusCode),
+			slog.Duration("latency", time.Since(start)),
+			slog.Int64("bytes", wrapped.bytes),
+			slog.Group("caller", callerAttrs...),
 		)
 	})
 }
+
+// routeTemplate returns the matched mux route template, such as /api/v1/products/{id}
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return ""
+}
+
+// clientIP returns the remote address without its port
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
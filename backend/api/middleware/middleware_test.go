@@ -0,0 +1,482 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"ecom-backend/infrastructure/logging"
+	"ecom-backend/infrastructure/metrics"
+	"ecom-backend/pkg/ratelimit"
+	"ecom-backend/pkg/tracing"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logging.RequestID(r.Context())
+	}))
+
+	t.Run("accepts client ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, "abc-123")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if seen != "abc-123" || w.Header().Get(RequestIDHeader) != "abc-123" {
+			t.Errorf("expected client ID to be propagated, got %q / %q", seen, w.Header().Get(RequestIDHeader))
+		}
+	})
+
+	t.Run("replaces invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, strings.Repeat("x", maxRequestIDLength+1))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if len(seen) != 36 || w.Header().Get(RequestIDHeader) != seen {
+			t.Errorf("expected generated ID, got %q", seen)
+		}
+	})
+}
+
+func TestLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := logging.New(&buf, logging.FormatJSON, "info")
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	defer slog.SetDefault(previous)
+
+	r := mux.NewRouter()
+	r.Use(RequestID)
+	r.Use(Logging)
+	r.HandleFunc("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("missing"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/products/42", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON log record, got %q", buf.String())
+	}
+
+	if record["route"] != "/products/{id}" || record["status"] != float64(404) || record["bytes"] != float64(7) {
+		t.Errorf("unexpected record: %v", record)
+	}
+	if record["request_id"] != "req-1" || record["level"] != "WARN" {
+		t.Errorf("expected request ID and WARN level, got %v", record)
+	}
+}
+
+func TestLogging_APIKeyID(t *testing.T) {
+	const key = "0123456789abcdef0123"
+
+	var buf bytes.Buffer
+	logger, _ := logging.New(&buf, logging.FormatJSON, "info")
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	defer slog.SetDefault(previous)
+
+	// The key is checked on a subrouter, inside the logging middleware
+	r := mux.NewRouter()
+	r.Use(Logging)
+	api := r.PathPrefix("/api/v1").Subrouter()
+	api.Use(APIKey(APIKeyConfig{Keys: []string{key}, Header: "X-API-Key"}))
+	api.HandleFunc("/products", func(w http.ResponseWriter, r *http.Request) {})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+	req.Header.Set("X-API-Key", key)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var record struct {
+		Caller map[string]string `json:"caller"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON log record, got %q", buf.String())
+	}
+	if record.Caller["api_key_id"] != apiKeyID(key) {
+		t.Errorf("expected the API key ID in the caller, got %v", record.Caller)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	m := metrics.New()
+
+	r := mux.NewRouter()
+	r.Use(Metrics(m))
+	r.Handle("/metrics", m.Handler()).Methods("GET")
+	api := r.PathPrefix("/api/v1").Subrouter()
+	api.HandleFunc("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods("GET")
+
+	for _, id := range []string{"1", "2"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/products/"+id, nil))
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	want := `ecom_http_requests_total{method="GET",route="/api/v1/products/{id}",status="404"} 2`
+	if !strings.Contains(w.Body.String(), want) {
+		t.Errorf("expected scrape to contain %q, got:\n%s", want, w.Body.String())
+	}
+}
+
+func TestTracing(t *testing.T) {
+	var buf bytes.Buffer
+	previous := tracing.Default()
+	tracing.SetDefault(tracing.NewTracer(tracing.NewJSONExporter(&buf)))
+	defer tracing.SetDefault(previous)
+
+	var inner tracing.SpanContext
+	r := mux.NewRouter()
+	r.Use(Tracing)
+	r.HandleFunc("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		inner = tracing.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/products/42", nil)
+	req.Header.Set(tracing.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if inner.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected handler to continue the caller's trace, got %s", inner.TraceID)
+	}
+	if got := w.Header().Get(tracing.TraceparentHeader); got != tracing.FormatTraceparent(inner) {
+		t.Errorf("expected response traceparent %q, got %q", tracing.FormatTraceparent(inner), got)
+	}
+
+	var span tracing.SpanData
+	if err := json.Unmarshal(buf.Bytes(), &span); err != nil {
+		t.Fatalf("invalid span record: %v", err)
+	}
+	if span.Name != "GET /products/{id}" || span.Kind != tracing.SpanKindServer || span.Status != tracing.StatusError {
+		t.Errorf("unexpected span: %+v", span)
+	}
+	if span.ParentSpanID != "00f067aa0ba902b7" || span.Attributes["http.status_code"] != float64(500) {
+		t.Errorf("unexpected span parent or status code: %+v", span)
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	const key = "0123456789abcdef0123"
+
+	var seen string
+	handler := APIKey(APIKeyConfig{Keys: []string{key}, Header: "X-API-Key", Required: true})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = APIKeyID(r.Context())
+		}),
+	)
+
+	tests := []struct {
+		name   string
+		method string
+		key    string
+		status int
+	}{
+		{"read without key", http.MethodGet, "", http.StatusOK},
+		{"write without key", http.MethodPost, "", http.StatusUnauthorized},
+		{"invalid key", http.MethodGet, "wrong", http.StatusUnauthorized},
+		{"valid key", http.MethodPost, key, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = ""
+			req := httptest.NewRequest(tt.method, "/api/v1/products", nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected %d, got %d", tt.status, w.Code)
+			}
+			if tt.key == key && (seen == "" || strings.Contains(key, seen)) {
+				t.Errorf("expected a non-secret key ID, got %q", seen)
+			}
+		})
+	}
+}
+
+func TestRequireAPIKey(t *testing.T) {
+	const key = "0123456789abcdef0123"
+
+	// Keys are optional, but the guarded handler still needs one
+	handler := APIKey(APIKeyConfig{Keys: []string{key}, Header: "X-API-Key"})(
+		RequireAPIKey(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})),
+	)
+
+	tests := []struct {
+		name   string
+		method string
+		key    string
+		status int
+	}{
+		{"read without key", http.MethodGet, "", http.StatusUnauthorized},
+		{"write without key", http.MethodDelete, "", http.StatusUnauthorized},
+		{"invalid key", http.MethodDelete, "wrong", http.StatusUnauthorized},
+		{"valid key", http.MethodDelete, key, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/v1/products/1/purge", nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("expected %d, got %d", tt.status, w.Code)
+			}
+		})
+	}
+}
+
+// newCORSRouter builds a router with the CORS middleware and a few routes
+func newCORSRouter(cfg CORSConfig) *mux.Router {
+	r := mux.NewRouter()
+	r.Use(CORS(r, cfg))
+
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	r.HandleFunc("/products", ok).Methods("GET", "OPTIONS")
+	r.HandleFunc("/products", ok).Methods("POST", "OPTIONS")
+	r.HandleFunc("/products/{id}", ok).Methods("GET", "OPTIONS")
+	r.HandleFunc("/products/{id}", ok).Methods("DELETE", "OPTIONS")
+	return r
+}
+
+func TestCORS_Origins(t *testing.T) {
+	r := newCORSRouter(CORSConfig{
+		AllowedOrigins: []string{"https://shop.example.com", "https://*.example.org"},
+		ExposedHeaders: []string{"X-Request-ID"},
+	})
+
+	tests := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"https://shop.example.com", true},
+		{"https://admin.example.org", true},
+		{"https://a.b.example.org", true},
+		{"https://example.org", false},
+		{"http://admin.example.org", false},
+		{"https://evil.example.com", false},
+		{"https://shop.example.com.evil.io", false},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.Header.Set("Origin", tt.origin)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		got := w.Header().Get("Access-Control-Allow-Origin")
+		if tt.allowed && (got != tt.origin || w.Header().Get("Access-Control-Expose-Headers") != "X-Request-ID") {
+			t.Errorf("%s: expected origin to be allowed, got %q", tt.origin, got)
+		}
+		if !tt.allowed && got != "" {
+			t.Errorf("%s: expected origin to be denied, got %q", tt.origin, got)
+		}
+		if w.Header().Get("Vary") != "Origin" {
+			t.Errorf("%s: expected Vary: Origin, got %q", tt.origin, w.Header().Get("Vary"))
+		}
+	}
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set("Origin", "https://shop.example.com")
+
+	w := httptest.NewRecorder()
+	newCORSRouter(CORSConfig{AllowedOrigins: []string{"*"}}).ServeHTTP(w, req)
+	if w.Header().Get("Access-Control-Allow-Origin") != "*" || w.Header().Get("Vary") != "" {
+		t.Errorf("expected wildcard without Vary, got %v", w.Header())
+	}
+
+	// Credentialed responses may not use "*", so the origin is reflected instead
+	w = httptest.NewRecorder()
+	newCORSRouter(CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true}).ServeHTTP(w, req)
+	if w.Header().Get("Access-Control-Allow-Origin") != "https://shop.example.com" ||
+		w.Header().Get("Access-Control-Allow-Credentials") != "true" || w.Header().Get("Vary") != "Origin" {
+		t.Errorf("expected reflected origin with credentials, got %v", w.Header())
+	}
+}
+
+func TestCORS_PreflightReflectsRouteMethods(t *testing.T) {
+	r := newCORSRouter(CORSConfig{
+		AllowedOrigins: []string{"https://shop.example.com"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+		AllowedHeaders: []string{"Content-Type", "X-API-Key"},
+		MaxAge:         10 * time.Minute,
+	})
+
+	tests := []struct {
+		path    string
+		methods string
+	}{
+		{"/products", "GET, POST"},
+		{"/products/42", "GET, DELETE"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodOptions, tt.path, nil)
+		req.Header.Set("Origin", "https://shop.example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNoContent {
+			t.Errorf("%s: expected 204, got %d", tt.path, w.Code)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Methods"); got != tt.methods {
+			t.Errorf("%s: expected methods %q, got %q", tt.path, tt.methods, got)
+		}
+		if got := w.Header().Get("Allow"); got != tt.methods+", OPTIONS" {
+			t.Errorf("%s: expected Allow %q, got %q", tt.path, tt.methods+", OPTIONS", got)
+		}
+		if w.Header().Get("Access-Control-Allow-Headers") != "Content-Type, X-API-Key" || w.Header().Get("Access-Control-Max-Age") != "600" {
+			t.Errorf("%s: unexpected preflight headers %v", tt.path, w.Header())
+		}
+		if vary := w.Header().Values("Vary"); len(vary) != 3 {
+			t.Errorf("%s: expected Vary on origin and request method and headers, got %v", tt.path, vary)
+		}
+	}
+
+	// Preflight from an unknown origin gets no CORS grant
+	req := httptest.NewRequest(http.MethodOptions, "/products", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Header().Get("Access-Control-Allow-Methods") != "" || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("expected denied preflight, got %v", w.Header())
+	}
+}
+
+// failingStore is a rate limit store that is always unavailable
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func newRateLimitRouter(store ratelimit.Store) *mux.Router {
+	r := mux.NewRouter()
+	r.Use(APIKey(APIKeyConfig{Keys: []string{"0123456789abcdef0123"}, Header: "X-API-Key"}))
+	r.Use(RateLimit(store, RateLimitConfig{Rules: []RateLimitRule{
+		{Group: "checkout", Methods: []string{"POST"}, Routes: []string{"/orders"}, Limit: ratelimit.Limit{RequestsPerMinute: 60, Burst: 2}},
+		{Group: "basket", Methods: []string{"POST"}, Routes: []string{"/baskets/*"}, Limit: ratelimit.Limit{RequestsPerMinute: 60, Burst: 1}},
+	}}))
+
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	r.HandleFunc("/orders", ok).Methods("GET", "POST")
+	r.HandleFunc("/baskets/{id}/items", ok).Methods("POST")
+	return r
+}
+
+func TestRateLimit(t *testing.T) {
+	r := newRateLimitRouter(ratelimit.NewMemoryStore())
+
+	send := func(method, path, ip, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = ip + ":1234"
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		w := send(http.MethodPost, "/orders", "10.0.0.1", "")
+		if w.Code != http.StatusOK || w.Header().Get("RateLimit-Remaining") != strconv.Itoa(1-i) {
+			t.Fatalf("request %d: expected allowed with %d remaining, got %d %v", i, 1-i, w.Code, w.Header())
+		}
+	}
+
+	w := send(http.MethodPost, "/orders", "10.0.0.1", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "1" || w.Header().Get("RateLimit-Limit") != "2" || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("unexpected rate limit headers: %v", w.Header())
+	}
+	if w.Header().Get("RateLimit-Policy") != `2;w=2;pk="checkout"` {
+		t.Errorf("unexpected policy %q", w.Header().Get("RateLimit-Policy"))
+	}
+
+	// Other groups, unlimited routes, other IPs and API keys have their own budgets
+	if w := send(http.MethodPost, "/baskets/1/items", "10.0.0.1", ""); w.Code != http.StatusOK {
+		t.Errorf("expected basket group to have its own bucket, got %d", w.Code)
+	}
+	if w := send(http.MethodGet, "/orders", "10.0.0.1", ""); w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("expected unmatched request to pass without headers, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/orders", "10.0.0.2", ""); w.Code != http.StatusOK {
+		t.Errorf("expected another IP to be allowed, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/orders", "10.0.0.1", "0123456789abcdef0123"); w.Code != http.StatusOK {
+		t.Errorf("expected API key to be limited separately from its IP, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_FailsOpen(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	w := httptest.NewRecorder()
+	newRateLimitRouter(failingStore{}).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected request to pass when the store fails, got %d", w.Code)
+	}
+}
+
+func TestRateLimitClient_ForwardedFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		forwarded []string
+		proxies   int
+		want      string
+	}{
+		{"header ignored without trusted proxies", []string{"203.0.113.7"}, 0, "ip:10.0.0.9"},
+		{"address added by the proxy", []string{"203.0.113.7"}, 1, "ip:203.0.113.7"},
+		{"spoofed entries are skipped", []string{"198.51.100.1, 198.51.100.2, 203.0.113.7"}, 1, "ip:203.0.113.7"},
+		{"repeated headers", []string{"198.51.100.1", "203.0.113.7, 10.0.0.5"}, 2, "ip:203.0.113.7"},
+		{"fewer entries than proxies", []string{"203.0.113.7"}, 2, "ip:10.0.0.9"},
+		{"malformed entry", []string{"not-an-ip"}, 1, "ip:10.0.0.9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+			req.RemoteAddr = "10.0.0.9:1234"
+			for _, header := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", header)
+			}
+
+			if got := rateLimitClient(req, tt.proxies); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
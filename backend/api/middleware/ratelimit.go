@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"ecom-backend/pkg/ratelimit"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// RateLimitRule applies a limit to the requests of a route group
+type RateLimitRule struct {
+	// Group names the rule in bucket keys and log records
+	Group string
+
+	// Methods restricts the rule to these methods; empty matches any method
+	Methods []string
+
+	// Routes are the route templates in the group, such as /api/v1/orders, or prefixes
+	// ending in /* such as /api/v1/baskets/*; empty matches any route
+	Routes []string
+
+	Limit ratelimit.Limit
+}
+
+// matches reports whether the rule covers the request
+func (rule RateLimitRule) matches(method, route string) bool {
+	if len(rule.Methods) > 0 && !contains(rule.Methods, method) {
+		return false
+	}
+	if len(rule.Routes) == 0 {
+		return true
+	}
+	for _, pattern := range rule.Routes {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if route == prefix || strings.HasPrefix(route, prefix+"/") {
+				return true
+			}
+		} else if route == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimitConfig configures request rate limiting
+type RateLimitConfig struct {
+	// Rules are checked in order and the first match applies; unmatched requests are not limited
+	Rules []RateLimitRule
+
+	// TrustedProxies is the number of proxies in front of the server that append to
+	// X-Forwarded-For. Clients are identified by the address the outermost of them added,
+	// counting from the right, since entries further left are whatever the client sent.
+	// Zero ignores the header.
+	TrustedProxies int
+}
+
+// RateLimit limits each client to the token-bucket limit of the request's route group.
+// Clients are identified by API key when one was authenticated, otherwise by IP address.
+func RateLimit(store ratelimit.Store, cfg RateLimitConfig) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			route := routeTemplate(r)
+			var rule *RateLimitRule
+			for i := range cfg.Rules {
+				if cfg.Rules[i].matches(r.Method, route) {
+					rule = &cfg.Rules[i]
+					break
+				}
+			}
+			if rule == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client := rateLimitClient(r, cfg.TrustedProxies)
+			result, err := store.Take(r.Context(), rule.Group+":"+client, rule.Limit)
+			if err != nil {
+				// Fail open: an unavailable store must not take the API down
+				slog.WarnContext(r.Context(), "rate limit store unavailable", "group", rule.Group, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Policy", strconv.Itoa(rule.Limit.Burst)+";w="+strconv.Itoa(windowSeconds(rule.Limit))+`;pk="`+rule.Group+`"`)
+			h.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				h.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				slog.WarnContext(r.Context(), "rate limit exceeded", "group", rule.Group, "client", client)
+				writeError(w, http.StatusTooManyRequests, "rate limit exceeded, retry later")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitClient identifies the caller for rate limiting
+func rateLimitClient(r *http.Request, trustedProxies int) string {
+	if id := APIKeyID(r.Context()); id != "" {
+		return "key:" + id
+	}
+	if ip := forwardedClientIP(r, trustedProxies); ip != "" {
+		return "ip:" + ip
+	}
+	return "ip:" + clientIP(r)
+}
+
+// forwardedClientIP returns the X-Forwarded-For address added by the outermost of the
+// trusted proxies, or "" when there are none or the header is too short or malformed
+func forwardedClientIP(r *http.Request, trustedProxies int) string {
+	if trustedProxies <= 0 {
+		return ""
+	}
+
+	// Repeated headers form one list, in order
+	var entries []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		entries = append(entries, strings.Split(header, ",")...)
+	}
+	if len(entries) < trustedProxies {
+		return ""
+	}
+
+	ip := strings.TrimSpace(entries[len(entries)-trustedProxies])
+	if net.ParseIP(ip) == nil {
+		return ""
+	}
+	return ip
+}
+
+// windowSeconds is the time the limit takes to refill a full burst
+func windowSeconds(limit ratelimit.Limit) int {
+	if limit.RequestsPerMinute <= 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(limit.Burst) * 60 / float64(limit.RequestsPerMinute)))
+}
+
+// ceilSeconds rounds a duration up to whole seconds
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// contains reports whether values contains value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// writeError writes a JSON error in the same shape as handler errors
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"ecom-backend/infrastructure/logging"
+	"ecom-backend/pkg/tracing"
+	"net/http"
+	"strconv"
+)
+
+// Tracing starts a server span per request, continuing any W3C traceparent sent by the caller
+// and returning the span's own traceparent in the response
+func Tracing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeTemplate(r)
+		name := r.Method + " " + route
+		if route == "" {
+			name = r.Method
+		}
+
+		ctx := tracing.Extract(r.Context(), r.Header)
+		ctx, span := tracing.Start(ctx, name,
+			tracing.WithKind(tracing.SpanKindServer),
+			tracing.WithAttributes(
+				"http.method", r.Method,
+				"http.route", route,
+				"http.target", r.URL.Path,
+			),
+		)
+		defer span.End()
+
+		if id := logging.RequestID(ctx); id != "" {
+			span.SetAttribute("request_id", id)
+		}
+		tracing.Inject(ctx, w.Header())
+
+		wrapped := newResponseWriter(w)
+		next.ServeHTTP(wrapped, r.WithContext(ctx))
+
+		span.SetAttribute("http.status_code", wrapped.statusCode)
+		if wrapped.statusCode >= 500 {
+			span.SetStatus(tracing.StatusError, strconv.Itoa(wrapped.statusCode)+" "+http.StatusText(wrapped.statusCode))
+		}
+	})
+}
@@ -0,0 +1,246 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// apiPrefix is the path prefix of the documented routes
+const apiPrefix = "/api/v1"
+
+// Options configures specification generation
+type Options struct {
+	// DTODir is the source directory of the DTO package, read for schema descriptions
+	DTODir string
+
+	// APIKeyHeader is the header clients send their API key in
+	APIKeyHeader string
+}
+
+// pathParam matches a mux path variable with an optional pattern, e.g. {key:.+}
+var pathParam = regexp.MustCompile(`\{(\w+)(?::[^}]*)?\}`)
+
+// Generate builds an OpenAPI 3.1 document from the routes registered on r and the
+// operations table. Every API route must have an operation and every operation a route.
+func Generate(r *mux.Router, opts Options) ([]byte, error) {
+	docs := map[string]string{}
+	if opts.DTODir != "" {
+		var err error
+		if docs, err = ParseDocs(opts.DTODir); err != nil {
+			return nil, err
+		}
+	}
+	b := newSchemaBuilder(docs)
+
+	errSchema, err := b.schemaOf(errorSchema)
+	if err != nil {
+		return nil, err
+	}
+	invalidSchema, err := b.schemaOf(validationErrorSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]map[string]interface{})
+	documented := make(map[string]bool)
+	tags := make(map[string]bool)
+
+	err = r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil || !strings.HasPrefix(template, apiPrefix) {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		path := pathParam.ReplaceAllString(template, "{$1}")
+		for _, method := range methods {
+			if method == http.MethodOptions || method == http.MethodHead {
+				continue
+			}
+
+			key := method + " " + path
+			op, ok := operations[key]
+			if !ok {
+				return fmt.Errorf("route %s is not documented in the operations table", key)
+			}
+			if documented[key] {
+				return fmt.Errorf("route %s is registered more than once", key)
+			}
+			documented[key] = true
+			tags[op.tag] = true
+
+			built, err := buildOperation(b, op, path, errSchema, invalidSchema)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			if paths[path] == nil {
+				paths[path] = make(map[string]interface{})
+			}
+			paths[path][strings.ToLower(method)] = built
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range operations {
+		if !documented[key] {
+			return nil, fmt.Errorf("operation %s has no registered route", key)
+		}
+	}
+
+	tagList := make([]string, 0, len(tags))
+	for tag := range tags {
+		tagList = append(tagList, tag)
+	}
+	sort.Strings(tagList)
+	tagObjects := make([]interface{}, len(tagList))
+	for i, tag := range tagList {
+		tagObjects[i] = map[string]string{"name": tag}
+	}
+
+	header := opts.APIKeyHeader
+	if header == "" {
+		header = "X-API-Key"
+	}
+
+	doc := map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":       "E-commerce API",
+			"version":     "1.0.0",
+			"description": "Products, baskets and orders. Amounts are integers in the currency's minor unit (cents).",
+		},
+		"servers":  []interface{}{map[string]string{"url": "/"}},
+		"tags":     tagObjects,
+		"paths":    paths,
+		"security": []interface{}{map[string]interface{}{}, map[string]interface{}{"apiKey": []string{}}},
+		"components": map[string]interface{}{
+			"schemas": b.components,
+			"securitySchemes": map[string]interface{}{
+				"apiKey": map[string]string{"type": "apiKey", "in": "header", "name": header},
+			},
+			"responses": map[string]interface{}{
+				"Unauthorized":    errorResponse("Missing or invalid API key", errSchema, nil),
+				"TooManyRequests": errorResponse("Rate limit exceeded", errSchema, map[string]interface{}{"Retry-After": map[string]interface{}{"description": "Seconds until a request will be allowed", "schema": schema{"type": "integer"}}}),
+				"InternalError":   errorResponse("Unexpected server error", errSchema, nil),
+			},
+		},
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// buildOperation renders an operation object
+func buildOperation(b *schemaBuilder, op operation, path string, errSchema, invalidSchema schema) (map[string]interface{}, error) {
+	parameters := []interface{}{}
+	for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+		parameters = append(parameters, map[string]interface{}{
+			"name": m[1], "in": "path", "required": true, "schema": schema{"type": "string"},
+		})
+	}
+	for _, q := range op.query {
+		parameters = append(parameters, map[string]interface{}{
+			"name": q.name, "in": "query", "description": q.description, "schema": q.schema,
+		})
+	}
+
+	out := map[string]interface{}{
+		"operationId": op.id,
+		"summary":     op.summary,
+		"tags":        []string{op.tag},
+	}
+	if len(parameters) > 0 {
+		out["parameters"] = parameters
+	}
+	if op.admin {
+		out["security"] = []interface{}{map[string]interface{}{"apiKey": []string{}}}
+	}
+
+	switch {
+	case op.request != nil:
+		s, err := b.schemaOf(op.request)
+		if err != nil {
+			return nil, err
+		}
+		out["requestBody"] = map[string]interface{}{"required": true, "content": jsonContent(s)}
+	case op.requestContent != nil:
+		out["requestBody"] = map[string]interface{}{"required": true, "content": content(op.requestContent)}
+	}
+
+	status := op.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := map[string]interface{}{"description": http.StatusText(status)}
+	switch {
+	case op.response != nil:
+		s, err := b.schemaOf(op.response)
+		if err != nil {
+			return nil, err
+		}
+		success["content"] = jsonContent(s)
+	case op.responseContent != nil:
+		success["content"] = content(op.responseContent)
+	}
+
+	responses := map[string]interface{}{
+		strconv.Itoa(status): success,
+		"401":                map[string]string{"$ref": "#/components/responses/Unauthorized"},
+		"429":                map[string]string{"$ref": "#/components/responses/TooManyRequests"},
+		"500":                map[string]string{"$ref": "#/components/responses/InternalError"},
+	}
+	for _, code := range op.errors {
+		responses[strconv.Itoa(code)] = errorResponse(http.StatusText(code), errSchema, nil)
+	}
+	for code, body := range op.errorBodies {
+		s, err := b.schemaOf(body)
+		if err != nil {
+			return nil, err
+		}
+		responses[strconv.Itoa(code)] = errorResponse(http.StatusText(code), s, nil)
+	}
+	// JSON bodies are decoded strictly and validated, reporting every invalid field
+	if op.request != nil {
+		responses["400"] = errorResponse("The body is malformed or has invalid fields", invalidSchema, nil)
+		responses["413"] = errorResponse("The body exceeds the size limit", errSchema, nil)
+	}
+	out["responses"] = responses
+
+	return out, nil
+}
+
+func jsonContent(s schema) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": s}}
+}
+
+func content(bodies map[string]schema) map[string]interface{} {
+	out := make(map[string]interface{}, len(bodies))
+	for contentType, s := range bodies {
+		out[contentType] = map[string]interface{}{"schema": s}
+	}
+	return out
+}
+
+func errorResponse(description string, errSchema schema, headers map[string]interface{}) map[string]interface{} {
+	resp := map[string]interface{}{"description": description, "content": jsonContent(errSchema)}
+	if headers != nil {
+		resp["headers"] = headers
+	}
+	return resp
+}
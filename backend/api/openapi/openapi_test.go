@@ -0,0 +1,148 @@
+package openapi_test
+
+import (
+	"bytes"
+	"ecom-backend/api/graphql"
+	"ecom-backend/api/handler"
+	"ecom-backend/api/openapi"
+	"ecom-backend/api/router"
+	"ecom-backend/infrastructure/config"
+	"ecom-backend/infrastructure/health"
+	"ecom-backend/infrastructure/metrics"
+	"ecom-backend/pkg/ratelimit"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+var update = flag.Bool("update", false, "regenerate openapi.json")
+
+func setupRouter(t *testing.T) *mux.Router {
+	t.Helper()
+	cfg := config.Default()
+	graphQLHandler, err := graphql.NewHandler(nil, nil, nil, nil, graphql.Limits{
+		MaxDepth:      cfg.GraphQL.MaxDepth,
+		MaxComplexity: cfg.GraphQL.MaxComplexity,
+	})
+	if err != nil {
+		t.Fatalf("building GraphQL handler: %v", err)
+	}
+	return router.Setup(
+		handler.NewProductHandler(nil),
+		handler.NewBasketHandler(nil),
+		handler.NewOrderHandler(nil),
+		handler.NewWishlistHandler(nil),
+		handler.NewReviewHandler(nil),
+		handler.NewSearchHandler(nil),
+		handler.NewMediaHandler(nil),
+		handler.NewEventsHandler(nil, time.Second),
+		handler.NewJobsHandler(nil),
+		graphQLHandler,
+		metrics.New(),
+		health.NewRegistry(time.Second),
+		cfg,
+		ratelimit.NewMemoryStore(),
+	)
+}
+
+func generate(t *testing.T) []byte {
+	t.Helper()
+	spec, err := openapi.Generate(setupRouter(t), openapi.Options{
+		DTODir:       "../../application/dto",
+		APIKeyHeader: config.Default().Auth.APIKeyHeader,
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	return spec
+}
+
+func TestSpecIsUpToDate(t *testing.T) {
+	spec := generate(t)
+
+	if *update {
+		if err := os.WriteFile("openapi.json", spec, 0o644); err != nil {
+			t.Fatalf("failed to write openapi.json: %v", err)
+		}
+		return
+	}
+
+	if !bytes.Equal(spec, openapi.Spec()) {
+		t.Fatal("openapi.json is out of date with the routes or DTOs; run `go generate ./api/openapi` and commit the result")
+	}
+}
+
+func TestSpec_Structure(t *testing.T) {
+	var doc struct {
+		OpenAPI    string                                       `json:"openapi"`
+		Paths      map[string]map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string               `json:"required"`
+				Properties map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(generate(t), &doc); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("openapi = %q, want 3.1.0", doc.OpenAPI)
+	}
+
+	// Mux patterns are reduced to plain path parameters
+	if _, ok := doc.Paths["/api/v1/media/{key}"]["get"]; !ok {
+		t.Error("expected GET /api/v1/media/{key}")
+	}
+	// OPTIONS and HEAD are handled generically and not documented
+	if _, ok := doc.Paths["/api/v1/media/{key}"]["head"]; ok {
+		t.Error("HEAD should not be documented")
+	}
+
+	create := doc.Paths["/api/v1/products"]["post"]
+	if create["operationId"] != "createProduct" {
+		t.Errorf("operationId = %v, want createProduct", create["operationId"])
+	}
+	if _, ok := create["responses"].(map[string]interface{})["201"]; !ok {
+		t.Error("createProduct should document a 201 response")
+	}
+
+	product := doc.Components.Schemas["ProductResponse"]
+	if _, ok := product.Properties["archived_at"]; !ok {
+		t.Error("ProductResponse should have archived_at")
+	}
+	for _, name := range product.Required {
+		if name == "archived_at" || name == "sku" {
+			t.Errorf("omitempty field %s should not be required", name)
+		}
+	}
+}
+
+func TestSpecHandler(t *testing.T) {
+	r := setupRouter(t)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !bytes.Equal(w.Body.Bytes(), openapi.Spec()) {
+		t.Error("served spec differs from the embedded spec")
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+	if w.Code != http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte("openapi.json")) {
+		t.Errorf("docs page: status = %d", w.Code)
+	}
+}
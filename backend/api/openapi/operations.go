@@ -0,0 +1,455 @@
+package openapi
+
+import (
+	"ecom-backend/api/handler"
+	"ecom-backend/application/dto"
+	"net/http"
+)
+
+// operation describes a route for the specification. The generator fails when a
+// registered route has no operation, so new routes must be documented here.
+type operation struct {
+	id      string
+	summary string
+	tag     string
+
+	// status is the success status code; zero means 200
+	status int
+
+	// request and response are JSON bodies given as zero values of their DTOs; a nil response means no body
+	request  interface{}
+	response interface{}
+
+	// requestContent and responseContent describe non-JSON bodies by content type
+	requestContent  map[string]schema
+	responseContent map[string]schema
+
+	query  []param
+	errors []int
+
+	// errorBodies documents error responses whose body is not the common error schema
+	errorBodies map[int]interface{}
+
+	// admin marks routes that always need an API key
+	admin bool
+}
+
+// param is a query parameter
+type param struct {
+	name        string
+	schema      schema
+	description string
+}
+
+// binary is the schema of a raw file body
+var binary = schema{"type": "string", "contentMediaType": "application/octet-stream"}
+
+// graphQLResult is the envelope of every GraphQL response
+var graphQLResult = schema{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"data":   schema{"type": []string{"object", "null"}},
+		"errors": schema{"type": "array", "items": schema{"type": "object"}},
+	},
+}
+
+// reviewPaging are the sorting and paging parameters of review lists
+var reviewPaging = []param{
+	{"sort", schema{"type": "string", "enum": []string{"newest", "oldest", "highest", "lowest"}}, "Sort order; defaults to newest"},
+	{"limit", schema{"type": "integer"}, "Page size; defaults to 20, at most 100"},
+	{"offset", schema{"type": "integer"}, "Number of reviews to skip"},
+}
+
+// operations maps "METHOD /path/template" to the route's documentation
+var operations = map[string]operation{
+	// Products
+	"GET /api/v1/products/search": {
+		id: "searchProducts", tag: "Products", summary: "Search products with full-text ranking, filters and facets",
+		response: dto.ProductSearchResponse{},
+		query: []param{
+			{"q", schema{"type": "string"}, "Full-text query"},
+			{"category", schema{"type": "string"}, "Only products in this category"},
+			{"min_price", schema{"type": "integer", "format": "int64"}, "Minimum price in cents"},
+			{"max_price", schema{"type": "integer", "format": "int64"}, "Maximum price in cents"},
+			{"in_stock", schema{"type": "boolean"}, "Only products with stock available"},
+			{"limit", schema{"type": "integer"}, "Page size"},
+			{"offset", schema{"type": "integer"}, "Number of results to skip"},
+		},
+		errors: []int{http.StatusBadRequest},
+	},
+	"POST /api/v1/products/import": {
+		id: "importProducts", tag: "Products", summary: "Create or update products from a CSV or NDJSON file",
+		requestContent: map[string]schema{"text/csv": {"type": "string"}, "application/x-ndjson": {"type": "string"}},
+		response:       dto.ImportResultResponse{},
+		query: []param{
+			{"format", schema{"type": "string", "enum": []string{"csv", "ndjson"}}, "File format; defaults to the Content-Type"},
+			{"dry_run", schema{"type": "boolean"}, "Validate without saving"},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge},
+	},
+	"GET /api/v1/products/export": {
+		id: "exportProducts", tag: "Products", summary: "Stream the catalog as CSV or NDJSON",
+		responseContent: map[string]schema{"text/csv": {"type": "string"}, "application/x-ndjson": {"type": "string"}},
+		query: []param{
+			{"format", schema{"type": "string", "enum": []string{"csv", "ndjson"}}, "File format; defaults to ndjson"},
+		},
+		errors: []int{http.StatusBadRequest},
+	},
+	"GET /api/v1/products/archived": {
+		id: "getArchivedProducts", tag: "Products", summary: "List archived products",
+		response: []dto.ProductResponse{},
+		admin:    true,
+	},
+	"POST /api/v1/products": {
+		id: "createProduct", tag: "Products", summary: "Create a product",
+		status: http.StatusCreated, request: dto.CreateProductRequest{}, response: dto.ProductResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"GET /api/v1/products": {
+		id: "getAllProducts", tag: "Products", summary: "List active products",
+		response: []dto.ProductResponse{},
+	},
+	"GET /api/v1/products/{id}": {
+		id: "getProduct", tag: "Products", summary: "Get a product, including archived products",
+		response: dto.ProductResponse{},
+		errors:   []int{http.StatusNotFound},
+	},
+	"PUT /api/v1/products/{id}": {
+		id: "updateProduct", tag: "Products", summary: "Update a product",
+		request: dto.UpdateProductRequest{}, response: dto.ProductResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"PATCH /api/v1/products/{id}/stock": {
+		id: "updateStock", tag: "Products", summary: "Set a product's stock",
+		request: dto.UpdateStockRequest{}, response: dto.ProductResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"PUT /api/v1/products/{id}/reorder-policy": {
+		id: "updateReorderPolicy", tag: "Inventory", summary: "Set a product's low-stock threshold and reorder quantity",
+		request: dto.UpdateReorderPolicyRequest{}, response: dto.ProductResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"GET /api/v1/inventory/low-stock": {
+		id: "getLowStockReport", tag: "Inventory", summary: "List products and variants at or below their low-stock threshold",
+		response: dto.LowStockReport{},
+	},
+	"DELETE /api/v1/products/{id}": {
+		id: "deleteProduct", tag: "Products", summary: "Archive a product and remove it from baskets",
+		status: http.StatusNoContent,
+		errors: []int{http.StatusBadRequest},
+	},
+	"POST /api/v1/products/{id}/restore": {
+		id: "restoreProduct", tag: "Products", summary: "Restore an archived product",
+		response: dto.ProductResponse{},
+		errors:   []int{http.StatusBadRequest},
+		admin:    true,
+	},
+	"DELETE /api/v1/products/{id}/purge": {
+		id: "purgeProduct", tag: "Products", summary: "Permanently delete an archived product that no order references",
+		status: http.StatusNoContent,
+		errors: []int{http.StatusBadRequest},
+		admin:  true,
+	},
+	"POST /api/v1/products/{id}/variants": {
+		id: "addVariant", tag: "Variants", summary: "Add a variant to a product",
+		status: http.StatusCreated, request: dto.AddVariantRequest{}, response: dto.ProductResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"PUT /api/v1/products/{id}/variants/{variantId}": {
+		id: "updateVariant", tag: "Variants", summary: "Update a variant's barcode or price override",
+		request: dto.UpdateVariantRequest{}, response: dto.ProductResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"PATCH /api/v1/products/{id}/variants/{variantId}/stock": {
+		id: "updateVariantStock", tag: "Variants", summary: "Set a variant's stock",
+		request: dto.UpdateStockRequest{}, response: dto.ProductResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"DELETE /api/v1/products/{id}/variants/{variantId}": {
+		id: "removeVariant", tag: "Variants", summary: "Remove a variant",
+		response: dto.ProductResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+
+	// Product images
+	"POST /api/v1/products/{id}/images": {
+		id: "uploadImage", tag: "Images", summary: "Upload a product image and generate thumbnails",
+		status: http.StatusCreated,
+		requestContent: map[string]schema{"multipart/form-data": {
+			"type":       "object",
+			"required":   []string{"image"},
+			"properties": map[string]interface{}{"image": binary, "alt_text": schema{"type": "string"}},
+		}},
+		response: dto.ProductResponse{},
+		errors:   []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType},
+	},
+	"GET /api/v1/products/{id}/images": {
+		id: "listImages", tag: "Images", summary: "List a product's images in display order",
+		response: []dto.ProductImageResponse{},
+		errors:   []int{http.StatusNotFound},
+	},
+	"PUT /api/v1/products/{id}/images/order": {
+		id: "reorderImages", tag: "Images", summary: "Set the display order of a product's images",
+		request: dto.ReorderImagesRequest{}, response: dto.ProductResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"PUT /api/v1/products/{id}/images/{imageId}/primary": {
+		id: "setPrimaryImage", tag: "Images", summary: "Mark an image as the product's primary image",
+		response: dto.ProductResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+	"DELETE /api/v1/products/{id}/images/{imageId}": {
+		id: "deleteImage", tag: "Images", summary: "Delete an image and its stored files",
+		response: dto.ProductResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+	"GET /api/v1/media/{key}": {
+		id: "serveMedia", tag: "Images", summary: "Serve a stored image or thumbnail",
+		responseContent: map[string]schema{"image/*": binary},
+		errors:          []int{http.StatusNotFound},
+	},
+
+	// Reviews
+	"POST /api/v1/products/{id}/reviews": {
+		id: "createReview", tag: "Reviews", summary: "Submit a review of a product for moderation",
+		request: dto.CreateReviewRequest{}, status: http.StatusCreated, response: dto.ReviewResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"GET /api/v1/products/{id}/reviews": {
+		id: "listProductReviews", tag: "Reviews", summary: "List the approved reviews of a product",
+		response: dto.ReviewListResponse{},
+		query:    reviewPaging,
+		errors:   []int{http.StatusBadRequest},
+	},
+	"GET /api/v1/reviews": {
+		id: "listReviews", tag: "Reviews", summary: "List reviews by status for moderation",
+		response: dto.ReviewListResponse{},
+		query: append([]param{
+			{"status", schema{"type": "string", "enum": []string{"pending", "approved", "rejected"}}, "Moderation status; defaults to pending"},
+		}, reviewPaging...),
+		errors: []int{http.StatusBadRequest},
+		admin:  true,
+	},
+	"POST /api/v1/reviews/{id}/approve": {
+		id: "approveReview", tag: "Reviews", summary: "Publish a review and add its rating to the product",
+		request: dto.ModerateReviewRequest{}, response: dto.ReviewResponse{},
+		errors: []int{http.StatusBadRequest},
+		admin:  true,
+	},
+	"POST /api/v1/reviews/{id}/reject": {
+		id: "rejectReview", tag: "Reviews", summary: "Hide a review and take its rating off the product",
+		request: dto.ModerateReviewRequest{}, response: dto.ReviewResponse{},
+		errors: []int{http.StatusBadRequest},
+		admin:  true,
+	},
+
+	// Baskets
+	"POST /api/v1/baskets": {
+		id: "createBasket", tag: "Baskets", summary: "Create an empty basket",
+		status: http.StatusCreated, response: dto.BasketResponse{},
+	},
+	"GET /api/v1/baskets/{id}": {
+		id: "getBasket", tag: "Baskets", summary: "Get a basket",
+		response: dto.BasketResponse{},
+		errors:   []int{http.StatusNotFound},
+	},
+	"GET /api/v1/baskets/{id}/revalidation": {
+		id: "revalidateBasket", tag: "Baskets", summary: "Compare basket items with current prices and stock before checkout",
+		response: dto.BasketRevalidationResponse{},
+		errors:   []int{http.StatusNotFound},
+	},
+	"POST /api/v1/baskets/{id}/items": {
+		id: "addItem", tag: "Baskets", summary: "Add a product or variant to a basket",
+		request: dto.AddItemRequest{}, response: dto.BasketResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"DELETE /api/v1/baskets/{id}/items/{productId}": {
+		id: "removeItem", tag: "Baskets", summary: "Remove an item from a basket",
+		response: dto.BasketResponse{},
+		query:    []param{{"variant_id", schema{"type": "string"}, "Variant of the product to remove"}},
+		errors:   []int{http.StatusBadRequest},
+	},
+	"PATCH /api/v1/baskets/{id}/items/{productId}": {
+		id: "updateItemQuantity", tag: "Baskets", summary: "Change the quantity of a basket item",
+		request: dto.UpdateItemQuantityRequest{}, response: dto.BasketResponse{},
+		query:  []param{{"variant_id", schema{"type": "string"}, "Variant of the product to update"}},
+		errors: []int{http.StatusBadRequest},
+	},
+	"DELETE /api/v1/baskets/{id}/items": {
+		id: "clearBasket", tag: "Baskets", summary: "Remove every item from a basket",
+		response: dto.BasketResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+	"POST /api/v1/baskets/{id}/merge": {
+		id: "mergeBasket", tag: "Baskets", summary: "Merge another basket, such as a guest basket at login, into a basket",
+		request: dto.MergeBasketRequest{}, response: dto.BasketMergeResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+
+	// Wishlists
+	"POST /api/v1/wishlists": {
+		id: "createWishlist", tag: "Wishlists", summary: "Create a wishlist with an empty default list",
+		status: http.StatusCreated, response: dto.WishlistResponse{},
+	},
+	"GET /api/v1/wishlists/shared/{token}": {
+		id: "getSharedWishlist", tag: "Wishlists", summary: "Get a shared wishlist list by its public token",
+		response: dto.SharedWishlistResponse{},
+		errors:   []int{http.StatusNotFound},
+	},
+	"GET /api/v1/wishlists/{id}": {
+		id: "getWishlist", tag: "Wishlists", summary: "Get a wishlist",
+		response: dto.WishlistResponse{},
+		errors:   []int{http.StatusNotFound},
+	},
+	"POST /api/v1/wishlists/{id}/lists": {
+		id: "createWishlistList", tag: "Wishlists", summary: "Add a named list to a wishlist",
+		request: dto.WishlistListRequest{}, status: http.StatusCreated, response: dto.WishlistResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"PATCH /api/v1/wishlists/{id}/lists/{listId}": {
+		id: "renameWishlistList", tag: "Wishlists", summary: "Rename a wishlist list",
+		request: dto.WishlistListRequest{}, response: dto.WishlistResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"DELETE /api/v1/wishlists/{id}/lists/{listId}": {
+		id: "deleteWishlistList", tag: "Wishlists", summary: "Remove a list and its items from a wishlist",
+		response: dto.WishlistResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+	"POST /api/v1/wishlists/{id}/lists/{listId}/items": {
+		id: "addWishlistItem", tag: "Wishlists", summary: "Save a product or variant to a wishlist list",
+		request: dto.AddWishlistItemRequest{}, response: dto.WishlistResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"PATCH /api/v1/wishlists/{id}/lists/{listId}/items/{productId}": {
+		id: "updateWishlistItem", tag: "Wishlists", summary: "Turn the back-in-stock alert of a saved item on or off",
+		request: dto.UpdateWishlistItemRequest{}, response: dto.WishlistResponse{},
+		query:  []param{{"variant_id", schema{"type": "string"}, "Variant of the product to update"}},
+		errors: []int{http.StatusBadRequest},
+	},
+	"DELETE /api/v1/wishlists/{id}/lists/{listId}/items/{productId}": {
+		id: "removeWishlistItem", tag: "Wishlists", summary: "Remove a saved item from a wishlist list",
+		response: dto.WishlistResponse{},
+		query:    []param{{"variant_id", schema{"type": "string"}, "Variant of the product to remove"}},
+		errors:   []int{http.StatusBadRequest},
+	},
+	"POST /api/v1/wishlists/{id}/lists/{listId}/move": {
+		id: "moveWishlistItemToBasket", tag: "Wishlists", summary: "Move a saved item into a basket",
+		request: dto.MoveToBasketRequest{}, response: dto.WishlistMoveResponse{},
+		errors: []int{http.StatusBadRequest},
+	},
+	"POST /api/v1/wishlists/{id}/lists/{listId}/share": {
+		id: "shareWishlistList", tag: "Wishlists", summary: "Share a wishlist list by a public token",
+		response: dto.WishlistResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+	"DELETE /api/v1/wishlists/{id}/lists/{listId}/share": {
+		id: "unshareWishlistList", tag: "Wishlists", summary: "Make a shared wishlist list private again",
+		response: dto.WishlistResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+
+	// Orders
+	"POST /api/v1/orders": {
+		id: "createOrder", tag: "Orders", summary: "Check out a basket",
+		status: http.StatusCreated, request: dto.CreateOrderRequest{}, response: dto.OrderResponse{},
+		errors:      []int{http.StatusBadRequest},
+		errorBodies: map[int]interface{}{http.StatusConflict: handler.StaleBasketResponse{}},
+	},
+	"GET /api/v1/orders": {
+		id: "getAllOrders", tag: "Orders", summary: "List orders",
+		response: []dto.OrderResponse{},
+	},
+	"GET /api/v1/orders/{id}": {
+		id: "getOrder", tag: "Orders", summary: "Get an order",
+		response: dto.OrderResponse{},
+		errors:   []int{http.StatusNotFound},
+	},
+	"POST /api/v1/orders/{id}/confirm": {
+		id: "confirmOrder", tag: "Orders", summary: "Confirm a pending order",
+		response: dto.OrderResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+	"POST /api/v1/orders/{id}/ship": {
+		id: "shipOrder", tag: "Orders", summary: "Mark a confirmed order as shipped",
+		response: dto.OrderResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+	"POST /api/v1/orders/{id}/deliver": {
+		id: "deliverOrder", tag: "Orders", summary: "Mark a shipped order as delivered",
+		response: dto.OrderResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+	"POST /api/v1/orders/{id}/cancel": {
+		id: "cancelOrder", tag: "Orders", summary: "Cancel an order",
+		response: dto.OrderResponse{},
+		errors:   []int{http.StatusBadRequest},
+	},
+
+	// Events
+	"GET /api/v1/events": {
+		id: "streamEvents", tag: "Events", summary: "Stream order status, stock and abandoned basket changes as Server-Sent Events",
+		responseContent: map[string]schema{"text/event-stream": {
+			"type":        "string",
+			"description": "Events named after their type, each carrying an EventResponse as JSON data. Send Last-Event-ID to resume; a resync event means some events could not be replayed.",
+		}},
+		query: []param{
+			{"topic", schema{"type": "string", "enum": []string{"orders", "stock", "baskets", "wishlists"}}, "Only stream this topic; repeat or comma-separate for several"},
+			{"resource_id", schema{"type": "string"}, "Only stream events for this order, product or basket ID; repeat or comma-separate for several"},
+			{"last_event_id", schema{"type": "integer", "minimum": 0}, "Resume after this event when the Last-Event-ID header cannot be sent"},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusServiceUnavailable},
+	},
+
+	// Background jobs
+	"GET /api/v1/jobs": {
+		id: "getJobs", tag: "Jobs", summary: "Report the last run, items processed and errors of each background job",
+		response: []dto.JobResponse{},
+		admin:    true,
+	},
+
+	// GraphQL
+	"GET /api/v1/graphql": {
+		id: "graphqlQuery", tag: "GraphQL", summary: "Run a GraphQL query passed in the query string",
+		responseContent: map[string]schema{"application/json": graphQLResult},
+		query: []param{
+			{"query", schema{"type": "string"}, "GraphQL document; mutations must use POST"},
+			{"operationName", schema{"type": "string"}, "Operation to run when the document has several"},
+			{"variables", schema{"type": "string"}, "JSON-encoded variables"},
+		},
+		errors: []int{http.StatusBadRequest, http.StatusMethodNotAllowed},
+	},
+	"POST /api/v1/graphql": {
+		id: "graphqlExecute", tag: "GraphQL", summary: "Run a GraphQL query or mutation",
+		requestContent: map[string]schema{
+			"application/json": {
+				"type":     "object",
+				"required": []string{"query"},
+				"properties": map[string]interface{}{
+					"query":         schema{"type": "string"},
+					"operationName": schema{"type": "string"},
+					"variables":     schema{"type": "object"},
+				},
+			},
+			"application/graphql": {"type": "string"},
+		},
+		responseContent: map[string]schema{"application/json": graphQLResult},
+		errors:          []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge},
+	},
+
+	// Documentation
+	"GET /api/v1/openapi.json": {
+		id: "getOpenAPISpec", tag: "Documentation", summary: "Get this OpenAPI document",
+		responseContent: map[string]schema{"application/json": {"type": "object"}},
+	},
+	"GET /api/v1/docs": {
+		id: "getDocs", tag: "Documentation", summary: "Browse the API documentation",
+		responseContent: map[string]schema{"text/html": {"type": "string"}},
+	},
+}
+
+// errorSchema is the DTO of every error response
+var errorSchema = handler.ErrorResponse{}
+
+// validationErrorSchema is the DTO of a rejected request body
+var validationErrorSchema = handler.ValidationErrorResponse{}
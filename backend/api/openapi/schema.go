@@ -0,0 +1,288 @@
+package openapi
+
+import (
+	"ecom-backend/pkg/validate"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schema is a JSON Schema object
+type schema map[string]interface{}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaBuilder reflects Go types into JSON Schemas, collecting named structs as components
+type schemaBuilder struct {
+	components map[string]schema
+	types      map[string]reflect.Type
+	docs       map[string]string
+}
+
+func newSchemaBuilder(docs map[string]string) *schemaBuilder {
+	return &schemaBuilder{components: make(map[string]schema), types: make(map[string]reflect.Type), docs: docs}
+}
+
+// schemaOf returns the schema of v's type
+func (b *schemaBuilder) schemaOf(v interface{}) (schema, error) {
+	return b.schemaFor(reflect.TypeOf(v))
+}
+
+func (b *schemaBuilder) schemaFor(t reflect.Type) (schema, error) {
+	if t == timeType {
+		return schema{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return schema{"type": "string"}, nil
+	case reflect.Bool:
+		return schema{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint8, reflect.Uint16:
+		return schema{"type": "integer"}, nil
+	case reflect.Int32, reflect.Uint32:
+		return schema{"type": "integer", "format": "int32"}, nil
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return schema{"type": "integer", "format": "int64"}, nil
+	case reflect.Float32:
+		return schema{"type": "number", "format": "float"}, nil
+	case reflect.Float64:
+		return schema{"type": "number", "format": "double"}, nil
+	case reflect.Ptr:
+		return b.schemaFor(t.Elem())
+	case reflect.Slice, reflect.Array:
+		items, err := b.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return schema{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map key of %s must be a string", t)
+		}
+		values, err := b.schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return schema{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return nil, fmt.Errorf("anonymous struct %s cannot be documented", t)
+		}
+		if err := b.component(t); err != nil {
+			return nil, err
+		}
+		return schema{"$ref": "#/components/schemas/" + t.Name()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// component adds a named struct to the component schemas
+func (b *schemaBuilder) component(t reflect.Type) error {
+	name := t.Name()
+	if existing, ok := b.types[name]; ok {
+		if existing != t {
+			return fmt.Errorf("schema name %s is used by both %s and %s", name, existing, t)
+		}
+		return nil
+	}
+
+	s := schema{"type": "object"}
+	// Register before walking fields so recursive types terminate
+	b.components[name] = s
+	b.types[name] = t
+
+	properties := make(map[string]interface{})
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		jsonName, omitEmpty, skip := jsonField(f)
+		if skip {
+			continue
+		}
+
+		prop, err := b.schemaFor(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, f.Name, err)
+		}
+		if prop, err = constrain(prop, f.Type, f.Tag.Get("validate")); err != nil {
+			return fmt.Errorf("%s.%s: %w", name, f.Name, err)
+		}
+
+		// A nil pointer that is not omitted is encoded as null
+		if f.Type.Kind() == reflect.Ptr && !omitEmpty {
+			prop = schema{"oneOf": []interface{}{prop, schema{"type": "null"}}}
+		}
+		if doc := b.docs[name+"."+f.Name]; doc != "" {
+			prop = withDescription(prop, doc)
+		}
+
+		properties[jsonName] = prop
+		if !omitEmpty {
+			required = append(required, jsonName)
+		}
+	}
+
+	s["properties"] = properties
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	if doc := b.docs[name]; doc != "" {
+		s["description"] = doc
+	}
+	return nil
+}
+
+// constrain adds the keywords matching a field's validation rules to its schema
+func constrain(s schema, t reflect.Type, tag string) (schema, error) {
+	rules, err := validate.ParseTag(tag)
+	if err != nil || len(rules) == 0 {
+		return s, err
+	}
+	if _, ok := s["$ref"]; ok {
+		return s, nil
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	minKey, maxKey := "minLength", "maxLength"
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		minKey, maxKey = "minItems", "maxItems"
+	case reflect.Map:
+		minKey, maxKey = "minProperties", "maxProperties"
+	}
+
+	out := make(schema, len(s)+len(rules))
+	for k, v := range s {
+		out[k] = v
+	}
+	for _, rule := range rules {
+		switch rule.Name {
+		case "min", "max":
+			n, _ := strconv.ParseFloat(rule.Arg, 64)
+			key := "minimum"
+			if rule.Name == "max" {
+				key = "maximum"
+			}
+			out[key] = n
+		case "len", "minlen", "maxlen":
+			n, _ := strconv.Atoi(rule.Arg)
+			if rule.Name != "maxlen" {
+				out[minKey] = n
+			}
+			if rule.Name != "minlen" {
+				out[maxKey] = n
+			}
+		case "required":
+			if t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+				out[minKey] = 1
+			}
+		case "currency":
+			out["pattern"] = "^[A-Z]{3}$"
+		case "uuid":
+			out["format"] = "uuid"
+		}
+	}
+	return out, nil
+}
+
+// withDescription returns s with a description; a $ref cannot carry siblings
+// in every tool, so it is wrapped in allOf
+func withDescription(s schema, doc string) schema {
+	if _, ok := s["$ref"]; ok {
+		return schema{"allOf": []interface{}{s}, "description": doc}
+	}
+	out := make(schema, len(s)+1)
+	for k, v := range s {
+		out[k] = v
+	}
+	out["description"] = doc
+	return out
+}
+
+// jsonField returns the JSON name of a struct field and whether it is omitted when empty
+func jsonField(f reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+// ParseDocs reads the Go source files in dir and returns type doc comments keyed by
+// type name and field comments keyed by "Type.Field"
+func ParseDocs(dir string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", dir, err)
+	}
+
+	docs := make(map[string]string)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					doc := ts.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					if text := commentText(doc); text != "" {
+						docs[ts.Name.Name] = text
+					}
+
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, field := range st.Fields.List {
+						text := commentText(field.Doc)
+						if text == "" {
+							text = commentText(field.Comment)
+						}
+						if text == "" {
+							continue
+						}
+						for _, n := range field.Names {
+							docs[ts.Name.Name+"."+n.Name] = text
+						}
+					}
+				}
+			}
+		}
+	}
+	return docs, nil
+}
+
+func commentText(g *ast.CommentGroup) string {
+	if g == nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.ReplaceAll(g.Text(), "\n", " "))
+}
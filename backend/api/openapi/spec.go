@@ -0,0 +1,39 @@
+// Package openapi generates and serves the OpenAPI specification of the HTTP API.
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// Regenerate openapi.json after changing routes or DTOs
+//go:generate go test -run TestSpecIsUpToDate -update
+
+//go:embed openapi.json
+var spec []byte
+
+//go:embed docs.html
+var docsPage []byte
+
+// Spec returns the committed OpenAPI document
+func Spec() []byte {
+	return spec
+}
+
+// SpecHandler serves the OpenAPI document as JSON
+func SpecHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Write(spec)
+	})
+}
+
+// DocsHandler serves a self-contained page that renders the OpenAPI document
+func DocsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Write(docsPage)
+	})
+}
@@ -1,8 +1,14 @@
 package router
 
 import (
+	"ecom-backend/api/graphql"
 	"ecom-backend/api/handler"
 	"ecom-backend/api/middleware"
+	"ecom-backend/api/openapi"
+	"ecom-backend/infrastructure/config"
+	"ecom-backend/infrastructure/health"
+	"ecom-backend/infrastructure/metrics"
+	"ecom-backend/pkg/ratelimit"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -13,31 +19,113 @@ func Setup(
 	productHandler *handler.ProductHandler,
 	basketHandler *handler.BasketHandler,
 	orderHandler *handler.OrderHandler,
+	wishlistHandler *handler.WishlistHandler,
+	reviewHandler *handler.ReviewHandler,
+	searchHandler *handler.SearchHandler,
+	mediaHandler *handler.MediaHandler,
+	eventsHandler *handler.EventsHandler,
+	jobsHandler *handler.JobsHandler,
+	graphQLHandler *graphql.Handler,
+	m *metrics.Metrics,
+	checks *health.Registry,
+	cfg *config.Config,
+	limits ratelimit.Store,
 ) *mux.Router {
 	r := mux.NewRouter()
 
 	// Apply middleware
-	r.Use(middleware.CORS)
+	r.Use(middleware.RequestID)
+	r.Use(middleware.Tracing)
+	r.Use(middleware.Metrics(m))
+	r.Use(middleware.CORS(r, middleware.CORSConfig{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		ExposedHeaders:   cfg.CORS.ExposedHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	}))
 	r.Use(middleware.Logging)
 
+	// Prometheus metrics
+	r.Handle("/metrics", m.Handler()).Methods("GET")
+
 	// API routes
 	api := r.PathPrefix("/api/v1").Subrouter()
+	api.Use(middleware.APIKey(middleware.APIKeyConfig{
+		Keys:     cfg.Auth.APIKeys,
+		Header:   cfg.Auth.APIKeyHeader,
+		Required: cfg.Auth.RequireAPIKey,
+	}))
+	if cfg.RateLimit.Enabled {
+		api.Use(middleware.RateLimit(limits, rateLimitConfig(cfg.RateLimit)))
+	}
+
+	// Admin routes need a valid API key even when keys are otherwise optional
+	admin := func(h http.HandlerFunc) http.Handler {
+		return middleware.RequireAPIKey(h)
+	}
 
 	// Product routes
+	api.HandleFunc("/products/search", searchHandler.SearchProducts).Methods("GET", "OPTIONS")
+	api.HandleFunc("/products/import", productHandler.ImportProducts).Methods("POST", "OPTIONS")
+	api.HandleFunc("/products/export", productHandler.ExportProducts).Methods("GET", "OPTIONS")
+	api.Handle("/products/archived", admin(productHandler.GetArchivedProducts)).Methods("GET", "OPTIONS")
 	api.HandleFunc("/products", productHandler.CreateProduct).Methods("POST", "OPTIONS")
 	api.HandleFunc("/products", productHandler.GetAllProducts).Methods("GET", "OPTIONS")
 	api.HandleFunc("/products/{id}", productHandler.GetProduct).Methods("GET", "OPTIONS")
 	api.HandleFunc("/products/{id}", productHandler.UpdateProduct).Methods("PUT", "OPTIONS")
 	api.HandleFunc("/products/{id}/stock", productHandler.UpdateStock).Methods("PATCH", "OPTIONS")
+	api.HandleFunc("/products/{id}/reorder-policy", productHandler.UpdateReorderPolicy).Methods("PUT", "OPTIONS")
 	api.HandleFunc("/products/{id}", productHandler.DeleteProduct).Methods("DELETE", "OPTIONS")
+	api.Handle("/products/{id}/restore", admin(productHandler.RestoreProduct)).Methods("POST", "OPTIONS")
+	api.Handle("/products/{id}/purge", admin(productHandler.PurgeProduct)).Methods("DELETE", "OPTIONS")
+	api.HandleFunc("/products/{id}/variants", productHandler.AddVariant).Methods("POST", "OPTIONS")
+	api.HandleFunc("/products/{id}/variants/{variantId}", productHandler.UpdateVariant).Methods("PUT", "OPTIONS")
+	api.HandleFunc("/products/{id}/variants/{variantId}/stock", productHandler.UpdateVariantStock).Methods("PATCH", "OPTIONS")
+	api.HandleFunc("/products/{id}/variants/{variantId}", productHandler.RemoveVariant).Methods("DELETE", "OPTIONS")
+
+	// Inventory routes
+	api.HandleFunc("/inventory/low-stock", productHandler.GetLowStockReport).Methods("GET", "OPTIONS")
+
+	// Product review routes
+	api.HandleFunc("/products/{id}/reviews", reviewHandler.CreateReview).Methods("POST", "OPTIONS")
+	api.HandleFunc("/products/{id}/reviews", reviewHandler.ListProductReviews).Methods("GET", "OPTIONS")
+	api.Handle("/reviews", admin(reviewHandler.ListReviews)).Methods("GET", "OPTIONS")
+	api.Handle("/reviews/{id}/approve", admin(reviewHandler.ApproveReview)).Methods("POST", "OPTIONS")
+	api.Handle("/reviews/{id}/reject", admin(reviewHandler.RejectReview)).Methods("POST", "OPTIONS")
+
+	// Product image routes
+	api.HandleFunc("/products/{id}/images", mediaHandler.UploadImage).Methods("POST", "OPTIONS")
+	api.HandleFunc("/products/{id}/images", mediaHandler.ListImages).Methods("GET", "OPTIONS")
+	api.HandleFunc("/products/{id}/images/order", mediaHandler.ReorderImages).Methods("PUT", "OPTIONS")
+	api.HandleFunc("/products/{id}/images/{imageId}/primary", mediaHandler.SetPrimaryImage).Methods("PUT", "OPTIONS")
+	api.HandleFunc("/products/{id}/images/{imageId}", mediaHandler.DeleteImage).Methods("DELETE", "OPTIONS")
+	api.HandleFunc("/media/{key:.+}", mediaHandler.ServeMedia).Methods("GET", "HEAD", "OPTIONS")
 
 	// Basket routes
 	api.HandleFunc("/baskets", basketHandler.CreateBasket).Methods("POST", "OPTIONS")
 	api.HandleFunc("/baskets/{id}", basketHandler.GetBasket).Methods("GET", "OPTIONS")
+	api.HandleFunc("/baskets/{id}/revalidation", basketHandler.RevalidateBasket).Methods("GET", "OPTIONS")
 	api.HandleFunc("/baskets/{id}/items", basketHandler.AddItem).Methods("POST", "OPTIONS")
 	api.HandleFunc("/baskets/{id}/items/{productId}", basketHandler.RemoveItem).Methods("DELETE", "OPTIONS")
 	api.HandleFunc("/baskets/{id}/items/{productId}", basketHandler.UpdateItemQuantity).Methods("PATCH", "OPTIONS")
 	api.HandleFunc("/baskets/{id}/items", basketHandler.ClearBasket).Methods("DELETE", "OPTIONS")
+	api.HandleFunc("/baskets/{id}/merge", basketHandler.MergeBasket).Methods("POST", "OPTIONS")
+
+	// Wishlist routes
+	api.HandleFunc("/wishlists", wishlistHandler.CreateWishlist).Methods("POST", "OPTIONS")
+	api.HandleFunc("/wishlists/shared/{token}", wishlistHandler.GetSharedList).Methods("GET", "OPTIONS")
+	api.HandleFunc("/wishlists/{id}", wishlistHandler.GetWishlist).Methods("GET", "OPTIONS")
+	api.HandleFunc("/wishlists/{id}/lists", wishlistHandler.CreateList).Methods("POST", "OPTIONS")
+	api.HandleFunc("/wishlists/{id}/lists/{listId}", wishlistHandler.RenameList).Methods("PATCH", "OPTIONS")
+	api.HandleFunc("/wishlists/{id}/lists/{listId}", wishlistHandler.DeleteList).Methods("DELETE", "OPTIONS")
+	api.HandleFunc("/wishlists/{id}/lists/{listId}/items", wishlistHandler.AddItem).Methods("POST", "OPTIONS")
+	api.HandleFunc("/wishlists/{id}/lists/{listId}/items/{productId}", wishlistHandler.UpdateItem).Methods("PATCH", "OPTIONS")
+	api.HandleFunc("/wishlists/{id}/lists/{listId}/items/{productId}", wishlistHandler.RemoveItem).Methods("DELETE", "OPTIONS")
+	api.HandleFunc("/wishlists/{id}/lists/{listId}/move", wishlistHandler.MoveToBasket).Methods("POST", "OPTIONS")
+	api.HandleFunc("/wishlists/{id}/lists/{listId}/share", wishlistHandler.ShareList).Methods("POST", "OPTIONS")
+	api.HandleFunc("/wishlists/{id}/lists/{listId}/share", wishlistHandler.UnshareList).Methods("DELETE", "OPTIONS")
 
 	// Order routes
 	api.HandleFunc("/orders", orderHandler.CreateOrder).Methods("POST", "OPTIONS")
@@ -48,11 +136,52 @@ func Setup(
 	api.HandleFunc("/orders/{id}/deliver", orderHandler.DeliverOrder).Methods("POST", "OPTIONS")
 	api.HandleFunc("/orders/{id}/cancel", orderHandler.CancelOrder).Methods("POST", "OPTIONS")
 
-	// Health check
-	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(200)
-		w.Write([]byte("OK"))
-	}).Methods("GET")
+	// Real-time events
+	api.HandleFunc("/events", eventsHandler.Stream).Methods("GET", "OPTIONS")
+
+	// Background jobs
+	api.Handle("/jobs", admin(jobsHandler.GetJobs)).Methods("GET", "OPTIONS")
+
+	// GraphQL
+	api.Handle("/graphql", graphQLHandler).Methods("GET", "POST", "OPTIONS")
+
+	// API documentation
+	api.Handle("/openapi.json", openapi.SpecHandler()).Methods("GET", "OPTIONS")
+	api.Handle("/docs", openapi.DocsHandler()).Methods("GET", "OPTIONS")
+
+	// Health checks; /health is kept as an alias of /livez
+	r.Handle("/livez", checks.LivenessHandler()).Methods("GET")
+	r.Handle("/readyz", checks.ReadinessHandler()).Methods("GET")
+	r.Handle("/health", checks.LivenessHandler()).Methods("GET")
 
 	return r
 }
+
+// rateLimitConfig maps the configured limits onto route groups. Checkout and basket
+// changes get their own, tighter buckets; every other API request shares the default.
+func rateLimitConfig(cfg config.RateLimitConfig) middleware.RateLimitConfig {
+	groups := []middleware.RateLimitRule{
+		{Group: "checkout", Methods: []string{"POST"}, Routes: []string{"/api/v1/orders"}, Limit: limit(cfg.Checkout)},
+		{Group: "basket", Methods: []string{"POST", "PUT", "PATCH", "DELETE"}, Routes: []string{"/api/v1/baskets/*"}, Limit: limit(cfg.Basket)},
+		{Group: "default", Limit: limit(cfg.Default)},
+	}
+
+	// A group with no limit is left out, so its requests fall through to the default group
+	rules := make([]middleware.RateLimitRule, 0, len(groups))
+	for _, g := range groups {
+		if g.Limit.RequestsPerMinute > 0 {
+			rules = append(rules, g)
+		}
+	}
+
+	trustedProxies := 0
+	if cfg.TrustForwardedFor {
+		trustedProxies = cfg.TrustedProxies
+	}
+
+	return middleware.RateLimitConfig{Rules: rules, TrustedProxies: trustedProxies}
+}
+
+func limit(rule config.RateLimitRule) ratelimit.Limit {
+	return ratelimit.Limit{RequestsPerMinute: rule.RequestsPerMinute, Burst: rule.Burst}
+}
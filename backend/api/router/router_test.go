@@ -0,0 +1,41 @@
+package router
+
+import (
+	"ecom-backend/infrastructure/config"
+	"ecom-backend/infrastructure/health"
+	"ecom-backend/infrastructure/metrics"
+	"ecom-backend/pkg/ratelimit"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSetup_AdminRoutesRequireAPIKey(t *testing.T) {
+	cfg := config.Default()
+	cfg.Auth.APIKeys = []string{"secret"}
+
+	// The admin gate answers before any handler runs, so no handlers are needed
+	r := Setup(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		metrics.New(), health.NewRegistry(time.Second), cfg, ratelimit.NewMemoryStore())
+
+	routes := []struct{ method, path string }{
+		{"GET", "/api/v1/reviews"},
+		{"POST", "/api/v1/reviews/review-1/approve"},
+		{"POST", "/api/v1/reviews/review-1/reject"},
+		{"GET", "/api/v1/products/archived"},
+		{"POST", "/api/v1/products/product-1/restore"},
+		{"DELETE", "/api/v1/products/product-1/purge"},
+		{"GET", "/api/v1/jobs"},
+	}
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(route.method, route.path, nil))
+
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("expected 401 without an API key, got %d", rec.Code)
+			}
+		})
+	}
+}
@@ -4,31 +4,97 @@ import "time"
 
 // AddItemRequest represents the request to add an item to basket
 type AddItemRequest struct {
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
+	ProductID string `json:"product_id" validate:"required,uuid"`
+	VariantID string `json:"variant_id,omitempty" validate:"uuid"`
+	Quantity  int    `json:"quantity" validate:"min=1"`
 }
 
 // UpdateItemQuantityRequest represents the request to update item quantity
 type UpdateItemQuantityRequest struct {
-	Quantity int `json:"quantity"`
+	Quantity int `json:"quantity" validate:"min=0"` // zero removes the item
+}
+
+// MergeBasketRequest represents the request to merge another basket into a basket
+type MergeBasketRequest struct {
+	SourceBasketID string `json:"source_basket_id" validate:"required,uuid"` // deleted once merged
+	Strategy       string `json:"strategy,omitempty"`                        // sum (default), max or newest
 }
 
 // BasketItemResponse represents a basket item in responses
 type BasketItemResponse struct {
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
-	Price     int64  `json:"price"`     // price in cents
-	Currency  string `json:"currency"`
-	Subtotal  int64  `json:"subtotal"`  // subtotal in cents
+	ProductID    string                 `json:"product_id"`
+	VariantID    string                 `json:"variant_id,omitempty"`
+	Quantity     int                    `json:"quantity"`
+	Price        int64                  `json:"price"` // price in cents when the item was added
+	Currency     string                 `json:"currency"`
+	Subtotal     int64                  `json:"subtotal"`          // subtotal in cents
+	Product      *BasketProductResponse `json:"product,omitempty"` // omitted once the product is purged
+	PriceChanged bool                   `json:"price_changed"`     // the current price differs from price
+	OutOfStock   bool                   `json:"out_of_stock"`      // the quantity can no longer be ordered
+}
+
+// BasketProductResponse holds the current details of a basket item's product or variant
+type BasketProductResponse struct {
+	Name        string                `json:"name"`
+	SKU         string                `json:"sku,omitempty"` // variant SKU for variant items
+	Description string                `json:"description"`
+	Options     map[string]string     `json:"options,omitempty"` // variant options
+	Price       int64                 `json:"price"`             // current price in cents
+	Currency    string                `json:"currency"`
+	Stock       int                   `json:"stock"`
+	Archived    bool                  `json:"archived"`
+	Image       *ProductImageResponse `json:"image,omitempty"` // primary image
 }
 
 // BasketResponse represents a basket in responses
 type BasketResponse struct {
 	ID        string               `json:"id"`
 	Items     []BasketItemResponse `json:"items"`
-	Total     int64                `json:"total"`     // total in cents
+	Total     int64                `json:"total"` // total in cents
 	Currency  string               `json:"currency"`
 	ItemCount int                  `json:"item_count"`
 	CreatedAt time.Time            `json:"created_at"`
 	UpdatedAt time.Time            `json:"updated_at"`
 }
+
+// BasketChangeResponse describes a basket item that no longer matches its product
+type BasketChangeResponse struct {
+	ProductID string `json:"product_id"`
+	VariantID string `json:"variant_id,omitempty"`
+	Name      string `json:"name,omitempty"` // omitted once the product is purged
+	Type      string `json:"type"`           // price_changed, insufficient_stock or unavailable
+	Quantity  int    `json:"quantity"`
+	OldPrice  int64  `json:"old_price"`           // price in cents in the basket
+	NewPrice  int64  `json:"new_price,omitempty"` // current price in cents, for price_changed
+	Currency  string `json:"currency"`
+	Stock     int    `json:"stock"` // current stock
+}
+
+// BasketRevalidationResponse lists the changes a customer must acknowledge before checkout
+type BasketRevalidationResponse struct {
+	BasketID         string                 `json:"basket_id"`
+	Changes          []BasketChangeResponse `json:"changes"`
+	Stale            bool                   `json:"stale"`             // prices changed since items were added
+	CanCheckout      bool                   `json:"can_checkout"`      // every item can still be ordered
+	Total            int64                  `json:"total"`             // basket total in cents
+	RevalidatedTotal int64                  `json:"revalidated_total"` // total in cents at current prices; send as confirmed_total
+	Currency         string                 `json:"currency"`
+}
+
+// BasketMergeAdjustmentResponse describes a merged item that was reduced or left out
+type BasketMergeAdjustmentResponse struct {
+	ProductID string `json:"product_id"`
+	VariantID string `json:"variant_id,omitempty"`
+	Name      string `json:"name,omitempty"` // omitted once the product is purged
+	Reason    string `json:"reason"`         // limited_by_stock, out_of_stock or unavailable
+	Requested int    `json:"requested"`      // quantity the strategy asked for
+	Quantity  int    `json:"quantity"`       // quantity in the merged basket
+	Stock     int    `json:"stock"`          // current stock
+}
+
+// BasketMergeResponse represents the basket after another basket was merged into it
+type BasketMergeResponse struct {
+	Basket      *BasketResponse                 `json:"basket"`
+	Strategy    string                          `json:"strategy"`
+	Adjustments []BasketMergeAdjustmentResponse `json:"adjustments"`
+}
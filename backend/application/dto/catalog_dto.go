@@ -0,0 +1,33 @@
+package dto
+
+// ProductRecord is a flat catalog record used for bulk import and export
+type ProductRecord struct {
+	ID          string                 `json:"id,omitempty" validate:"uuid"`
+	SKU         string                 `json:"sku,omitempty" validate:"maxlen=64"`
+	Name        string                 `json:"name" validate:"required,maxlen=255"`
+	Description string                 `json:"description"`
+	Category    string                 `json:"category,omitempty" validate:"maxlen=100"`
+	Price       int64                  `json:"price" validate:"min=0"` // price in cents
+	Currency    string                 `json:"currency" validate:"required,currency"`
+	Stock       *int                   `json:"stock,omitempty" validate:"min=0"` // nil leaves stock unchanged on update; omitted for products with variants
+	Options     []ProductOptionRequest `json:"options,omitempty"`
+	Variants    []AddVariantRequest    `json:"variants,omitempty"`
+}
+
+// ImportRowError describes why a single import row was rejected
+type ImportRowError struct {
+	Row   int    `json:"row"` // 1-based record number, excluding any header line
+	ID    string `json:"id,omitempty"`
+	SKU   string `json:"sku,omitempty"`
+	Error string `json:"error"`
+}
+
+// ImportResultResponse summarizes a bulk product import
+type ImportResultResponse struct {
+	DryRun  bool             `json:"dry_run"`
+	Total   int              `json:"total"`
+	Created int              `json:"created"`
+	Updated int              `json:"updated"`
+	Failed  int              `json:"failed"`
+	Errors  []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,60 @@
+package dto
+
+import "time"
+
+// EventResponse is the data of a server-sent event
+type EventResponse struct {
+	ID         uint64      `json:"id"`
+	Topic      string      `json:"topic"` // orders, stock, baskets or wishlists
+	Type       string      `json:"type"`
+	ResourceID string      `json:"resource_id"` // order, product, basket or wishlist ID
+	Time       time.Time   `json:"time"`
+	Data       interface{} `json:"data"` // OrderStatusEvent, StockEvent, BasketAbandonedEvent or BackInStockEvent
+}
+
+// OrderStatusEvent is published when an order is created or changes status
+type OrderStatusEvent struct {
+	OrderID        string `json:"order_id"`
+	Status         string `json:"status"`
+	PreviousStatus string `json:"previous_status,omitempty"` // empty for a new order
+	Total          int64  `json:"total"`                     // total in cents
+	Currency       string `json:"currency"`
+}
+
+// StockEvent is published when the stock of a product or variant changes
+type StockEvent struct {
+	ProductID     string `json:"product_id"`
+	VariantID     string `json:"variant_id,omitempty"`
+	Stock         int    `json:"stock"`
+	PreviousStock int    `json:"previous_stock"`
+	InStock       bool   `json:"in_stock"`
+}
+
+// BasketAbandonedEvent is published when a basket holding items expires unused
+type BasketAbandonedEvent struct {
+	BasketID     string          `json:"basket_id"`
+	Items        []AbandonedItem `json:"items"`
+	ItemCount    int             `json:"item_count"`
+	Total        int64           `json:"total"` // total in cents at the basket's prices
+	Currency     string          `json:"currency"`
+	CreatedAt    time.Time       `json:"created_at"`
+	LastActivity time.Time       `json:"last_activity"`
+}
+
+// AbandonedItem is an item left in an abandoned basket
+type AbandonedItem struct {
+	ProductID string `json:"product_id"`
+	VariantID string `json:"variant_id,omitempty"`
+	Quantity  int    `json:"quantity"`
+	Price     int64  `json:"price"` // price in cents when the item was added
+}
+
+// BackInStockEvent is published for each wishlist list that asked to hear when an item is back in stock
+type BackInStockEvent struct {
+	WishlistID string `json:"wishlist_id"`
+	ListID     string `json:"list_id"`
+	ProductID  string `json:"product_id"`
+	VariantID  string `json:"variant_id,omitempty"`
+	Name       string `json:"name"`
+	Stock      int    `json:"stock"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+// LowStockItem is a product, or a variant of one, at or below its low-stock threshold
+type LowStockItem struct {
+	ProductID         string `json:"product_id"`
+	VariantID         string `json:"variant_id,omitempty"`
+	Name              string `json:"name"`
+	SKU               string `json:"sku,omitempty"` // the variant SKU for variants
+	Stock             int    `json:"stock"`
+	LowStockThreshold int    `json:"low_stock_threshold"`
+	ReorderQuantity   int    `json:"reorder_quantity"`
+}
+
+// LowStockReport lists every active product and variant that is low on stock
+type LowStockReport struct {
+	Total int            `json:"total"`
+	Items []LowStockItem `json:"items"`
+}
+
+// LowStockAlert is the data of an inventory.low_stock alert
+type LowStockAlert struct {
+	ProductID         string `json:"product_id"`
+	VariantID         string `json:"variant_id,omitempty"`
+	Name              string `json:"name"`
+	SKU               string `json:"sku,omitempty"`
+	Stock             int    `json:"stock"`
+	PreviousStock     int    `json:"previous_stock"`
+	LowStockThreshold int    `json:"low_stock_threshold"`
+	ReorderQuantity   int    `json:"reorder_quantity"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// JobResponse reports the runs of a scheduled background job
+type JobResponse struct {
+	Name           string     `json:"name"`
+	Interval       string     `json:"interval"` // Go duration, such as 1h0m0s
+	Running        bool       `json:"running"`
+	Runs           int        `json:"runs"`
+	Failures       int        `json:"failures"`
+	TotalProcessed int        `json:"total_processed"`    // items processed across every run
+	LastRun        *time.Time `json:"last_run,omitempty"` // omitted until the first run finishes
+	LastDurationMS int64      `json:"last_duration_ms"`
+	LastProcessed  int        `json:"last_processed"`
+	LastError      string     `json:"last_error,omitempty"` // omitted when the last run succeeded
+	NextRun        *time.Time `json:"next_run,omitempty"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+// ImageRenditionResponse represents a stored rendition of an image
+type ImageRenditionResponse struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// ProductImageResponse represents a product image in responses
+type ProductImageResponse struct {
+	ID          string                            `json:"id"`
+	URL         string                            `json:"url"`
+	AltText     string                            `json:"alt_text,omitempty"`
+	ContentType string                            `json:"content_type"`
+	Size        int64                             `json:"size"` // size in bytes
+	Width       int                               `json:"width"`
+	Height      int                               `json:"height"`
+	Primary     bool                              `json:"primary"`
+	Thumbnails  map[string]ImageRenditionResponse `json:"thumbnails"`
+}
+
+// ReorderImagesRequest represents the request to reorder product images
+type ReorderImagesRequest struct {
+	ImageIDs []string `json:"image_ids" validate:"required"`
+}
@@ -4,23 +4,31 @@ import "time"
 
 // CreateOrderRequest represents the request to create an order
 type CreateOrderRequest struct {
-	BasketID string `json:"basket_id"`
+	BasketID string `json:"basket_id" validate:"required,uuid"`
+	// ConfirmedTotal accepts changed prices: when it equals the revalidated total in cents,
+	// a stale basket is checked out at current prices
+	ConfirmedTotal *int64 `json:"confirmed_total,omitempty" validate:"min=0"`
 }
 
-// OrderItemResponse represents an order item in responses
+// OrderItemResponse represents an order item in responses.
+// Name, SKU and description are recorded at checkout and do not follow later product changes.
 type OrderItemResponse struct {
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
-	Price     int64  `json:"price"`     // price in cents
-	Currency  string `json:"currency"`
-	Subtotal  int64  `json:"subtotal"`  // subtotal in cents
+	ProductID   string `json:"product_id"`
+	VariantID   string `json:"variant_id,omitempty"`
+	Name        string `json:"name"`
+	SKU         string `json:"sku,omitempty"` // variant SKU for variant items
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	Price       int64  `json:"price"` // price in cents
+	Currency    string `json:"currency"`
+	Subtotal    int64  `json:"subtotal"` // subtotal in cents
 }
 
 // OrderResponse represents an order in responses
 type OrderResponse struct {
 	ID        string              `json:"id"`
 	Items     []OrderItemResponse `json:"items"`
-	Total     int64               `json:"total"`     // total in cents
+	Total     int64               `json:"total"` // total in cents
 	Currency  string              `json:"currency"`
 	Status    string              `json:"status"`
 	CreatedAt time.Time           `json:"created_at"`
@@ -4,34 +4,95 @@ import "time"
 
 // CreateProductRequest represents the request to create a product
 type CreateProductRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Price       int64  `json:"price"`        // price in cents
-	Currency    string `json:"currency"`     // e.g., "USD"
-	Stock       int    `json:"stock"`
+	SKU         string                 `json:"sku,omitempty" validate:"maxlen=64"`
+	Name        string                 `json:"name" validate:"required,maxlen=255"`
+	Description string                 `json:"description"`
+	Category    string                 `json:"category,omitempty" validate:"maxlen=100"`
+	Price       int64                  `json:"price" validate:"min=0"`                // price in cents
+	Currency    string                 `json:"currency" validate:"required,currency"` // e.g., "USD"
+	Stock       int                    `json:"stock" validate:"min=0"`
+	Options     []ProductOptionRequest `json:"options,omitempty"`
+	Variants    []AddVariantRequest    `json:"variants,omitempty"`
 }
 
 // UpdateProductRequest represents the request to update a product
 type UpdateProductRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Price       int64  `json:"price"`        // price in cents
-	Currency    string `json:"currency"`
+	Name        string  `json:"name" validate:"required,maxlen=255"`
+	Description string  `json:"description"`
+	Category    *string `json:"category,omitempty" validate:"maxlen=100"` // omit to keep the current category
+	Price       int64   `json:"price" validate:"min=0"`                   // price in cents
+	Currency    string  `json:"currency" validate:"required,currency"`
 }
 
 // UpdateStockRequest represents the request to update stock
 type UpdateStockRequest struct {
-	Stock int `json:"stock"`
+	Stock int `json:"stock" validate:"min=0"`
+}
+
+// UpdateReorderPolicyRequest represents the request to set a product's low-stock threshold
+type UpdateReorderPolicyRequest struct {
+	LowStockThreshold int `json:"low_stock_threshold" validate:"min=0"` // zero turns low-stock alerts off
+	ReorderQuantity   int `json:"reorder_quantity" validate:"min=0"`
+}
+
+// ProductOptionRequest represents a product option in requests
+type ProductOptionRequest struct {
+	Name   string   `json:"name" validate:"required,maxlen=100"`
+	Values []string `json:"values" validate:"required"`
+}
+
+// AddVariantRequest represents the request to add a variant to a product
+type AddVariantRequest struct {
+	SKU     string            `json:"sku" validate:"required,maxlen=64"`
+	Barcode string            `json:"barcode,omitempty" validate:"maxlen=64"`
+	Options map[string]string `json:"options"`
+	Price   *int64            `json:"price,omitempty" validate:"min=0"` // price override in cents
+	Stock   int               `json:"stock" validate:"min=0"`
+}
+
+// UpdateVariantRequest represents the request to update a variant
+type UpdateVariantRequest struct {
+	Barcode string `json:"barcode,omitempty" validate:"maxlen=64"`
+	Price   *int64 `json:"price,omitempty" validate:"min=0"` // price override in cents, omit to use the product price
+}
+
+// ProductOptionResponse represents a product option in responses
+type ProductOptionResponse struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// ProductVariantResponse represents a product variant in responses
+type ProductVariantResponse struct {
+	ID            string            `json:"id"`
+	SKU           string            `json:"sku"`
+	Barcode       string            `json:"barcode,omitempty"`
+	Options       map[string]string `json:"options"`
+	Price         int64             `json:"price"` // effective price in cents
+	PriceOverride bool              `json:"price_override"`
+	Currency      string            `json:"currency"`
+	Stock         int               `json:"stock"`
 }
 
 // ProductResponse represents a product in responses
 type ProductResponse struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       int64     `json:"price"`        // price in cents
-	Currency    string    `json:"currency"`
-	Stock       int       `json:"stock"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                string                   `json:"id"`
+	SKU               string                   `json:"sku,omitempty"`
+	Name              string                   `json:"name"`
+	Description       string                   `json:"description"`
+	Category          string                   `json:"category,omitempty"`
+	Price             int64                    `json:"price"` // price in cents
+	Currency          string                   `json:"currency"`
+	Stock             int                      `json:"stock"`
+	Options           []ProductOptionResponse  `json:"options,omitempty"`
+	Variants          []ProductVariantResponse `json:"variants,omitempty"`
+	Images            []ProductImageResponse   `json:"images,omitempty"`
+	PrimaryImage      *ProductImageResponse    `json:"primary_image,omitempty"`
+	ArchivedAt        *time.Time               `json:"archived_at,omitempty"`
+	AverageRating     float64                  `json:"average_rating"`      // mean of approved reviews, rounded to two decimals
+	ReviewCount       int                      `json:"review_count"`        // number of approved reviews
+	LowStockThreshold int                      `json:"low_stock_threshold"` // zero when low-stock alerts are off
+	ReorderQuantity   int                      `json:"reorder_quantity"`
+	CreatedAt         time.Time                `json:"created_at"`
+	UpdatedAt         time.Time                `json:"updated_at"`
 }
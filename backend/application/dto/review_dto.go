@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// CreateReviewRequest represents the request to review a product
+type CreateReviewRequest struct {
+	Author  string `json:"author" validate:"required,maxlen=100"`
+	Rating  int    `json:"rating" validate:"min=1,max=5"`
+	Title   string `json:"title" validate:"required,maxlen=200"`
+	Body    string `json:"body,omitempty" validate:"maxlen=5000"`
+	OrderID string `json:"order_id,omitempty" validate:"uuid"` // a delivered order holding the product marks a verified purchase
+}
+
+// ModerateReviewRequest represents a staff decision on a review
+type ModerateReviewRequest struct {
+	Note string `json:"note,omitempty" validate:"maxlen=1000"` // reason for the decision
+}
+
+// ReviewListRequest selects a page of reviews
+type ReviewListRequest struct {
+	Status string // pending, approved or rejected
+	Sort   string // newest (default), oldest, highest or lowest
+	Limit  int
+	Offset int
+}
+
+// ReviewResponse represents a review in responses
+type ReviewResponse struct {
+	ID               string     `json:"id"`
+	ProductID        string     `json:"product_id"`
+	Author           string     `json:"author"`
+	Rating           int        `json:"rating"`
+	Title            string     `json:"title"`
+	Body             string     `json:"body"`
+	VerifiedPurchase bool       `json:"verified_purchase"`
+	Status           string     `json:"status"`
+	ModerationNote   string     `json:"moderation_note,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+}
+
+// ReviewListResponse represents a page of reviews
+type ReviewListResponse struct {
+	Total   int              `json:"total"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+	Sort    string           `json:"sort"`
+	Reviews []ReviewResponse `json:"reviews"`
+}
@@ -0,0 +1,48 @@
+package dto
+
+// ProductSearchRequest represents a full-text product search
+type ProductSearchRequest struct {
+	Query    string
+	Category string
+	MinPrice *int64 // in cents
+	MaxPrice *int64 // in cents
+	InStock  *bool
+	Limit    int
+	Offset   int
+}
+
+// SearchHighlightResponse holds snippets with matched terms wrapped in <mark></mark>
+type SearchHighlightResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ProductSearchHitResponse represents a ranked search result
+type ProductSearchHitResponse struct {
+	Product    *ProductResponse        `json:"product"`
+	Rank       float64                 `json:"rank"`
+	Highlights SearchHighlightResponse `json:"highlights"`
+}
+
+// FacetCountResponse represents the number of matches for a facet value
+type FacetCountResponse struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// SearchFacetsResponse represents facet counts over all matches
+type SearchFacetsResponse struct {
+	Categories   []FacetCountResponse `json:"categories"`
+	PriceBands   []FacetCountResponse `json:"price_bands"`
+	Availability []FacetCountResponse `json:"availability"`
+}
+
+// ProductSearchResponse represents a page of search results
+type ProductSearchResponse struct {
+	Query   string                     `json:"query"`
+	Total   int                        `json:"total"`
+	Limit   int                        `json:"limit"`
+	Offset  int                        `json:"offset"`
+	Results []ProductSearchHitResponse `json:"results"`
+	Facets  SearchFacetsResponse       `json:"facets"`
+}
@@ -0,0 +1,69 @@
+package dto
+
+import "time"
+
+// WishlistListRequest represents the request to create or rename a wishlist list
+type WishlistListRequest struct {
+	Name string `json:"name" validate:"required,maxlen=100"`
+}
+
+// AddWishlistItemRequest represents the request to save an item to a wishlist list
+type AddWishlistItemRequest struct {
+	ProductID         string `json:"product_id" validate:"required,uuid"`
+	VariantID         string `json:"variant_id,omitempty" validate:"uuid"`
+	NotifyBackInStock bool   `json:"notify_back_in_stock"`
+}
+
+// UpdateWishlistItemRequest represents the request to change the alert of a saved item
+type UpdateWishlistItemRequest struct {
+	NotifyBackInStock bool `json:"notify_back_in_stock"`
+}
+
+// MoveToBasketRequest represents the request to move a saved item into a basket
+type MoveToBasketRequest struct {
+	BasketID  string `json:"basket_id" validate:"required,uuid"`
+	ProductID string `json:"product_id" validate:"required,uuid"`
+	VariantID string `json:"variant_id,omitempty" validate:"uuid"`
+	Quantity  int    `json:"quantity,omitempty" validate:"min=0"` // defaults to 1
+}
+
+// WishlistItemResponse represents a saved item in responses
+type WishlistItemResponse struct {
+	ProductID         string                 `json:"product_id"`
+	VariantID         string                 `json:"variant_id,omitempty"`
+	NotifyBackInStock bool                   `json:"notify_back_in_stock"`
+	AddedAt           time.Time              `json:"added_at"`
+	Product           *BasketProductResponse `json:"product,omitempty"` // current details, omitted once the product is purged
+	InStock           bool                   `json:"in_stock"`          // the item can be moved to a basket
+}
+
+// WishlistListResponse represents a named wishlist list in responses
+type WishlistListResponse struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Shared     bool                   `json:"shared"`
+	ShareToken string                 `json:"share_token,omitempty"` // public token of a shared list
+	Items      []WishlistItemResponse `json:"items"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// WishlistResponse represents a wishlist in responses
+type WishlistResponse struct {
+	ID        string                 `json:"id"`
+	Lists     []WishlistListResponse `json:"lists"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// SharedWishlistResponse is the public view of a shared wishlist list
+type SharedWishlistResponse struct {
+	Name      string                 `json:"name"`
+	Items     []WishlistItemResponse `json:"items"` // alerts are always reported off
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// WishlistMoveResponse represents a wishlist and basket after an item moved between them
+type WishlistMoveResponse struct {
+	Wishlist *WishlistResponse `json:"wishlist"`
+	Basket   *BasketResponse   `json:"basket"`
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/pkg/alert"
+	"fmt"
+	"log/slog"
+)
+
+// Alert types raised through AlertNotifier
+const (
+	AlertLowStock    = "inventory.low_stock"
+	AlertBackInStock = "wishlist.back_in_stock"
+)
+
+// AlertNotifier delivers alerts to the people running the shop
+type AlertNotifier interface {
+	// Notify queues or sends the alert; it must not block for long
+	Notify(ctx context.Context, a alert.Alert) error
+
+	// Deliver sends the alert before returning and fails if it did not arrive
+	Deliver(ctx context.Context, a alert.Alert) error
+}
+
+// NopAlerts is an AlertNotifier that discards every alert
+type NopAlerts struct{}
+
+// Notify does nothing
+func (NopAlerts) Notify(ctx context.Context, a alert.Alert) error { return nil }
+
+// Deliver does nothing
+func (NopAlerts) Deliver(ctx context.Context, a alert.Alert) error { return nil }
+
+// notifyLowStock raises a low-stock alert when the stock of a product or, with a variantID,
+// one of its variants falls to or below the product's threshold. Stock that was already
+// low does not raise it again until it has been restocked above the threshold. A failed
+// alert is only logged.
+func notifyLowStock(ctx context.Context, notifier AlertNotifier, product *entity.Product, variantID string, previous, stock int) {
+	threshold := product.LowStockThreshold()
+	if threshold == 0 || product.IsArchived() || previous <= threshold || stock > threshold {
+		return
+	}
+
+	item := newLowStockItem(product, variantID, stock)
+	subject := fmt.Sprintf("Low stock: %s", item.Name)
+	if item.SKU != "" {
+		subject = fmt.Sprintf("Low stock: %s (%s)", item.Name, item.SKU)
+	}
+	text := fmt.Sprintf("%s is down to %d in stock, at or below its low-stock threshold of %d.", item.Name, stock, threshold)
+	if item.ReorderQuantity > 0 {
+		text += fmt.Sprintf("\nReorder %d units.", item.ReorderQuantity)
+	}
+
+	err := notifier.Notify(ctx, alert.Alert{
+		Type:    AlertLowStock,
+		Subject: subject,
+		Text:    text,
+		Data: dto.LowStockAlert{
+			ProductID:         item.ProductID,
+			VariantID:         item.VariantID,
+			Name:              item.Name,
+			SKU:               item.SKU,
+			Stock:             stock,
+			PreviousStock:     previous,
+			LowStockThreshold: threshold,
+			ReorderQuantity:   item.ReorderQuantity,
+		},
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to raise low-stock alert", "product_id", product.ID(), "variant_id", variantID, "error", err)
+	}
+}
+
+// newBackInStockAlert builds the alert delivering a queued back-in-stock notice
+func newBackInStockAlert(notice repository.BackInStockAlert, product *entity.Product) alert.Alert {
+	return alert.Alert{
+		Type:    AlertBackInStock,
+		Subject: fmt.Sprintf("Back in stock: %s", product.Name()),
+		Text:    fmt.Sprintf("%s is back in stock with %d available, for wishlist list %s.", product.Name(), notice.Stock, notice.ListID),
+		Data:    newBackInStockEvent(notice, product),
+	}
+}
+
+// newLowStockItem describes a product or variant for low-stock reports and alerts
+func newLowStockItem(product *entity.Product, variantID string, stock int) dto.LowStockItem {
+	item := dto.LowStockItem{
+		ProductID:         product.ID(),
+		VariantID:         variantID,
+		Name:              product.Name(),
+		SKU:               product.SKU(),
+		Stock:             stock,
+		LowStockThreshold: product.LowStockThreshold(),
+		ReorderQuantity:   product.ReorderQuantity(),
+	}
+	if variantID != "" {
+		if variant, err := product.Variant(variantID); err == nil {
+			item.SKU = variant.SKU()
+		}
+	}
+	return item
+}
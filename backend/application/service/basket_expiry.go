@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/pkg/tracing"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// ExpireIdleBaskets deletes baskets not updated within ttl, batchSize at a time, and
+// publishes a basket.abandoned event for each deleted basket that still held items.
+// It returns the number of baskets deleted, including those deleted before an error.
+func (s *BasketService) ExpireIdleBaskets(ctx context.Context, ttl time.Duration, batchSize int) (int, error) {
+	ctx, span := tracing.Start(ctx, "BasketService.ExpireIdleBaskets")
+	defer span.End()
+
+	if ttl <= 0 || batchSize <= 0 {
+		return 0, errors.New("basket expiry needs a positive ttl and batch size")
+	}
+
+	cutoff := time.Now().Add(-ttl)
+	deleted, abandoned := 0, 0
+	defer func() {
+		span.SetAttribute("baskets.deleted", deleted)
+		span.SetAttribute("baskets.abandoned", abandoned)
+	}()
+
+	for {
+		baskets, err := s.basketRepo.DeleteIdle(ctx, cutoff, batchSize)
+		if err != nil {
+			return deleted, err
+		}
+		deleted += len(baskets)
+
+		for _, basket := range baskets {
+			if basket.IsEmpty() {
+				continue
+			}
+			abandoned++
+			if err := publishBasketAbandoned(s.events, basket); err != nil {
+				// The basket is already gone; losing its event must not stop the cleanup
+				slog.WarnContext(ctx, "Failed to publish abandoned basket", "basket_id", basket.ID(), "error", err)
+			}
+		}
+
+		if len(baskets) < batchSize {
+			return deleted, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/domain/value"
+)
+
+// Basket change types reported by revalidation
+const (
+	BasketChangePrice             = "price_changed"
+	BasketChangeInsufficientStock = "insufficient_stock"
+	BasketChangeUnavailable       = "unavailable"
+)
+
+// StaleBasketError is returned by checkout when basket prices no longer match the catalog
+// and the caller has not confirmed the revalidated total
+type StaleBasketError struct {
+	Revalidation *dto.BasketRevalidationResponse
+}
+
+func (e *StaleBasketError) Error() string {
+	return "basket prices have changed: confirm the revalidated total to check out"
+}
+
+// findBasketProducts loads the products of the given basket items in one batch, keyed by
+// product ID. Purged products are missing from the result.
+func findBasketProducts(ctx context.Context, repo repository.ProductRepository, items []*entity.BasketItem) (map[string]*entity.Product, error) {
+	products := make(map[string]*entity.Product, len(items))
+	if len(items) == 0 {
+		return products, nil
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ProductID())
+	}
+
+	found, err := repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range found {
+		products[product.ID()] = product
+	}
+
+	return products, nil
+}
+
+// revalidateBasket compares each basket item with the current price and stock of its product.
+// An item may report both a price change and insufficient stock.
+func revalidateBasket(basket *entity.Basket, products map[string]*entity.Product) (*dto.BasketRevalidationResponse, error) {
+	total, err := basket.Total()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.BasketRevalidationResponse{
+		BasketID:    basket.ID(),
+		Changes:     []dto.BasketChangeResponse{},
+		CanCheckout: true,
+		Total:       total.Amount(),
+		Currency:    total.Currency(),
+	}
+
+	var revalidated *value.Money
+	for _, item := range basket.Items() {
+		change := dto.BasketChangeResponse{
+			ProductID: item.ProductID(),
+			VariantID: item.VariantID(),
+			Quantity:  item.Quantity().Value(),
+			OldPrice:  item.Price().Amount(),
+			Currency:  item.Price().Currency(),
+		}
+
+		// Archived products and removed variants leave current and stock nil
+		var current *value.Money
+		var stock *value.Quantity
+		if product, ok := products[item.ProductID()]; ok {
+			change.Name = product.Name()
+			if !product.IsArchived() {
+				current, _ = product.PriceFor(item.VariantID())
+				stock, _ = product.StockFor(item.VariantID())
+			}
+		}
+
+		// Unavailable items keep their basket price in the revalidated total
+		price := item.Price()
+		if current == nil || stock == nil {
+			change.Type = BasketChangeUnavailable
+			response.Changes = append(response.Changes, change)
+			response.CanCheckout = false
+		} else {
+			change.Stock = stock.Value()
+			if current.Amount() != price.Amount() || current.Currency() != price.Currency() {
+				priceChange := change
+				priceChange.Type = BasketChangePrice
+				priceChange.NewPrice = current.Amount()
+				priceChange.Currency = current.Currency()
+				response.Changes = append(response.Changes, priceChange)
+				response.Stale = true
+				price = current
+			}
+			if stock.Value() < item.Quantity().Value() {
+				change.Type = BasketChangeInsufficientStock
+				response.Changes = append(response.Changes, change)
+				response.CanCheckout = false
+			}
+		}
+
+		subtotal, err := price.Multiply(item.Quantity().Value())
+		if err != nil {
+			return nil, err
+		}
+		if revalidated == nil {
+			revalidated = subtotal
+		} else if revalidated, err = revalidated.Add(subtotal); err != nil {
+			return nil, err
+		}
+	}
+
+	response.RevalidatedTotal = response.Total
+	if revalidated != nil {
+		response.RevalidatedTotal = revalidated.Amount()
+		response.Currency = revalidated.Currency()
+	}
+
+	return response, nil
+}
+
+// acceptPriceChanges reprices basket items to the current prices reported by revalidation
+func acceptPriceChanges(basket *entity.Basket, revalidation *dto.BasketRevalidationResponse) error {
+	for _, change := range revalidation.Changes {
+		if change.Type != BasketChangePrice {
+			continue
+		}
+		price, err := value.NewMoney(change.NewPrice, change.Currency)
+		if err != nil {
+			return err
+		}
+		if err := basket.RepriceVariantItem(change.ProductID, change.VariantID, price); err != nil {
+			return err
+		}
+	}
+	return nil
+}
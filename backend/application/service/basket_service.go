@@ -6,6 +6,8 @@ import (
 	"ecom-backend/domain/entity"
 	"ecom-backend/domain/repository"
 	"ecom-backend/domain/value"
+	"ecom-backend/pkg/tracing"
+	"ecom-backend/pkg/validate"
 	"errors"
 )
 
@@ -13,45 +15,54 @@ import (
 type BasketService struct {
 	basketRepo  repository.BasketRepository
 	productRepo repository.ProductRepository
+	metrics     MetricsRecorder
+	events      EventPublisher
 }
 
 // NewBasketService creates a new BasketService
-func NewBasketService(basketRepo repository.BasketRepository, productRepo repository.ProductRepository) *BasketService {
+func NewBasketService(basketRepo repository.BasketRepository, productRepo repository.ProductRepository, metrics MetricsRecorder, events EventPublisher) *BasketService {
 	return &BasketService{
 		basketRepo:  basketRepo,
 		productRepo: productRepo,
+		metrics:     metrics,
+		events:      events,
 	}
 }
 
 // CreateBasket creates a new empty basket
 func (s *BasketService) CreateBasket(ctx context.Context) (*dto.BasketResponse, error) {
+	ctx, span := tracing.Start(ctx, "BasketService.CreateBasket")
+	defer span.End()
+
 	basket := entity.NewBasket()
 
 	if err := s.basketRepo.Save(ctx, basket); err != nil {
 		return nil, err
 	}
 
-	return s.toBasketResponse(basket)
+	return s.toBasketResponse(ctx, basket)
 }
 
 // GetBasket retrieves a basket by ID
 func (s *BasketService) GetBasket(ctx context.Context, id string) (*dto.BasketResponse, error) {
+	ctx, span := tracing.Start(ctx, "BasketService.GetBasket")
+	defer span.End()
+
 	basket, err := s.basketRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.toBasketResponse(basket)
+	return s.toBasketResponse(ctx, basket)
 }
 
 // AddItem adds an item to the basket
 func (s *BasketService) AddItem(ctx context.Context, basketID string, req *dto.AddItemRequest) (*dto.BasketResponse, error) {
-	// Validate request
-	if req.ProductID == "" {
-		return nil, errors.New("product ID is required")
-	}
-	if req.Quantity <= 0 {
-		return nil, errors.New("quantity must be greater than zero")
+	ctx, span := tracing.Start(ctx, "BasketService.AddItem")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
 	}
 
 	// Retrieve basket
@@ -65,6 +76,20 @@ func (s *BasketService) AddItem(ctx context.Context, basketID string, req *dto.A
 	if err != nil {
 		return nil, err
 	}
+	if product.IsArchived() {
+		return nil, errors.New("product is no longer available")
+	}
+
+	// Resolve the price and stock of the requested product or variant
+	price, err := product.PriceFor(req.VariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := product.StockFor(req.VariantID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check if product has sufficient stock
 	requestedQty, err := value.NewQuantity(req.Quantity)
@@ -72,12 +97,12 @@ func (s *BasketService) AddItem(ctx context.Context, basketID string, req *dto.A
 		return nil, err
 	}
 
-	if product.Stock().Value() < req.Quantity {
+	if stock.Value() < req.Quantity {
 		return nil, errors.New("insufficient stock")
 	}
 
 	// Add item to basket
-	if err := basket.AddItem(product.ID(), requestedQty, product.Price()); err != nil {
+	if err := basket.AddVariantItem(product.ID(), req.VariantID, requestedQty, price); err != nil {
 		return nil, err
 	}
 
@@ -86,17 +111,150 @@ func (s *BasketService) AddItem(ctx context.Context, basketID string, req *dto.A
 		return nil, err
 	}
 
-	return s.toBasketResponse(basket)
+	s.metrics.BasketItemAdded(req.Quantity)
+
+	return s.toBasketResponse(ctx, basket)
+}
+
+// RevalidateBasket compares each item with its product's current price and stock. Price
+// changes must be confirmed at checkout with the revalidated total.
+func (s *BasketService) RevalidateBasket(ctx context.Context, basketID string) (*dto.BasketRevalidationResponse, error) {
+	ctx, span := tracing.Start(ctx, "BasketService.RevalidateBasket")
+	defer span.End()
+
+	basket, err := s.basketRepo.FindByID(ctx, basketID)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := findBasketProducts(ctx, s.productRepo, basket.Items())
+	if err != nil {
+		return nil, err
+	}
+
+	return revalidateBasket(basket, products)
 }
 
-// RemoveItem removes an item from the basket
-func (s *BasketService) RemoveItem(ctx context.Context, basketID, productID string) (*dto.BasketResponse, error) {
+// MergeBaskets merges the source basket of req into the basket and deletes the source,
+// such as a guest basket into a customer's basket when they log in. Items held by both
+// are resolved by req.Strategy, and merged items are limited to the current stock.
+func (s *BasketService) MergeBaskets(ctx context.Context, basketID string, req *dto.MergeBasketRequest) (*dto.BasketMergeResponse, error) {
+	ctx, span := tracing.Start(ctx, "BasketService.MergeBaskets")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	strategy, err := entity.ParseMergeStrategy(req.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
 	basket, err := s.basketRepo.FindByID(ctx, basketID)
 	if err != nil {
 		return nil, err
 	}
+	source, err := s.basketRepo.FindByID(ctx, req.SourceBasketID)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := findBasketProducts(ctx, s.productRepo, source.Items())
+	if err != nil {
+		return nil, err
+	}
 
-	if err := basket.RemoveItem(productID); err != nil {
+	adjustments, err := basket.Merge(source, strategy, products)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.basketRepo.UpdateMerged(ctx, basket, source.ID()); err != nil {
+		return nil, err
+	}
+
+	response, err := s.toBasketResponse(ctx, basket)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &dto.BasketMergeResponse{
+		Basket:      response,
+		Strategy:    string(strategy),
+		Adjustments: make([]dto.BasketMergeAdjustmentResponse, 0, len(adjustments)),
+	}
+	for _, a := range adjustments {
+		adjustment := dto.BasketMergeAdjustmentResponse{
+			ProductID: a.ProductID,
+			VariantID: a.VariantID,
+			Reason:    a.Reason,
+			Requested: a.Requested,
+			Quantity:  a.Quantity,
+			Stock:     a.Available,
+		}
+		if product, ok := products[a.ProductID]; ok {
+			adjustment.Name = product.Name()
+		}
+		merged.Adjustments = append(merged.Adjustments, adjustment)
+	}
+
+	return merged, nil
+}
+
+// MergeOnLogin is the hook a login flow calls once a customer is authenticated, with the
+// customer's basket and the guest basket the session was using; either ID may be empty.
+// The guest basket is merged into the customer's with the default strategy. Without a
+// customer basket the guest basket becomes the customer's, and without either a new basket
+// is created. A guest basket that has expired or was already merged is ignored.
+// The returned basket is the one to keep for the customer.
+func (s *BasketService) MergeOnLogin(ctx context.Context, customerBasketID, guestBasketID string) (*dto.BasketMergeResponse, error) {
+	ctx, span := tracing.Start(ctx, "BasketService.MergeOnLogin")
+	defer span.End()
+
+	if guestBasketID != "" && guestBasketID != customerBasketID {
+		if _, err := s.basketRepo.FindByID(ctx, guestBasketID); err != nil {
+			if !errors.Is(err, repository.ErrNotFound) {
+				return nil, err
+			}
+			guestBasketID = ""
+		}
+	}
+
+	var basket *dto.BasketResponse
+	var err error
+	switch {
+	case guestBasketID != "" && customerBasketID != "" && guestBasketID != customerBasketID:
+		return s.MergeBaskets(ctx, customerBasketID, &dto.MergeBasketRequest{SourceBasketID: guestBasketID})
+	case customerBasketID != "":
+		basket, err = s.GetBasket(ctx, customerBasketID)
+	case guestBasketID != "":
+		basket, err = s.GetBasket(ctx, guestBasketID)
+	default:
+		basket, err = s.CreateBasket(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.BasketMergeResponse{
+		Basket:      basket,
+		Strategy:    string(entity.MergeSum),
+		Adjustments: []dto.BasketMergeAdjustmentResponse{},
+	}, nil
+}
+
+// RemoveItem removes an item from the basket.
+// An empty variantID refers to a product without variants.
+func (s *BasketService) RemoveItem(ctx context.Context, basketID, productID, variantID string) (*dto.BasketResponse, error) {
+	ctx, span := tracing.Start(ctx, "BasketService.RemoveItem")
+	defer span.End()
+
+	basket, err := s.basketRepo.FindByID(ctx, basketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := basket.RemoveVariantItem(productID, variantID); err != nil {
 		return nil, err
 	}
 
@@ -104,13 +262,17 @@ func (s *BasketService) RemoveItem(ctx context.Context, basketID, productID stri
 		return nil, err
 	}
 
-	return s.toBasketResponse(basket)
+	return s.toBasketResponse(ctx, basket)
 }
 
-// UpdateItemQuantity updates the quantity of an item in the basket
-func (s *BasketService) UpdateItemQuantity(ctx context.Context, basketID, productID string, req *dto.UpdateItemQuantityRequest) (*dto.BasketResponse, error) {
-	if req.Quantity < 0 {
-		return nil, errors.New("quantity cannot be negative")
+// UpdateItemQuantity updates the quantity of an item in the basket.
+// An empty variantID refers to a product without variants.
+func (s *BasketService) UpdateItemQuantity(ctx context.Context, basketID, productID, variantID string, req *dto.UpdateItemQuantityRequest) (*dto.BasketResponse, error) {
+	ctx, span := tracing.Start(ctx, "BasketService.UpdateItemQuantity")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
 	}
 
 	basket, err := s.basketRepo.FindByID(ctx, basketID)
@@ -120,7 +282,7 @@ func (s *BasketService) UpdateItemQuantity(ctx context.Context, basketID, produc
 
 	// If quantity is 0, remove the item
 	if req.Quantity == 0 {
-		if err := basket.RemoveItem(productID); err != nil {
+		if err := basket.RemoveVariantItem(productID, variantID); err != nil {
 			return nil, err
 		}
 	} else {
@@ -129,8 +291,16 @@ func (s *BasketService) UpdateItemQuantity(ctx context.Context, basketID, produc
 		if err != nil {
 			return nil, err
 		}
+		if product.IsArchived() {
+			return nil, errors.New("product is no longer available")
+		}
 
-		if product.Stock().Value() < req.Quantity {
+		stock, err := product.StockFor(variantID)
+		if err != nil {
+			return nil, err
+		}
+
+		if stock.Value() < req.Quantity {
 			return nil, errors.New("insufficient stock")
 		}
 
@@ -139,7 +309,7 @@ func (s *BasketService) UpdateItemQuantity(ctx context.Context, basketID, produc
 			return nil, err
 		}
 
-		if err := basket.UpdateItemQuantity(productID, quantity); err != nil {
+		if err := basket.UpdateVariantItemQuantity(productID, variantID, quantity); err != nil {
 			return nil, err
 		}
 	}
@@ -148,11 +318,14 @@ func (s *BasketService) UpdateItemQuantity(ctx context.Context, basketID, produc
 		return nil, err
 	}
 
-	return s.toBasketResponse(basket)
+	return s.toBasketResponse(ctx, basket)
 }
 
 // ClearBasket removes all items from the basket
 func (s *BasketService) ClearBasket(ctx context.Context, basketID string) (*dto.BasketResponse, error) {
+	ctx, span := tracing.Start(ctx, "BasketService.ClearBasket")
+	defer span.End()
+
 	basket, err := s.basketRepo.FindByID(ctx, basketID)
 	if err != nil {
 		return nil, err
@@ -164,11 +337,17 @@ func (s *BasketService) ClearBasket(ctx context.Context, basketID string) (*dto.
 		return nil, err
 	}
 
-	return s.toBasketResponse(basket)
+	return s.toBasketResponse(ctx, basket)
 }
 
-// toBasketResponse converts a Basket entity to BasketResponse DTO
-func (s *BasketService) toBasketResponse(basket *entity.Basket) (*dto.BasketResponse, error) {
+// toBasketResponse converts a Basket entity to BasketResponse DTO, embedding the current
+// details of each item's product in one batched lookup
+func (s *BasketService) toBasketResponse(ctx context.Context, basket *entity.Basket) (*dto.BasketResponse, error) {
+	products, err := findBasketProducts(ctx, s.productRepo, basket.Items())
+	if err != nil {
+		return nil, err
+	}
+
 	items := make([]dto.BasketItemResponse, 0, len(basket.Items()))
 
 	for _, item := range basket.Items() {
@@ -177,13 +356,24 @@ func (s *BasketService) toBasketResponse(basket *entity.Basket) (*dto.BasketResp
 			return nil, err
 		}
 
-		items = append(items, dto.BasketItemResponse{
-			ProductID: item.ProductID(),
-			Quantity:  item.Quantity().Value(),
-			Price:     item.Price().Amount(),
-			Currency:  item.Price().Currency(),
-			Subtotal:  subtotal.Amount(),
-		})
+		response := dto.BasketItemResponse{
+			ProductID:  item.ProductID(),
+			VariantID:  item.VariantID(),
+			Quantity:   item.Quantity().Value(),
+			Price:      item.Price().Amount(),
+			Currency:   item.Price().Currency(),
+			Subtotal:   subtotal.Amount(),
+			OutOfStock: true,
+		}
+		if product, ok := products[item.ProductID()]; ok {
+			response.Product = newBasketProductResponse(product, item.VariantID())
+		}
+		if current := response.Product; current != nil {
+			response.PriceChanged = current.Price != response.Price || current.Currency != response.Currency
+			response.OutOfStock = current.Archived || current.Stock < response.Quantity
+		}
+
+		items = append(items, response)
 	}
 
 	total, err := basket.Total()
@@ -206,3 +396,37 @@ func (s *BasketService) toBasketResponse(basket *entity.Basket) (*dto.BasketResp
 		UpdatedAt: basket.UpdatedAt(),
 	}, nil
 }
+
+// newBasketProductResponse describes the current state of a product or one of its variants.
+// It returns nil when the variant has been removed.
+func newBasketProductResponse(product *entity.Product, variantID string) *dto.BasketProductResponse {
+	price, err := product.PriceFor(variantID)
+	if err != nil {
+		return nil
+	}
+	stock, err := product.StockFor(variantID)
+	if err != nil {
+		return nil
+	}
+
+	response := &dto.BasketProductResponse{
+		Name:        product.Name(),
+		SKU:         product.SKU(),
+		Description: product.Description(),
+		Price:       price.Amount(),
+		Currency:    price.Currency(),
+		Stock:       stock.Value(),
+		Archived:    product.IsArchived(),
+	}
+	if variantID != "" {
+		variant, _ := product.Variant(variantID)
+		response.SKU = variant.SKU()
+		response.Options = variant.Options()
+	}
+	if primary := product.PrimaryImage(); primary != nil {
+		image := newProductImageResponse(primary, true)
+		response.Image = &image
+	}
+
+	return response
+}
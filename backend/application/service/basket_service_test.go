@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/value"
+	"ecom-backend/pkg/events"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBasketService_GetBasketEmbedsCurrentProducts(t *testing.T) {
+	products := newMockProductRepo()
+	baskets := newMockBasketRepo()
+	service := NewBasketService(baskets, products, NopMetrics{}, NopEvents{})
+	ctx := context.Background()
+
+	newProduct := func(name string, stock int) *entity.Product {
+		price, _ := value.NewMoney(1000, "USD")
+		qty, _ := value.NewQuantity(stock)
+		product, _ := entity.NewProduct(name, name+" description", price, qty)
+		products.Save(ctx, product)
+		return product
+	}
+	unchanged := newProduct("Mug", 10)
+	repriced := newProduct("Shirt", 10)
+	soldOut := newProduct("Poster", 10)
+	archived := newProduct("Lamp", 10)
+	purged := newProduct("Vase", 10)
+
+	basket, _ := service.CreateBasket(ctx)
+	for _, p := range []*entity.Product{unchanged, repriced, soldOut, archived, purged} {
+		if _, err := service.AddItem(ctx, basket.ID, &dto.AddItemRequest{ProductID: p.ID(), Quantity: 2}); err != nil {
+			t.Fatalf("AddItem: %v", err)
+		}
+	}
+
+	newPrice, _ := value.NewMoney(1200, "USD")
+	repriced.UpdateDetails(repriced.Name(), repriced.Description(), newPrice)
+	one, _ := value.NewQuantity(1)
+	soldOut.UpdateStock(one)
+	archived.Archive()
+	products.Delete(ctx, purged.ID())
+
+	response, err := service.GetBasket(ctx, basket.ID)
+	if err != nil {
+		t.Fatalf("GetBasket: %v", err)
+	}
+
+	tests := []struct {
+		product          *entity.Product
+		wantProduct      bool
+		wantPriceChanged bool
+		wantOutOfStock   bool
+	}{
+		{unchanged, true, false, false},
+		{repriced, true, true, false},
+		{soldOut, true, false, true},
+		{archived, true, false, true},
+		{purged, false, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.product.Name(), func(t *testing.T) {
+			var item *dto.BasketItemResponse
+			for i := range response.Items {
+				if response.Items[i].ProductID == tt.product.ID() {
+					item = &response.Items[i]
+				}
+			}
+			if item == nil {
+				t.Fatal("item missing from basket")
+			}
+
+			if (item.Product != nil) != tt.wantProduct {
+				t.Fatalf("expected product details: %v, got %+v", tt.wantProduct, item.Product)
+			}
+			if item.Product != nil && item.Product.Name != tt.product.Name() {
+				t.Errorf("expected product name %q, got %q", tt.product.Name(), item.Product.Name)
+			}
+			if item.PriceChanged != tt.wantPriceChanged {
+				t.Errorf("expected price_changed %v, got %v", tt.wantPriceChanged, item.PriceChanged)
+			}
+			if item.OutOfStock != tt.wantOutOfStock {
+				t.Errorf("expected out_of_stock %v, got %v", tt.wantOutOfStock, item.OutOfStock)
+			}
+			if item.Price != 1000 {
+				t.Errorf("expected the basket to keep the price when added, got %d", item.Price)
+			}
+		})
+	}
+}
+
+func TestBasketService_RevalidateBasket(t *testing.T) {
+	products := newMockProductRepo()
+	baskets := newMockBasketRepo()
+	service := NewBasketService(baskets, products, NopMetrics{}, NopEvents{})
+	ctx := context.Background()
+
+	price, _ := value.NewMoney(1000, "USD")
+	stock, _ := value.NewQuantity(5)
+	mug, _ := entity.NewProduct("Mug", "", price, stock)
+	lamp, _ := entity.NewProduct("Lamp", "", price, stock)
+	products.Save(ctx, mug)
+	products.Save(ctx, lamp)
+
+	basket, _ := service.CreateBasket(ctx)
+	service.AddItem(ctx, basket.ID, &dto.AddItemRequest{ProductID: mug.ID(), Quantity: 3})
+	service.AddItem(ctx, basket.ID, &dto.AddItemRequest{ProductID: lamp.ID(), Quantity: 1})
+
+	// The mug is repriced and runs low; the lamp is archived
+	cheaper, _ := value.NewMoney(800, "USD")
+	mug.UpdateDetails(mug.Name(), mug.Description(), cheaper)
+	low, _ := value.NewQuantity(2)
+	mug.UpdateStock(low)
+	lamp.Archive()
+
+	r, err := service.RevalidateBasket(ctx, basket.ID)
+	if err != nil {
+		t.Fatalf("RevalidateBasket: %v", err)
+	}
+
+	types := make(map[string]int)
+	for _, c := range r.Changes {
+		types[c.Type]++
+	}
+	if types[BasketChangePrice] != 1 || types[BasketChangeInsufficientStock] != 1 || types[BasketChangeUnavailable] != 1 {
+		t.Errorf("unexpected changes %+v", r.Changes)
+	}
+	if !r.Stale || r.CanCheckout {
+		t.Errorf("expected a stale basket that cannot be checked out, got %+v", r)
+	}
+	// 3 mugs at the new price plus the archived lamp at its basket price
+	if r.Total != 4000 || r.RevalidatedTotal != 3400 {
+		t.Errorf("expected totals 4000 and 3400, got %d and %d", r.Total, r.RevalidatedTotal)
+	}
+
+	if _, err := service.RevalidateBasket(ctx, "missing"); err == nil {
+		t.Error("expected error for an unknown basket")
+	}
+}
+
+// recordingEvents records published events
+type recordingEvents struct {
+	events []events.Event
+}
+
+func (r *recordingEvents) Publish(e events.Event) events.Event {
+	r.events = append(r.events, e)
+	return e
+}
+
+func TestBasketService_ExpireIdleBaskets(t *testing.T) {
+	ctx := context.Background()
+	baskets := newMockBasketRepo()
+	published := &recordingEvents{}
+	service := NewBasketService(baskets, newMockProductRepo(), NopMetrics{}, published)
+
+	price, _ := value.NewMoney(1000, "USD")
+	qty, _ := value.NewQuantity(2)
+	old := time.Now().Add(-48 * time.Hour)
+	for i := 0; i < 5; i++ {
+		var items []*entity.BasketItem
+		if i%2 == 0 {
+			item, _ := entity.NewBasketItem("product-1", qty, price)
+			items = append(items, item)
+		}
+		idle := entity.ReconstructBasket(fmt.Sprintf("idle-%d", i), items, old, old.Add(time.Duration(i)*time.Minute))
+		baskets.Save(ctx, idle)
+	}
+	active := entity.NewBasket()
+	baskets.Save(ctx, active)
+
+	deleted, err := service.ExpireIdleBaskets(ctx, 24*time.Hour, 2)
+	if err != nil {
+		t.Fatalf("ExpireIdleBaskets: %v", err)
+	}
+	if deleted != 5 {
+		t.Errorf("expected 5 idle baskets deleted in batches, got %d", deleted)
+	}
+	if exists, _ := baskets.ExistsByID(ctx, active.ID()); !exists || len(baskets.baskets) != 1 {
+		t.Errorf("expected only the active basket to remain, got %d baskets", len(baskets.baskets))
+	}
+
+	if len(published.events) != 3 {
+		t.Fatalf("expected an abandoned event for each of the 3 baskets with items, got %d", len(published.events))
+	}
+	e := published.events[0]
+	data, ok := e.Data.(dto.BasketAbandonedEvent)
+	if e.Topic != TopicBaskets || e.Type != EventBasketAbandoned || !ok {
+		t.Fatalf("unexpected event %+v", e)
+	}
+	if data.BasketID != "idle-0" || data.ItemCount != 2 || data.Total != 2000 || len(data.Items) != 1 {
+		t.Errorf("unexpected event data %+v", data)
+	}
+
+	if _, err := service.ExpireIdleBaskets(ctx, 0, 2); err == nil {
+		t.Error("expected error for a zero ttl")
+	}
+}
+
+func TestBasketService_MergeBaskets(t *testing.T) {
+	ctx := context.Background()
+	products := newMockProductRepo()
+	baskets := newMockBasketRepo()
+	service := NewBasketService(baskets, products, NopMetrics{}, NopEvents{})
+
+	price, _ := value.NewMoney(1000, "USD")
+	stock, _ := value.NewQuantity(4)
+	mug, _ := entity.NewProduct("Mug", "", price, stock)
+	products.Save(ctx, mug)
+
+	account, _ := service.CreateBasket(ctx)
+	guest, _ := service.CreateBasket(ctx)
+	service.AddItem(ctx, account.ID, &dto.AddItemRequest{ProductID: mug.ID(), Quantity: 3})
+	service.AddItem(ctx, guest.ID, &dto.AddItemRequest{ProductID: mug.ID(), Quantity: 2})
+
+	merged, err := service.MergeBaskets(ctx, account.ID, &dto.MergeBasketRequest{SourceBasketID: guest.ID})
+	if err != nil {
+		t.Fatalf("MergeBaskets: %v", err)
+	}
+	if merged.Strategy != "sum" || merged.Basket.ItemCount != 4 {
+		t.Errorf("expected the summed quantity limited to stock, got %+v", merged)
+	}
+	if len(merged.Adjustments) != 1 || merged.Adjustments[0].Reason != entity.MergeLimitedByStock ||
+		merged.Adjustments[0].Requested != 5 || merged.Adjustments[0].Name != "Mug" {
+		t.Errorf("unexpected adjustments %+v", merged.Adjustments)
+	}
+	if exists, _ := baskets.ExistsByID(ctx, guest.ID); exists {
+		t.Error("expected the guest basket to be deleted")
+	}
+
+	if _, err := service.MergeBaskets(ctx, account.ID, &dto.MergeBasketRequest{SourceBasketID: guest.ID}); err == nil {
+		t.Error("expected error merging a deleted basket")
+	}
+	other, _ := service.CreateBasket(ctx)
+	if _, err := service.MergeBaskets(ctx, account.ID, &dto.MergeBasketRequest{SourceBasketID: other.ID, Strategy: "first"}); err == nil {
+		t.Error("expected error for an unknown strategy")
+	}
+}
+
+func TestBasketService_MergeOnLogin(t *testing.T) {
+	ctx := context.Background()
+	products := newMockProductRepo()
+	baskets := newMockBasketRepo()
+	service := NewBasketService(baskets, products, NopMetrics{}, NopEvents{})
+
+	price, _ := value.NewMoney(1000, "USD")
+	stock, _ := value.NewQuantity(10)
+	mug, _ := entity.NewProduct("Mug", "", price, stock)
+	products.Save(ctx, mug)
+
+	t.Run("merges the guest basket", func(t *testing.T) {
+		account, _ := service.CreateBasket(ctx)
+		guest, _ := service.CreateBasket(ctx)
+		service.AddItem(ctx, account.ID, &dto.AddItemRequest{ProductID: mug.ID(), Quantity: 1})
+		service.AddItem(ctx, guest.ID, &dto.AddItemRequest{ProductID: mug.ID(), Quantity: 2})
+
+		merged, err := service.MergeOnLogin(ctx, account.ID, guest.ID)
+		if err != nil {
+			t.Fatalf("MergeOnLogin: %v", err)
+		}
+		if merged.Basket.ID != account.ID || merged.Basket.ItemCount != 3 {
+			t.Errorf("expected the guest items in the customer's basket, got %+v", merged.Basket)
+		}
+		if exists, _ := baskets.ExistsByID(ctx, guest.ID); exists {
+			t.Error("expected the guest basket to be deleted")
+		}
+
+		// Logging in again with the merged guest basket leaves the customer's basket as it is
+		again, err := service.MergeOnLogin(ctx, account.ID, guest.ID)
+		if err != nil {
+			t.Fatalf("MergeOnLogin with a merged guest basket: %v", err)
+		}
+		if again.Basket.ID != account.ID || again.Basket.ItemCount != 3 {
+			t.Errorf("expected the customer's basket unchanged, got %+v", again.Basket)
+		}
+	})
+
+	t.Run("adopts the guest basket", func(t *testing.T) {
+		guest, _ := service.CreateBasket(ctx)
+		merged, err := service.MergeOnLogin(ctx, "", guest.ID)
+		if err != nil {
+			t.Fatalf("MergeOnLogin: %v", err)
+		}
+		if merged.Basket.ID != guest.ID {
+			t.Errorf("expected the guest basket to become the customer's, got %s", merged.Basket.ID)
+		}
+	})
+
+	t.Run("creates a basket", func(t *testing.T) {
+		merged, err := service.MergeOnLogin(ctx, "", "")
+		if err != nil {
+			t.Fatalf("MergeOnLogin: %v", err)
+		}
+		if exists, _ := baskets.ExistsByID(ctx, merged.Basket.ID); !exists {
+			t.Error("expected a new basket for the customer")
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/pkg/events"
+)
+
+// Event topics and types published to EventPublisher
+const (
+	TopicOrders    = "orders"
+	TopicStock     = "stock"
+	TopicBaskets   = "baskets"
+	TopicWishlists = "wishlists"
+
+	EventOrderStatusChanged = "order.status_changed"
+	EventStockChanged       = "stock.changed"
+	EventBasketAbandoned    = "basket.abandoned"
+	EventBackInStock        = "wishlist.back_in_stock"
+)
+
+// EventPublisher receives order, stock, basket and wishlist changes for real-time delivery
+type EventPublisher interface {
+	// Publish delivers the event and returns it with its assigned ID
+	Publish(event events.Event) events.Event
+}
+
+// NopEvents is an EventPublisher that discards every event
+type NopEvents struct{}
+
+// Publish does nothing
+func (NopEvents) Publish(event events.Event) events.Event { return event }
+
+// publishOrderStatus announces a new order (previous is empty) or a status change
+func publishOrderStatus(publisher EventPublisher, order *entity.Order, previous entity.OrderStatus) {
+	publisher.Publish(events.Event{
+		Topic:      TopicOrders,
+		Type:       EventOrderStatusChanged,
+		ResourceID: order.ID(),
+		Data: dto.OrderStatusEvent{
+			OrderID:        order.ID(),
+			Status:         string(order.Status()),
+			PreviousStatus: string(previous),
+			Total:          order.Total().Amount(),
+			Currency:       order.Total().Currency(),
+		},
+	})
+}
+
+// publishStock announces a stock change of a product or, with a variantID, one of its variants
+func publishStock(publisher EventPublisher, productID, variantID string, previous, stock int) {
+	if previous == stock {
+		return
+	}
+	publisher.Publish(events.Event{
+		Topic:      TopicStock,
+		Type:       EventStockChanged,
+		ResourceID: productID,
+		Data: dto.StockEvent{
+			ProductID:     productID,
+			VariantID:     variantID,
+			Stock:         stock,
+			PreviousStock: previous,
+			InStock:       stock > 0,
+		},
+	})
+}
+
+// publishBasketAbandoned announces an expired basket that still held items
+func publishBasketAbandoned(publisher EventPublisher, basket *entity.Basket) error {
+	total, err := basket.Total()
+	if err != nil {
+		return err
+	}
+
+	items := make([]dto.AbandonedItem, 0, len(basket.Items()))
+	for _, item := range basket.Items() {
+		items = append(items, dto.AbandonedItem{
+			ProductID: item.ProductID(),
+			VariantID: item.VariantID(),
+			Quantity:  item.Quantity().Value(),
+			Price:     item.Price().Amount(),
+		})
+	}
+
+	publisher.Publish(events.Event{
+		Topic:      TopicBaskets,
+		Type:       EventBasketAbandoned,
+		ResourceID: basket.ID(),
+		Data: dto.BasketAbandonedEvent{
+			BasketID:     basket.ID(),
+			Items:        items,
+			ItemCount:    basket.ItemCount(),
+			Total:        total.Amount(),
+			Currency:     total.Currency(),
+			CreatedAt:    basket.CreatedAt(),
+			LastActivity: basket.UpdatedAt(),
+		},
+	})
+	return nil
+}
+
+// publishBackInStock announces a product or variant back in stock to a wishlist list that asked for it
+func publishBackInStock(publisher EventPublisher, notice repository.BackInStockAlert, product *entity.Product) {
+	publisher.Publish(events.Event{
+		Topic:      TopicWishlists,
+		Type:       EventBackInStock,
+		ResourceID: notice.WishlistID,
+		Data:       newBackInStockEvent(notice, product),
+	})
+}
+
+// newBackInStockEvent describes a queued back-in-stock notice for events and alerts
+func newBackInStockEvent(notice repository.BackInStockAlert, product *entity.Product) dto.BackInStockEvent {
+	return dto.BackInStockEvent{
+		WishlistID: notice.WishlistID,
+		ListID:     notice.ListID,
+		ProductID:  product.ID(),
+		VariantID:  notice.VariantID,
+		Name:       product.Name(),
+		Stock:      notice.Stock,
+	}
+}
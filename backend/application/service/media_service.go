@@ -0,0 +1,230 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/pkg/tracing"
+	"ecom-backend/pkg/validate"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// ErrImageTooLarge is returned when an upload exceeds the configured size limit
+var ErrImageTooLarge = errors.New("image exceeds the maximum upload size")
+
+// MediaService handles product image uploads and media retrieval
+type MediaService struct {
+	productRepo    repository.ProductRepository
+	blobs          repository.BlobStore
+	processor      repository.ImageProcessor
+	maxUploadBytes int64
+}
+
+// NewMediaService creates a new MediaService
+func NewMediaService(productRepo repository.ProductRepository, blobs repository.BlobStore, processor repository.ImageProcessor, maxUploadBytes int64) *MediaService {
+	return &MediaService{
+		productRepo:    productRepo,
+		blobs:          blobs,
+		processor:      processor,
+		maxUploadBytes: maxUploadBytes,
+	}
+}
+
+// MaxUploadBytes returns the maximum accepted image size in bytes
+func (s *MediaService) MaxUploadBytes() int64 {
+	return s.maxUploadBytes
+}
+
+// UploadImage validates an image, stores it with its thumbnails and appends it to the product
+func (s *MediaService) UploadImage(ctx context.Context, productID string, data []byte, altText string) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "MediaService.UploadImage")
+	defer span.End()
+
+	if len(data) == 0 {
+		return nil, errors.New("image is required")
+	}
+	if int64(len(data)) > s.maxUploadBytes {
+		return nil, ErrImageTooLarge
+	}
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, err := s.processor.Process(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store the original and every thumbnail under the image's own prefix
+	imageID := entity.NewProductImageID()
+	prefix := fmt.Sprintf("products/%s/%s", product.ID(), imageID)
+
+	original := entity.ImageRendition{
+		Name:   "original",
+		Key:    prefix + "/original" + processed.Extension,
+		Width:  processed.Width,
+		Height: processed.Height,
+	}
+	stored := []string{original.Key}
+
+	if err := s.blobs.Put(ctx, original.Key, processed.ContentType, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	original.URL = s.blobs.URL(original.Key)
+
+	thumbnails := make([]entity.ImageRendition, 0, len(processed.Thumbnails))
+	for _, t := range processed.Thumbnails {
+		key := prefix + "/" + t.Name + t.Extension
+		if err := s.blobs.Put(ctx, key, t.ContentType, bytes.NewReader(t.Data)); err != nil {
+			s.deleteBlobs(ctx, stored)
+			return nil, err
+		}
+		stored = append(stored, key)
+
+		thumbnails = append(thumbnails, entity.ImageRendition{
+			Name:   t.Name,
+			Key:    key,
+			URL:    s.blobs.URL(key),
+			Width:  t.Width,
+			Height: t.Height,
+		})
+	}
+
+	image, err := entity.NewProductImage(imageID, altText, processed.ContentType, int64(len(data)), original, thumbnails)
+	if err != nil {
+		s.deleteBlobs(ctx, stored)
+		return nil, err
+	}
+
+	if err := product.AddImage(image); err != nil {
+		s.deleteBlobs(ctx, stored)
+		return nil, err
+	}
+
+	if err := s.productRepo.AddImage(ctx, product.ID(), image); err != nil {
+		s.deleteBlobs(ctx, stored)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "product image uploaded",
+		"product_id", product.ID(),
+		"image_id", imageID,
+		"content_type", processed.ContentType,
+		"bytes", len(data),
+	)
+
+	return newProductResponse(product), nil
+}
+
+// ListImages returns the images of a product in display order
+func (s *MediaService) ListImages(ctx context.Context, productID string) ([]dto.ProductImageResponse, error) {
+	ctx, span := tracing.Start(ctx, "MediaService.ListImages")
+	defer span.End()
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	primary := product.PrimaryImage()
+	images := make([]dto.ProductImageResponse, 0, len(product.Images()))
+	for _, img := range product.Images() {
+		images = append(images, newProductImageResponse(img, primary == img))
+	}
+
+	return images, nil
+}
+
+// ReorderImages sets the display order of a product's images
+func (s *MediaService) ReorderImages(ctx context.Context, productID string, req *dto.ReorderImagesRequest) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "MediaService.ReorderImages")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := product.ReorderImages(req.ImageIDs); err != nil {
+		return nil, err
+	}
+
+	if err := s.productRepo.ReorderImages(ctx, product.ID(), req.ImageIDs); err != nil {
+		return nil, err
+	}
+
+	return newProductResponse(product), nil
+}
+
+// SetPrimaryImage marks one of a product's images as primary
+func (s *MediaService) SetPrimaryImage(ctx context.Context, productID, imageID string) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "MediaService.SetPrimaryImage")
+	defer span.End()
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := product.SetPrimaryImage(imageID); err != nil {
+		return nil, err
+	}
+
+	if err := s.productRepo.SetPrimaryImage(ctx, product.ID(), imageID); err != nil {
+		return nil, err
+	}
+
+	return newProductResponse(product), nil
+}
+
+// DeleteImage removes an image from a product and deletes its stored files
+func (s *MediaService) DeleteImage(ctx context.Context, productID, imageID string) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "MediaService.DeleteImage")
+	defer span.End()
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := product.RemoveImage(imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.productRepo.DeleteImage(ctx, product.ID(), imageID); err != nil {
+		return nil, err
+	}
+
+	s.deleteBlobs(ctx, image.Keys())
+
+	return newProductResponse(product), nil
+}
+
+// OpenMedia opens a stored media file for serving
+func (s *MediaService) OpenMedia(ctx context.Context, key string) (io.ReadSeekCloser, *repository.BlobInfo, error) {
+	ctx, span := tracing.Start(ctx, "MediaService.OpenMedia")
+	defer span.End()
+
+	return s.blobs.Open(ctx, key)
+}
+
+// deleteBlobs removes stored files on a best-effort basis
+func (s *MediaService) deleteBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			slog.WarnContext(ctx, "failed to delete media file", "key", key, "error", err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package service
+
+// Checkout failure reasons reported to MetricsRecorder
+const (
+	CheckoutFailureInvalidRequest    = "invalid_request"
+	CheckoutFailureBasketNotFound    = "basket_not_found"
+	CheckoutFailureEmptyBasket       = "empty_basket"
+	CheckoutFailureProductNotFound   = "product_not_found"
+	CheckoutFailureUnavailable       = "product_unavailable"
+	CheckoutFailureInsufficientStock = "insufficient_stock"
+	CheckoutFailureStaleBasket       = "stale_basket"
+	CheckoutFailureInternal          = "internal"
+)
+
+// MetricsRecorder receives business events for monitoring
+type MetricsRecorder interface {
+	// OrderStatusChanged counts an order entering a status
+	OrderStatusChanged(status string)
+
+	// CheckoutFailed counts a failed checkout
+	CheckoutFailed(reason string)
+
+	// BasketItemAdded counts an add-to-basket operation
+	BasketItemAdded(quantity int)
+
+	// RevenueRecorded adds order revenue in minor currency units
+	RevenueRecorded(currency string, amount int64)
+}
+
+// NopMetrics is a MetricsRecorder that discards every event
+type NopMetrics struct{}
+
+// OrderStatusChanged does nothing
+func (NopMetrics) OrderStatusChanged(string) {}
+
+// CheckoutFailed does nothing
+func (NopMetrics) CheckoutFailed(string) {}
+
+// BasketItemAdded does nothing
+func (NopMetrics) BasketItemAdded(int) {}
+
+// RevenueRecorded does nothing
+func (NopMetrics) RevenueRecorded(string, int64) {}
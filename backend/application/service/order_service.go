@@ -5,7 +5,10 @@ import (
 	"ecom-backend/application/dto"
 	"ecom-backend/domain/entity"
 	"ecom-backend/domain/repository"
+	"ecom-backend/pkg/tracing"
+	"ecom-backend/pkg/validate"
 	"errors"
+	"log/slog"
 )
 
 // OrderService handles order-related business logic
@@ -13,72 +16,137 @@ type OrderService struct {
 	orderRepo   repository.OrderRepository
 	basketRepo  repository.BasketRepository
 	productRepo repository.ProductRepository
+	metrics     MetricsRecorder
+	events      EventPublisher
+	alerts      AlertNotifier
 }
 
 // NewOrderService creates a new OrderService
-func NewOrderService(orderRepo repository.OrderRepository, basketRepo repository.BasketRepository, productRepo repository.ProductRepository) *OrderService {
+func NewOrderService(orderRepo repository.OrderRepository, basketRepo repository.BasketRepository, productRepo repository.ProductRepository, metrics MetricsRecorder, events EventPublisher, alerts AlertNotifier) *OrderService {
 	return &OrderService{
 		orderRepo:   orderRepo,
 		basketRepo:  basketRepo,
 		productRepo: productRepo,
+		metrics:     metrics,
+		events:      events,
+		alerts:      alerts,
 	}
 }
 
 // CreateOrder creates an order from a basket (checkout)
 func (s *OrderService) CreateOrder(ctx context.Context, req *dto.CreateOrderRequest) (*dto.OrderResponse, error) {
-	if req.BasketID == "" {
-		return nil, errors.New("basket ID is required")
+	ctx, span := tracing.Start(ctx, "OrderService.CreateOrder")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return s.checkoutFailed(ctx, CheckoutFailureInvalidRequest, err)
 	}
 
 	// Retrieve basket
 	basket, err := s.basketRepo.FindByID(ctx, req.BasketID)
 	if err != nil {
-		return nil, err
+		return s.checkoutFailed(ctx, CheckoutFailureBasketNotFound, err)
 	}
 
 	if basket.IsEmpty() {
-		return nil, errors.New("cannot create order from empty basket")
+		return s.checkoutFailed(ctx, CheckoutFailureEmptyBasket, errors.New("cannot create order from empty basket"))
+	}
+
+	products, err := findBasketProducts(ctx, s.productRepo, basket.Items())
+	if err != nil {
+		return s.checkoutFailed(ctx, CheckoutFailureInternal, err)
 	}
 
 	// Verify stock availability for all items
 	for _, item := range basket.Items() {
-		product, err := s.productRepo.FindByID(ctx, item.ProductID())
+		product, ok := products[item.ProductID()]
+		if !ok {
+			return s.checkoutFailed(ctx, CheckoutFailureProductNotFound, errors.New("product not found"))
+		}
+		if product.IsArchived() {
+			return s.checkoutFailed(ctx, CheckoutFailureUnavailable, errors.New("product is no longer available: "+product.Name()))
+		}
+
+		stock, err := product.StockFor(item.VariantID())
 		if err != nil {
-			return nil, err
+			return s.checkoutFailed(ctx, CheckoutFailureUnavailable, err)
 		}
 
-		if product.Stock().Value() < item.Quantity().Value() {
-			return nil, errors.New("insufficient stock for product: " + product.Name())
+		if stock.Value() < item.Quantity().Value() {
+			return s.checkoutFailed(ctx, CheckoutFailureInsufficientStock, errors.New("insufficient stock for product: "+product.Name()))
 		}
 	}
 
-	// Reduce stock for all items
+	// Refuse outdated prices unless the caller confirmed the total at current prices
+	revalidation, err := revalidateBasket(basket, products)
+	if err != nil {
+		return s.checkoutFailed(ctx, CheckoutFailureInternal, err)
+	}
+	confirmed := req.ConfirmedTotal != nil && *req.ConfirmedTotal == revalidation.RevalidatedTotal
+	if (revalidation.Stale || req.ConfirmedTotal != nil) && !confirmed {
+		return s.checkoutFailed(ctx, CheckoutFailureStaleBasket, &StaleBasketError{Revalidation: revalidation})
+	}
+	if err := acceptPriceChanges(basket, revalidation); err != nil {
+		return s.checkoutFailed(ctx, CheckoutFailureInternal, err)
+	}
+
+	// Reduce stock on the loaded products; Place persists the reductions with the order
+	type stockChange struct {
+		product         *entity.Product
+		variantID       string
+		previous, stock int
+	}
+	changes := make([]stockChange, 0, len(basket.Items()))
 	for _, item := range basket.Items() {
-		product, err := s.productRepo.FindByID(ctx, item.ProductID())
+		product := products[item.ProductID()]
+		previous, err := product.StockFor(item.VariantID())
 		if err != nil {
-			return nil, err
+			return s.checkoutFailed(ctx, CheckoutFailureInternal, err)
 		}
 
-		if err := product.ReduceStock(item.Quantity()); err != nil {
-			return nil, err
+		if err := product.ReduceStockFor(item.VariantID(), item.Quantity()); err != nil {
+			return s.checkoutFailed(ctx, CheckoutFailureInsufficientStock, err)
 		}
 
-		if err := s.productRepo.Update(ctx, product); err != nil {
-			return nil, err
-		}
+		changes = append(changes, stockChange{
+			product:   product,
+			variantID: item.VariantID(),
+			previous:  previous.Value(),
+			stock:     previous.Value() - item.Quantity().Value(),
+		})
 	}
 
-	// Create order
-	order, err := entity.NewOrder(basket.Items())
+	// Create order, recording each product as it was at checkout
+	order, err := entity.NewOrder(basket.Items(), products)
 	if err != nil {
-		return nil, err
+		return s.checkoutFailed(ctx, CheckoutFailureInternal, err)
 	}
 
-	// Persist order
-	if err := s.orderRepo.Save(ctx, order); err != nil {
-		return nil, err
+	// Persist the order and take its stock together, so concurrent checkouts cannot oversell
+	if err := s.orderRepo.Place(ctx, order); err != nil {
+		if errors.Is(err, repository.ErrInsufficientStock) {
+			return s.checkoutFailed(ctx, CheckoutFailureInsufficientStock, err)
+		}
+		return s.checkoutFailed(ctx, CheckoutFailureInternal, err)
 	}
 
+	s.metrics.OrderStatusChanged(string(order.Status()))
+	s.metrics.RevenueRecorded(order.Total().Currency(), order.Total().Amount())
+
+	publishOrderStatus(s.events, order, "")
+	for _, c := range changes {
+		publishStock(s.events, c.product.ID(), c.variantID, c.previous, c.stock)
+		notifyLowStock(ctx, s.alerts, c.product, c.variantID, c.previous, c.stock)
+	}
+
+	slog.InfoContext(ctx, "order created",
+		"order_id", order.ID(),
+		"basket_id", basket.ID(),
+		"items", len(order.Items()),
+		"total", order.Total().Amount(),
+		"currency", order.Total().Currency(),
+	)
+
 	// Clear basket after successful order
 	basket.Clear()
 	if err := s.basketRepo.Update(ctx, basket); err != nil {
@@ -90,6 +158,9 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *dto.CreateOrderRequ
 
 // GetOrder retrieves an order by ID
 func (s *OrderService) GetOrder(ctx context.Context, id string) (*dto.OrderResponse, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.GetOrder")
+	defer span.End()
+
 	order, err := s.orderRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -100,6 +171,9 @@ func (s *OrderService) GetOrder(ctx context.Context, id string) (*dto.OrderRespo
 
 // GetAllOrders retrieves all orders
 func (s *OrderService) GetAllOrders(ctx context.Context) ([]*dto.OrderResponse, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.GetAllOrders")
+	defer span.End()
+
 	orders, err := s.orderRepo.FindAll(ctx)
 	if err != nil {
 		return nil, err
@@ -115,11 +189,15 @@ func (s *OrderService) GetAllOrders(ctx context.Context) ([]*dto.OrderResponse,
 
 // ConfirmOrder confirms a pending order
 func (s *OrderService) ConfirmOrder(ctx context.Context, id string) (*dto.OrderResponse, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.ConfirmOrder")
+	defer span.End()
+
 	order, err := s.orderRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	previous := order.Status()
 	if err := order.Confirm(); err != nil {
 		return nil, err
 	}
@@ -128,16 +206,24 @@ func (s *OrderService) ConfirmOrder(ctx context.Context, id string) (*dto.OrderR
 		return nil, err
 	}
 
+	s.metrics.OrderStatusChanged(string(order.Status()))
+	publishOrderStatus(s.events, order, previous)
+	slog.InfoContext(ctx, "order confirmed", "order_id", order.ID())
+
 	return s.toOrderResponse(order), nil
 }
 
 // ShipOrder marks an order as shipped
 func (s *OrderService) ShipOrder(ctx context.Context, id string) (*dto.OrderResponse, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.ShipOrder")
+	defer span.End()
+
 	order, err := s.orderRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	previous := order.Status()
 	if err := order.Ship(); err != nil {
 		return nil, err
 	}
@@ -146,16 +232,24 @@ func (s *OrderService) ShipOrder(ctx context.Context, id string) (*dto.OrderResp
 		return nil, err
 	}
 
+	s.metrics.OrderStatusChanged(string(order.Status()))
+	publishOrderStatus(s.events, order, previous)
+	slog.InfoContext(ctx, "order shipped", "order_id", order.ID())
+
 	return s.toOrderResponse(order), nil
 }
 
 // DeliverOrder marks an order as delivered
 func (s *OrderService) DeliverOrder(ctx context.Context, id string) (*dto.OrderResponse, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.DeliverOrder")
+	defer span.End()
+
 	order, err := s.orderRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	previous := order.Status()
 	if err := order.Deliver(); err != nil {
 		return nil, err
 	}
@@ -164,16 +258,24 @@ func (s *OrderService) DeliverOrder(ctx context.Context, id string) (*dto.OrderR
 		return nil, err
 	}
 
+	s.metrics.OrderStatusChanged(string(order.Status()))
+	publishOrderStatus(s.events, order, previous)
+	slog.InfoContext(ctx, "order delivered", "order_id", order.ID())
+
 	return s.toOrderResponse(order), nil
 }
 
 // CancelOrder cancels an order
 func (s *OrderService) CancelOrder(ctx context.Context, id string) (*dto.OrderResponse, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.CancelOrder")
+	defer span.End()
+
 	order, err := s.orderRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	previous := order.Status()
 	if err := order.Cancel(); err != nil {
 		return nil, err
 	}
@@ -182,9 +284,25 @@ func (s *OrderService) CancelOrder(ctx context.Context, id string) (*dto.OrderRe
 		return nil, err
 	}
 
+	s.metrics.OrderStatusChanged(string(order.Status()))
+	publishOrderStatus(s.events, order, previous)
+	slog.InfoContext(ctx, "order cancelled", "order_id", order.ID())
+
 	return s.toOrderResponse(order), nil
 }
 
+// checkoutFailed records a failed checkout and returns its error
+func (s *OrderService) checkoutFailed(ctx context.Context, reason string, err error) (*dto.OrderResponse, error) {
+	s.metrics.CheckoutFailed(reason)
+
+	span := tracing.SpanFromContext(ctx)
+	span.SetAttribute("checkout.failure_reason", reason)
+	span.RecordError(err)
+
+	slog.WarnContext(ctx, "checkout failed", "reason", reason, "error", err)
+	return nil, err
+}
+
 // toOrderResponse converts an Order entity to OrderResponse DTO
 func (s *OrderService) toOrderResponse(order *entity.Order) *dto.OrderResponse {
 	items := make([]dto.OrderItemResponse, 0, len(order.Items()))
@@ -193,11 +311,15 @@ func (s *OrderService) toOrderResponse(order *entity.Order) *dto.OrderResponse {
 		subtotal, _ := item.Subtotal()
 
 		items = append(items, dto.OrderItemResponse{
-			ProductID: item.ProductID(),
-			Quantity:  item.Quantity().Value(),
-			Price:     item.Price().Amount(),
-			Currency:  item.Price().Currency(),
-			Subtotal:  subtotal.Amount(),
+			ProductID:   item.ProductID(),
+			VariantID:   item.VariantID(),
+			Name:        item.Product().Name(),
+			SKU:         item.Product().SKU(),
+			Description: item.Product().Description(),
+			Quantity:    item.Quantity().Value(),
+			Price:       item.Price().Amount(),
+			Currency:    item.Price().Currency(),
+			Subtotal:    subtotal.Amount(),
 		})
 	}
 
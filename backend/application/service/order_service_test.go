@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/domain/value"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// Mock order repository
+type mockOrderRepo struct {
+	orders   map[string]*entity.Order
+	placeErr error
+}
+
+func newMockOrderRepo() *mockOrderRepo {
+	return &mockOrderRepo{
+		orders: make(map[string]*entity.Order),
+	}
+}
+
+func (m *mockOrderRepo) Save(ctx context.Context, order *entity.Order) error {
+	m.orders[order.ID()] = order
+	return nil
+}
+
+func (m *mockOrderRepo) Place(ctx context.Context, order *entity.Order) error {
+	if m.placeErr != nil {
+		return m.placeErr
+	}
+	m.orders[order.ID()] = order
+	return nil
+}
+
+func (m *mockOrderRepo) FindByID(ctx context.Context, id string) (*entity.Order, error) {
+	order, ok := m.orders[id]
+	if !ok {
+		return nil, errors.New("order not found")
+	}
+	return order, nil
+}
+
+func (m *mockOrderRepo) FindAll(ctx context.Context) ([]*entity.Order, error) {
+	orders := make([]*entity.Order, 0, len(m.orders))
+	for _, o := range m.orders {
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+func (m *mockOrderRepo) Update(ctx context.Context, order *entity.Order) error {
+	m.orders[order.ID()] = order
+	return nil
+}
+
+func (m *mockOrderRepo) ExistsByID(ctx context.Context, id string) (bool, error) {
+	_, ok := m.orders[id]
+	return ok, nil
+}
+
+// recordingMetrics records checkout failure reasons
+type recordingMetrics struct {
+	NopMetrics
+	failures []string
+}
+
+func (m *recordingMetrics) CheckoutFailed(reason string) {
+	m.failures = append(m.failures, reason)
+}
+
+func TestOrderService_CreateOrderWithChangedPrices(t *testing.T) {
+	ctx := context.Background()
+	products := newMockProductRepo()
+	baskets := newMockBasketRepo()
+	metrics := &recordingMetrics{}
+	basketService := NewBasketService(baskets, products, metrics, NopEvents{})
+	orderService := NewOrderService(newMockOrderRepo(), baskets, products, metrics, NopEvents{}, NopAlerts{})
+
+	price, _ := value.NewMoney(1000, "USD")
+	stock, _ := value.NewQuantity(10)
+	product, _ := entity.NewProduct("Mug", "Stoneware mug", price, stock)
+	products.Save(ctx, product)
+
+	basket, _ := basketService.CreateBasket(ctx)
+	if _, err := basketService.AddItem(ctx, basket.ID, &dto.AddItemRequest{ProductID: product.ID(), Quantity: 2}); err != nil {
+		t.Fatalf("AddItem: %v", err)
+	}
+
+	newPrice, _ := value.NewMoney(1250, "USD")
+	product.UpdateDetails(product.Name(), product.Description(), newPrice)
+
+	revalidation, err := basketService.RevalidateBasket(ctx, basket.ID)
+	if err != nil {
+		t.Fatalf("RevalidateBasket: %v", err)
+	}
+	if !revalidation.Stale || !revalidation.CanCheckout || revalidation.Total != 2000 || revalidation.RevalidatedTotal != 2500 {
+		t.Fatalf("unexpected revalidation %+v", revalidation)
+	}
+	if len(revalidation.Changes) != 1 || revalidation.Changes[0].Type != BasketChangePrice || revalidation.Changes[0].NewPrice != 1250 {
+		t.Fatalf("unexpected changes %+v", revalidation.Changes)
+	}
+
+	t.Run("unconfirmed", func(t *testing.T) {
+		_, err := orderService.CreateOrder(ctx, &dto.CreateOrderRequest{BasketID: basket.ID})
+		var stale *StaleBasketError
+		if !errors.As(err, &stale) {
+			t.Fatalf("expected StaleBasketError, got %v", err)
+		}
+		if stale.Revalidation.RevalidatedTotal != 2500 {
+			t.Errorf("expected revalidated total 2500, got %d", stale.Revalidation.RevalidatedTotal)
+		}
+		if last := metrics.failures[len(metrics.failures)-1]; last != CheckoutFailureStaleBasket {
+			t.Errorf("expected failure reason %s, got %s", CheckoutFailureStaleBasket, last)
+		}
+	})
+
+	t.Run("confirmed old total", func(t *testing.T) {
+		old := int64(2000)
+		_, err := orderService.CreateOrder(ctx, &dto.CreateOrderRequest{BasketID: basket.ID, ConfirmedTotal: &old})
+		var stale *StaleBasketError
+		if !errors.As(err, &stale) {
+			t.Fatalf("expected StaleBasketError, got %v", err)
+		}
+	})
+
+	t.Run("confirmed revalidated total", func(t *testing.T) {
+		confirmed := revalidation.RevalidatedTotal
+		order, err := orderService.CreateOrder(ctx, &dto.CreateOrderRequest{BasketID: basket.ID, ConfirmedTotal: &confirmed})
+		if err != nil {
+			t.Fatalf("CreateOrder: %v", err)
+		}
+		if order.Total != 2500 || order.Items[0].Price != 1250 {
+			t.Errorf("expected the order at current prices, got %+v", order)
+		}
+	})
+}
+
+func TestOrderService_CreateOrderRejectsWrongConfirmedTotal(t *testing.T) {
+	ctx := context.Background()
+	products := newMockProductRepo()
+	baskets := newMockBasketRepo()
+	basketService := NewBasketService(baskets, products, NopMetrics{}, NopEvents{})
+	orderService := NewOrderService(newMockOrderRepo(), baskets, products, NopMetrics{}, NopEvents{}, NopAlerts{})
+
+	price, _ := value.NewMoney(1000, "USD")
+	stock, _ := value.NewQuantity(10)
+	product, _ := entity.NewProduct("Mug", "", price, stock)
+	products.Save(ctx, product)
+
+	basket, _ := basketService.CreateBasket(ctx)
+	basketService.AddItem(ctx, basket.ID, &dto.AddItemRequest{ProductID: product.ID(), Quantity: 1})
+
+	wrong := int64(999)
+	_, err := orderService.CreateOrder(ctx, &dto.CreateOrderRequest{BasketID: basket.ID, ConfirmedTotal: &wrong})
+	var stale *StaleBasketError
+	if !errors.As(err, &stale) {
+		t.Fatalf("expected StaleBasketError for a mismatched total, got %v", err)
+	}
+
+	order, err := orderService.CreateOrder(ctx, &dto.CreateOrderRequest{BasketID: basket.ID})
+	if err != nil {
+		t.Fatalf("expected an unchanged basket to check out without confirmation, got %v", err)
+	}
+	if order.Items[0].Name != "Mug" {
+		t.Errorf("expected the product snapshot on the order item, got %+v", order.Items[0])
+	}
+}
+
+func TestOrderService_CreateOrderRaisesLowStockAlert(t *testing.T) {
+	ctx := context.Background()
+	products := newMockProductRepo()
+	baskets := newMockBasketRepo()
+	raised := &recordingAlerts{}
+	basketService := NewBasketService(baskets, products, NopMetrics{}, NopEvents{})
+	orderService := NewOrderService(newMockOrderRepo(), baskets, products, NopMetrics{}, NopEvents{}, raised)
+
+	price, _ := value.NewMoney(1000, "USD")
+	stock, _ := value.NewQuantity(6)
+	product, _ := entity.NewProduct("Mug", "", price, stock)
+	product.SetReorderPolicy(4, 12)
+	products.Save(ctx, product)
+
+	checkout := func(quantity int) {
+		t.Helper()
+		basket, _ := basketService.CreateBasket(ctx)
+		basketService.AddItem(ctx, basket.ID, &dto.AddItemRequest{ProductID: product.ID(), Quantity: quantity})
+		if _, err := orderService.CreateOrder(ctx, &dto.CreateOrderRequest{BasketID: basket.ID}); err != nil {
+			t.Fatalf("CreateOrder: %v", err)
+		}
+	}
+
+	checkout(1)
+	if len(raised.alerts) != 0 {
+		t.Fatalf("expected no alert above the threshold, got %d", len(raised.alerts))
+	}
+
+	checkout(2)
+	checkout(1)
+	if len(raised.alerts) != 1 {
+		t.Fatalf("expected one alert when stock fell to the threshold, got %d", len(raised.alerts))
+	}
+	if data := raised.alerts[0].Data.(dto.LowStockAlert); data.Stock != 3 || data.PreviousStock != 5 {
+		t.Errorf("expected stock to fall from 5 to 3, got %+v", data)
+	}
+}
+
+func TestOrderService_CreateOrderPlacesWithLoadedProducts(t *testing.T) {
+	ctx := context.Background()
+	products := newMockProductRepo()
+	baskets := newMockBasketRepo()
+	orders := newMockOrderRepo()
+	metrics := &recordingMetrics{}
+	basketService := NewBasketService(baskets, products, metrics, NopEvents{})
+	orderService := NewOrderService(orders, baskets, products, metrics, NopEvents{}, NopAlerts{})
+
+	price, _ := value.NewMoney(1000, "USD")
+	stock, _ := value.NewQuantity(5)
+	product, _ := entity.NewProduct("Mug", "", price, stock)
+	products.Save(ctx, product)
+
+	basket, _ := basketService.CreateBasket(ctx)
+	basketService.AddItem(ctx, basket.ID, &dto.AddItemRequest{ProductID: product.ID(), Quantity: 2})
+
+	t.Run("stock taken concurrently", func(t *testing.T) {
+		orders.placeErr = fmt.Errorf("%w for product: Mug", repository.ErrInsufficientStock)
+		defer func() { orders.placeErr = nil }()
+
+		_, err := orderService.CreateOrder(ctx, &dto.CreateOrderRequest{BasketID: basket.ID})
+		if err == nil {
+			t.Fatal("expected checkout to fail when the stock is gone")
+		}
+		if last := metrics.failures[len(metrics.failures)-1]; last != CheckoutFailureInsufficientStock {
+			t.Errorf("expected failure reason %s, got %s", CheckoutFailureInsufficientStock, last)
+		}
+		if len(orders.orders) != 0 {
+			t.Errorf("expected no order to be saved, got %d", len(orders.orders))
+		}
+		if found, _ := baskets.FindByID(ctx, basket.ID); found.IsEmpty() {
+			t.Error("expected the basket to be kept")
+		}
+	})
+
+	t.Run("products loaded once", func(t *testing.T) {
+		// Checkout reuses the batch-loaded products instead of fetching each line again
+		products.findErr = errors.New("unexpected FindByID")
+		defer func() { products.findErr = nil }()
+
+		if _, err := orderService.CreateOrder(ctx, &dto.CreateOrderRequest{BasketID: basket.ID}); err != nil {
+			t.Fatalf("CreateOrder: %v", err)
+		}
+		if len(orders.orders) != 1 {
+			t.Errorf("expected the order to be placed, got %d", len(orders.orders))
+		}
+	})
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/domain/value"
+	"ecom-backend/pkg/tracing"
+	"ecom-backend/pkg/validate"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// exportBatchSize is the number of products loaded per query while exporting
+const exportBatchSize = 100
+
+// ErrMalformedRecord marks an import record that could not be decoded.
+// The import reports it against its row and continues with the next record.
+var ErrMalformedRecord = errors.New("malformed record")
+
+// ProductRecordReader reads catalog records from an import source.
+// Next returns io.EOF once all records have been read.
+type ProductRecordReader interface {
+	Next() (*dto.ProductRecord, error)
+}
+
+// ProductRecordWriter writes catalog records to an export destination
+type ProductRecordWriter interface {
+	Write(record *dto.ProductRecord) error
+	Flush() error
+}
+
+// ImportProducts creates or updates products from catalog records, matching existing products by ID or SKU.
+// Each record is validated and saved on its own; in dry-run mode nothing is saved.
+func (s *ProductService) ImportProducts(ctx context.Context, reader ProductRecordReader, dryRun bool) (*dto.ImportResultResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.ImportProducts")
+	defer span.End()
+
+	result := &dto.ImportResultResponse{
+		DryRun: dryRun,
+		Errors: make([]dto.ImportRowError, 0),
+	}
+	seen := make(map[string]int)
+
+	for row := 1; ; row++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		result.Total++
+		if err != nil {
+			if !errors.Is(err, ErrMalformedRecord) {
+				return nil, err
+			}
+			addRowError(result, row, nil, err)
+			continue
+		}
+
+		if err := claimRecordKeys(seen, record, row); err != nil {
+			addRowError(result, row, record, err)
+			continue
+		}
+
+		created, err := s.importRecord(ctx, record, dryRun)
+		if err != nil {
+			addRowError(result, row, record, err)
+			continue
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	slog.InfoContext(ctx, "product import finished",
+		"dry_run", dryRun,
+		"total", result.Total,
+		"created", result.Created,
+		"updated", result.Updated,
+		"failed", result.Failed,
+	)
+
+	return result, nil
+}
+
+// ExportProducts streams every product to the writer in batches
+func (s *ProductService) ExportProducts(ctx context.Context, writer ProductRecordWriter) error {
+	ctx, span := tracing.Start(ctx, "ProductService.ExportProducts")
+	defer span.End()
+
+	afterID := ""
+	for {
+		products, err := s.productRepo.FindAfter(ctx, afterID, exportBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, product := range products {
+			if err := writer.Write(newProductRecord(product)); err != nil {
+				return err
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+
+		if len(products) < exportBatchSize {
+			return nil
+		}
+		afterID = products[len(products)-1].ID()
+	}
+}
+
+// importRecord creates or updates the product described by a record, reporting whether it was created
+func (s *ProductService) importRecord(ctx context.Context, record *dto.ProductRecord, dryRun bool) (bool, error) {
+	if err := validate.Struct(record); err != nil {
+		return false, err
+	}
+
+	product, err := s.findImportTarget(ctx, record)
+	if err != nil {
+		return false, err
+	}
+	if product != nil && product.IsArchived() {
+		return false, errors.New("product is archived; restore it before importing")
+	}
+
+	if product == nil {
+		product, err = s.buildProduct(ctx, newCreateProductRequest(record))
+		if err != nil {
+			return false, err
+		}
+		if dryRun {
+			return true, nil
+		}
+		return true, s.productRepo.Save(ctx, product)
+	}
+
+	if err := s.applyRecord(ctx, product, record); err != nil {
+		return false, err
+	}
+	if dryRun {
+		return false, nil
+	}
+	return false, s.productRepo.Update(ctx, product)
+}
+
+// findImportTarget returns the existing product a record refers to, or nil if it describes a new product
+func (s *ProductService) findImportTarget(ctx context.Context, record *dto.ProductRecord) (*entity.Product, error) {
+	if record.ID != "" {
+		return s.productRepo.FindByID(ctx, record.ID)
+	}
+	if record.SKU == "" {
+		return nil, nil
+	}
+
+	exists, err := s.productRepo.ExistsBySKU(ctx, record.SKU)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	// The SKU exists but belongs to no product, so a variant holds it
+	product, err := s.productRepo.FindBySKU(ctx, record.SKU)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errors.New("SKU already in use by a variant: " + record.SKU)
+		}
+		return nil, err
+	}
+	return product, nil
+}
+
+// applyRecord updates an existing product from a validated record
+func (s *ProductService) applyRecord(ctx context.Context, product *entity.Product, record *dto.ProductRecord) error {
+	if record.SKU != "" && record.SKU != product.SKU() {
+		if err := s.ensureSKUAvailable(ctx, record.SKU); err != nil {
+			return err
+		}
+		product.AssignSKU(record.SKU)
+	}
+
+	price, err := value.NewMoney(record.Price, record.Currency)
+	if err != nil {
+		return err
+	}
+	if err := product.UpdateDetails(record.Name, record.Description, price); err != nil {
+		return err
+	}
+	product.Categorize(record.Category)
+
+	if err := s.applyRecordVariants(ctx, product, record); err != nil {
+		return err
+	}
+
+	if record.Stock != nil {
+		if product.HasVariants() {
+			return errors.New("stock is managed per variant for this product")
+		}
+		stock, err := value.NewQuantity(*record.Stock)
+		if err != nil {
+			return err
+		}
+		if err := product.UpdateStock(stock); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyRecordVariants adds new options and variants and updates variants matched by SKU
+func (s *ProductService) applyRecordVariants(ctx context.Context, product *entity.Product, record *dto.ProductRecord) error {
+	existing := make(map[string]bool)
+	for _, o := range product.Options() {
+		existing[o.Name()] = true
+	}
+	for _, o := range record.Options {
+		if existing[o.Name] {
+			continue
+		}
+		option, err := entity.NewProductOption(o.Name, o.Values)
+		if err != nil {
+			return err
+		}
+		if err := product.AddOption(option); err != nil {
+			return err
+		}
+	}
+
+	variants := make(map[string]*entity.ProductVariant)
+	for _, v := range product.Variants() {
+		variants[v.SKU()] = v
+	}
+
+	for i := range record.Variants {
+		req := &record.Variants[i]
+
+		variant, ok := variants[req.SKU]
+		if !ok {
+			if err := s.ensureSKUAvailable(ctx, req.SKU); err != nil {
+				return err
+			}
+			variant, err := s.newVariant(req, product.Price().Currency())
+			if err != nil {
+				return err
+			}
+			if err := product.AddVariant(variant); err != nil {
+				return err
+			}
+			continue
+		}
+
+		price, err := s.priceOverride(req.Price, product.Price().Currency())
+		if err != nil {
+			return err
+		}
+		stock, err := value.NewQuantity(req.Stock)
+		if err != nil {
+			return err
+		}
+		variant.UpdateDetails(req.Barcode, price)
+		if err := variant.UpdateStock(stock); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// claimRecordKeys rejects records that repeat an ID or SKU used earlier in the same import
+func claimRecordKeys(seen map[string]int, record *dto.ProductRecord, row int) error {
+	keys := make([]string, 0, len(record.Variants)+2)
+	if record.ID != "" {
+		keys = append(keys, "ID "+record.ID)
+	}
+	if record.SKU != "" {
+		keys = append(keys, "SKU "+record.SKU)
+	}
+	for _, v := range record.Variants {
+		if v.SKU != "" {
+			keys = append(keys, "SKU "+v.SKU)
+		}
+	}
+
+	for _, key := range keys {
+		if first, ok := seen[key]; ok && first != row {
+			return fmt.Errorf("duplicate %s in import, first seen on row %d", key, first)
+		}
+		seen[key] = row
+	}
+
+	return nil
+}
+
+// addRowError records a rejected row in the import result
+func addRowError(result *dto.ImportResultResponse, row int, record *dto.ProductRecord, err error) {
+	rowErr := dto.ImportRowError{Row: row, Error: err.Error()}
+	if record != nil {
+		rowErr.ID = record.ID
+		rowErr.SKU = record.SKU
+	}
+
+	result.Failed++
+	result.Errors = append(result.Errors, rowErr)
+}
+
+// newCreateProductRequest converts an import record into a create request
+func newCreateProductRequest(record *dto.ProductRecord) *dto.CreateProductRequest {
+	req := &dto.CreateProductRequest{
+		SKU:         record.SKU,
+		Name:        record.Name,
+		Description: record.Description,
+		Category:    record.Category,
+		Price:       record.Price,
+		Currency:    record.Currency,
+		Options:     record.Options,
+		Variants:    record.Variants,
+	}
+	if record.Stock != nil {
+		req.Stock = *record.Stock
+	}
+	return req
+}
+
+// newProductRecord converts a Product entity into an export record
+func newProductRecord(product *entity.Product) *dto.ProductRecord {
+	record := &dto.ProductRecord{
+		ID:          product.ID(),
+		SKU:         product.SKU(),
+		Name:        product.Name(),
+		Description: product.Description(),
+		Category:    product.Category(),
+		Price:       product.Price().Amount(),
+		Currency:    product.Price().Currency(),
+	}
+
+	if !product.HasVariants() {
+		stock := product.Stock().Value()
+		record.Stock = &stock
+	}
+
+	for _, o := range product.Options() {
+		record.Options = append(record.Options, dto.ProductOptionRequest{Name: o.Name(), Values: o.Values()})
+	}
+	for _, v := range product.Variants() {
+		variant := dto.AddVariantRequest{
+			SKU:     v.SKU(),
+			Barcode: v.Barcode(),
+			Options: v.Options(),
+			Stock:   v.Stock().Value(),
+		}
+		if override := v.PriceOverride(); override != nil {
+			amount := override.Amount()
+			variant.Price = &amount
+		}
+		record.Variants = append(record.Variants, variant)
+	}
+
+	return record
+}
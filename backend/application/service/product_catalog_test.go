@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// sliceRecordReader replays records and errors in order
+type sliceRecordReader struct {
+	records []*dto.ProductRecord
+	errs    []error
+	pos     int
+}
+
+func (r *sliceRecordReader) Next() (*dto.ProductRecord, error) {
+	if r.pos >= len(r.records) {
+		return nil, io.EOF
+	}
+	i := r.pos
+	r.pos++
+	if r.errs != nil && r.errs[i] != nil {
+		return nil, r.errs[i]
+	}
+	return r.records[i], nil
+}
+
+// sliceRecordWriter collects written records
+type sliceRecordWriter struct {
+	records []*dto.ProductRecord
+	flushes int
+}
+
+func (w *sliceRecordWriter) Write(record *dto.ProductRecord) error {
+	w.records = append(w.records, record)
+	return nil
+}
+
+func (w *sliceRecordWriter) Flush() error {
+	w.flushes++
+	return nil
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestProductService_ImportProducts(t *testing.T) {
+	repo := newMockProductRepo()
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
+	ctx := context.Background()
+
+	existing, err := service.CreateProduct(ctx, &dto.CreateProductRequest{
+		SKU: "MUG-1", Name: "Mug", Price: 900, Currency: "USD", Stock: 3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reader := &sliceRecordReader{
+		records: []*dto.ProductRecord{
+			{SKU: "MUG-1", Name: "Large Mug", Price: 1200, Currency: "USD", Stock: intPtr(7)},
+			{SKU: "CUP-1", Name: "Cup", Price: 500, Currency: "USD", Stock: intPtr(2)},
+			{SKU: "BAD-1", Name: "", Price: 500, Currency: "USD"},
+			{SKU: "CUP-1", Name: "Cup again", Price: 500, Currency: "USD"},
+			nil,
+			{ID: "missing", Name: "Ghost", Price: 100, Currency: "USD"},
+		},
+		errs: []error{nil, nil, nil, nil, fmt.Errorf("%w: invalid price", ErrMalformedRecord), nil},
+	}
+
+	result, err := service.ImportProducts(ctx, reader, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Total != 6 || result.Created != 1 || result.Updated != 1 || result.Failed != 4 {
+		t.Errorf("unexpected counts: %+v", result)
+	}
+	wantRows := []int{3, 4, 5, 6}
+	for i, rowErr := range result.Errors {
+		if rowErr.Row != wantRows[i] {
+			t.Errorf("expected error on row %d, got row %d (%s)", wantRows[i], rowErr.Row, rowErr.Error)
+		}
+	}
+
+	updated, _ := repo.FindByID(ctx, existing.ID)
+	if updated.Name() != "Large Mug" || updated.Stock().Value() != 7 {
+		t.Errorf("expected existing product to be updated, got %s with stock %d", updated.Name(), updated.Stock().Value())
+	}
+	if len(repo.products) != 2 {
+		t.Errorf("expected 2 products, got %d", len(repo.products))
+	}
+}
+
+func TestProductService_ImportProducts_DryRun(t *testing.T) {
+	repo := newMockProductRepo()
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
+
+	reader := &sliceRecordReader{
+		records: []*dto.ProductRecord{
+			{SKU: "CUP-1", Name: "Cup", Price: 500, Currency: "USD"},
+			{SKU: "CUP-2", Name: "Cup", Price: -1, Currency: "USD"},
+		},
+	}
+
+	result, err := service.ImportProducts(context.Background(), reader, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.DryRun || result.Created != 1 || result.Failed != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(repo.products) != 0 {
+		t.Errorf("expected dry run to save nothing, got %d products", len(repo.products))
+	}
+}
+
+func TestProductService_ImportProducts_SKULookup(t *testing.T) {
+	repo := newMockProductRepo()
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
+	ctx := context.Background()
+
+	_, err := service.CreateProduct(ctx, &dto.CreateProductRequest{
+		Name: "Shirt", Price: 2000, Currency: "USD",
+		Options:  []dto.ProductOptionRequest{{Name: "size", Values: []string{"S"}}},
+		Variants: []dto.AddVariantRequest{{SKU: "SHIRT-S", Options: map[string]string{"size": "S"}, Stock: 3}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records := []*dto.ProductRecord{{SKU: "SHIRT-S", Name: "Shirt", Price: 2000, Currency: "USD"}}
+
+	result, _ := service.ImportProducts(ctx, &sliceRecordReader{records: records}, true)
+	if len(result.Errors) != 1 || result.Errors[0].Error != "SKU already in use by a variant: SHIRT-S" {
+		t.Errorf("expected the variant SKU to be reported, got %+v", result.Errors)
+	}
+
+	// A failing lookup is reported as it is, not as a SKU conflict
+	repo.findErr = errors.New("connection refused")
+	result, _ = service.ImportProducts(ctx, &sliceRecordReader{records: records}, true)
+	if len(result.Errors) != 1 || result.Errors[0].Error != "connection refused" {
+		t.Errorf("expected the lookup error, got %+v", result.Errors)
+	}
+}
+
+func TestProductService_ExportProducts(t *testing.T) {
+	repo := newMockProductRepo()
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
+	ctx := context.Background()
+
+	for i := 0; i < exportBatchSize+5; i++ {
+		_, err := service.CreateProduct(ctx, &dto.CreateProductRequest{
+			Name: fmt.Sprintf("Product %d", i), Price: 100, Currency: "USD", Stock: 1,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	_, err := service.CreateProduct(ctx, &dto.CreateProductRequest{
+		Name: "Shirt", Price: 2000, Currency: "USD",
+		Options:  []dto.ProductOptionRequest{{Name: "size", Values: []string{"S"}}},
+		Variants: []dto.AddVariantRequest{{SKU: "SHIRT-S", Options: map[string]string{"size": "S"}, Stock: 4}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	writer := &sliceRecordWriter{}
+	if err := service.ExportProducts(ctx, writer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(writer.records) != exportBatchSize+6 {
+		t.Fatalf("expected %d records, got %d", exportBatchSize+6, len(writer.records))
+	}
+	if writer.flushes != 2 {
+		t.Errorf("expected a flush per batch, got %d", writer.flushes)
+	}
+
+	for _, record := range writer.records {
+		if record.Name != "Shirt" {
+			continue
+		}
+		if record.Stock != nil {
+			t.Error("expected no product-level stock for a product with variants")
+		}
+		if len(record.Variants) != 1 || record.Variants[0].Stock != 4 {
+			t.Errorf("unexpected exported variants: %+v", record.Variants)
+		}
+	}
+}
@@ -6,32 +6,62 @@ import (
 	"ecom-backend/domain/entity"
 	"ecom-backend/domain/repository"
 	"ecom-backend/domain/value"
+	"ecom-backend/pkg/tracing"
+	"ecom-backend/pkg/validate"
 	"errors"
+	"log/slog"
+	"math"
+	"sort"
 )
 
 // ProductService handles product-related business logic
 type ProductService struct {
-	productRepo repository.ProductRepository
+	productRepo  repository.ProductRepository
+	wishlistRepo repository.WishlistRepository
+	events       EventPublisher
+	alerts       AlertNotifier
 }
 
 // NewProductService creates a new ProductService
-func NewProductService(productRepo repository.ProductRepository) *ProductService {
+func NewProductService(productRepo repository.ProductRepository, wishlistRepo repository.WishlistRepository, events EventPublisher, alerts AlertNotifier) *ProductService {
 	return &ProductService{
-		productRepo: productRepo,
+		productRepo:  productRepo,
+		wishlistRepo: wishlistRepo,
+		events:       events,
+		alerts:       alerts,
 	}
 }
 
 // CreateProduct creates a new product
 func (s *ProductService) CreateProduct(ctx context.Context, req *dto.CreateProductRequest) (*dto.ProductResponse, error) {
-	// Validate request
-	if req.Name == "" {
-		return nil, errors.New("product name is required")
+	ctx, span := tracing.Start(ctx, "ProductService.CreateProduct")
+	defer span.End()
+
+	product, err := s.buildProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Persist
+	if err := s.productRepo.Save(ctx, product); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "product created", "product_id", product.ID(), "sku", product.SKU())
+
+	return s.toProductResponse(product), nil
+}
+
+// buildProduct validates a create request and builds the Product entity without persisting it
+func (s *ProductService) buildProduct(ctx context.Context, req *dto.CreateProductRequest) (*entity.Product, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, err
 	}
-	if req.Price < 0 {
-		return nil, errors.New("price cannot be negative")
+	if req.Stock != 0 && len(req.Variants) > 0 {
+		return nil, errors.New("stock is managed per variant for this product")
 	}
-	if req.Stock < 0 {
-		return nil, errors.New("stock cannot be negative")
+	if err := s.ensureSKUsAvailable(ctx, req); err != nil {
+		return nil, err
 	}
 
 	// Create value objects
@@ -50,17 +80,41 @@ func (s *ProductService) CreateProduct(ctx context.Context, req *dto.CreateProdu
 	if err != nil {
 		return nil, err
 	}
+	if req.SKU != "" {
+		product.AssignSKU(req.SKU)
+	}
+	if req.Category != "" {
+		product.Categorize(req.Category)
+	}
 
-	// Persist
-	if err := s.productRepo.Save(ctx, product); err != nil {
-		return nil, err
+	// Attach options and variants
+	for _, o := range req.Options {
+		option, err := entity.NewProductOption(o.Name, o.Values)
+		if err != nil {
+			return nil, err
+		}
+		if err := product.AddOption(option); err != nil {
+			return nil, err
+		}
+	}
+	for i := range req.Variants {
+		variant, err := s.newVariant(&req.Variants[i], req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if err := product.AddVariant(variant); err != nil {
+			return nil, err
+		}
 	}
 
-	return s.toProductResponse(product), nil
+	return product, nil
 }
 
 // GetProduct retrieves a product by ID
 func (s *ProductService) GetProduct(ctx context.Context, id string) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetProduct")
+	defer span.End()
+
 	product, err := s.productRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -69,8 +123,30 @@ func (s *ProductService) GetProduct(ctx context.Context, id string) (*dto.Produc
 	return s.toProductResponse(product), nil
 }
 
+// GetProductsByIDs retrieves products by ID in one lookup, keyed by ID. IDs that do not exist are left out.
+func (s *ProductService) GetProductsByIDs(ctx context.Context, ids []string) (map[string]*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetProductsByIDs")
+	defer span.End()
+	span.SetAttribute("product.count", len(ids))
+
+	products, err := s.productRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(map[string]*dto.ProductResponse, len(products))
+	for _, product := range products {
+		responses[product.ID()] = s.toProductResponse(product)
+	}
+
+	return responses, nil
+}
+
 // GetAllProducts retrieves all products
 func (s *ProductService) GetAllProducts(ctx context.Context) ([]*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetAllProducts")
+	defer span.End()
+
 	products, err := s.productRepo.FindAll(ctx)
 	if err != nil {
 		return nil, err
@@ -86,12 +162,11 @@ func (s *ProductService) GetAllProducts(ctx context.Context) ([]*dto.ProductResp
 
 // UpdateProduct updates an existing product
 func (s *ProductService) UpdateProduct(ctx context.Context, id string, req *dto.UpdateProductRequest) (*dto.ProductResponse, error) {
-	// Validate request
-	if req.Name == "" {
-		return nil, errors.New("product name is required")
-	}
-	if req.Price < 0 {
-		return nil, errors.New("price cannot be negative")
+	ctx, span := tracing.Start(ctx, "ProductService.UpdateProduct")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
 	}
 
 	// Retrieve existing product
@@ -110,6 +185,9 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id string, req *dto.
 	if err := product.UpdateDetails(req.Name, req.Description, price); err != nil {
 		return nil, err
 	}
+	if req.Category != nil {
+		product.Categorize(*req.Category)
+	}
 
 	// Persist
 	if err := s.productRepo.Update(ctx, product); err != nil {
@@ -121,20 +199,27 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id string, req *dto.
 
 // UpdateStock updates product stock
 func (s *ProductService) UpdateStock(ctx context.Context, id string, req *dto.UpdateStockRequest) (*dto.ProductResponse, error) {
-	if req.Stock < 0 {
-		return nil, errors.New("stock cannot be negative")
+	ctx, span := tracing.Start(ctx, "ProductService.UpdateStock")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
 	}
 
 	product, err := s.productRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if product.HasVariants() {
+		return nil, errors.New("stock is managed per variant for this product")
+	}
 
 	stock, err := value.NewQuantity(req.Stock)
 	if err != nil {
 		return nil, err
 	}
 
+	previous := product.Stock().Value()
 	if err := product.UpdateStock(stock); err != nil {
 		return nil, err
 	}
@@ -143,32 +228,505 @@ func (s *ProductService) UpdateStock(ctx context.Context, id string, req *dto.Up
 		return nil, err
 	}
 
+	publishStock(s.events, product.ID(), "", previous, req.Stock)
+	s.notifyBackInStock(ctx, product, "", previous, req.Stock)
+	notifyLowStock(ctx, s.alerts, product, "", previous, req.Stock)
+
+	return s.toProductResponse(product), nil
+}
+
+// UpdateReorderPolicy sets the stock at which a product or its variants raise a low-stock
+// alert and the quantity to reorder then
+func (s *ProductService) UpdateReorderPolicy(ctx context.Context, id string, req *dto.UpdateReorderPolicyRequest) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.UpdateReorderPolicy")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := product.SetReorderPolicy(req.LowStockThreshold, req.ReorderQuantity); err != nil {
+		return nil, err
+	}
+
+	if err := s.productRepo.UpdateReorderPolicy(ctx, product); err != nil {
+		return nil, err
+	}
+
 	return s.toProductResponse(product), nil
 }
 
-// DeleteProduct deletes a product
+// GetLowStockReport lists every active product and variant at or below its low-stock
+// threshold, lowest stock first
+func (s *ProductService) GetLowStockReport(ctx context.Context) (*dto.LowStockReport, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetLowStockReport")
+	defer span.End()
+
+	products, err := s.productRepo.FindLowStock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.LowStockItem, 0, len(products))
+	for _, product := range products {
+		if !product.HasVariants() {
+			if product.IsLowStock("") {
+				items = append(items, newLowStockItem(product, "", product.Stock().Value()))
+			}
+			continue
+		}
+		for _, v := range product.Variants() {
+			if product.IsLowStock(v.ID()) {
+				items = append(items, newLowStockItem(product, v.ID(), v.Stock().Value()))
+			}
+		}
+	}
+
+	// Products come back by name; keep that order among items with the same stock
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Stock < items[j].Stock })
+
+	return &dto.LowStockReport{Total: len(items), Items: items}, nil
+}
+
+// DeleteProduct archives a product and removes it from every basket.
+// Archived products stay resolvable by ID so order history keeps working.
 func (s *ProductService) DeleteProduct(ctx context.Context, id string) error {
-	exists, err := s.productRepo.ExistsByID(ctx, id)
+	ctx, span := tracing.Start(ctx, "ProductService.DeleteProduct")
+	defer span.End()
+
+	product, err := s.productRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return errors.New("product not found")
+
+	if err := product.Archive(); err != nil {
+		return err
+	}
+
+	baskets, err := s.productRepo.Archive(ctx, product)
+	if err != nil {
+		return err
 	}
 
-	return s.productRepo.Delete(ctx, id)
+	slog.InfoContext(ctx, "product archived", "product_id", id, "baskets_updated", baskets)
+	return nil
+}
+
+// GetArchivedProducts retrieves all archived products
+func (s *ProductService) GetArchivedProducts(ctx context.Context) ([]*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetArchivedProducts")
+	defer span.End()
+
+	products, err := s.productRepo.FindArchived(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.ProductResponse, 0, len(products))
+	for _, product := range products {
+		responses = append(responses, s.toProductResponse(product))
+	}
+
+	return responses, nil
+}
+
+// RestoreProduct returns an archived product to the catalog
+func (s *ProductService) RestoreProduct(ctx context.Context, id string) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.RestoreProduct")
+	defer span.End()
+
+	product, err := s.productRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := product.Restore(); err != nil {
+		return nil, err
+	}
+
+	if err := s.productRepo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "product restored", "product_id", id)
+
+	return s.toProductResponse(product), nil
+}
+
+// PurgeProduct permanently deletes an archived product that no order refers to
+func (s *ProductService) PurgeProduct(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "ProductService.PurgeProduct")
+	defer span.End()
+
+	product, err := s.productRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !product.IsArchived() {
+		return errors.New("only archived products can be purged")
+	}
+
+	ordered, err := s.productRepo.IsReferencedByOrders(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ordered {
+		return errors.New("product is referenced by orders and cannot be purged")
+	}
+
+	if err := s.productRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "product purged", "product_id", id)
+	return nil
+}
+
+// AddVariant adds a variant to an existing product
+func (s *ProductService) AddVariant(ctx context.Context, productID string, req *dto.AddVariantRequest) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.AddVariant")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureSKUAvailable(ctx, req.SKU); err != nil {
+		return nil, err
+	}
+
+	variant, err := s.newVariant(req, product.Price().Currency())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := product.AddVariant(variant); err != nil {
+		return nil, err
+	}
+
+	if err := s.productRepo.AddVariant(ctx, product.ID(), variant); err != nil {
+		return nil, err
+	}
+
+	return s.toProductResponse(product), nil
+}
+
+// UpdateVariant updates the barcode and price override of a variant
+func (s *ProductService) UpdateVariant(ctx context.Context, productID, variantID string, req *dto.UpdateVariantRequest) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.UpdateVariant")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := product.Variant(variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := s.priceOverride(req.Price, product.Price().Currency())
+	if err != nil {
+		return nil, err
+	}
+
+	variant.UpdateDetails(req.Barcode, price)
+
+	if err := s.productRepo.UpdateVariant(ctx, product.ID(), variant); err != nil {
+		return nil, err
+	}
+
+	return s.toProductResponse(product), nil
+}
+
+// UpdateVariantStock updates the stock of a variant
+func (s *ProductService) UpdateVariantStock(ctx context.Context, productID, variantID string, req *dto.UpdateStockRequest) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.UpdateVariantStock")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	variant, err := product.Variant(variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := value.NewQuantity(req.Stock)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := variant.Stock().Value()
+	if err := variant.UpdateStock(stock); err != nil {
+		return nil, err
+	}
+
+	if err := s.productRepo.UpdateVariantStock(ctx, product.ID(), variant); err != nil {
+		return nil, err
+	}
+
+	publishStock(s.events, product.ID(), variant.ID(), previous, req.Stock)
+	s.notifyBackInStock(ctx, product, variant.ID(), previous, req.Stock)
+	notifyLowStock(ctx, s.alerts, product, variant.ID(), previous, req.Stock)
+
+	return s.toProductResponse(product), nil
+}
+
+// RemoveVariant removes a variant from a product
+func (s *ProductService) RemoveVariant(ctx context.Context, productID, variantID string) (*dto.ProductResponse, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.RemoveVariant")
+	defer span.End()
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := product.RemoveVariant(variantID); err != nil {
+		return nil, err
+	}
+
+	if err := s.productRepo.RemoveVariant(ctx, product.ID(), variantID); err != nil {
+		return nil, err
+	}
+
+	return s.toProductResponse(product), nil
+}
+
+// DeliverBackInStockAlerts delivers up to batchSize queued back-in-stock notices, oldest first,
+// and returns the number delivered. Each is sent through the alert notifiers before it leaves
+// the queue and is then published on the wishlists topic. A notice the notifiers refuse stays
+// queued for the next run. Notices for products since archived or purged are dropped.
+func (s *ProductService) DeliverBackInStockAlerts(ctx context.Context, batchSize int) (int, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.DeliverBackInStockAlerts")
+	defer span.End()
+
+	delivered := 0
+	var deliverErr error
+	err := s.wishlistRepo.ProcessBackInStockAlerts(ctx, batchSize, func(notices []repository.BackInStockAlert) (done, failed []int64) {
+		ids := make([]string, 0, len(notices))
+		for _, notice := range notices {
+			ids = append(ids, notice.ProductID)
+		}
+		products, err := s.productRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			deliverErr = err
+			return nil, nil
+		}
+		byID := make(map[string]*entity.Product, len(products))
+		for _, product := range products {
+			byID[product.ID()] = product
+		}
+
+		for i, notice := range notices {
+			product, ok := byID[notice.ProductID]
+			if !ok || product.IsArchived() {
+				done = append(done, notice.ID)
+				continue
+			}
+
+			if err := s.alerts.Deliver(ctx, newBackInStockAlert(notice, product)); err != nil {
+				// The notifiers are likely down; leave the rest of the batch for the next run
+				slog.WarnContext(ctx, "Failed to deliver back-in-stock alert", "wishlist_id", notice.WishlistID,
+					"product_id", notice.ProductID, "attempts", notice.Attempts+1, "remaining", len(notices)-i-1, "error", err)
+				deliverErr = err
+				failed = append(failed, notice.ID)
+				break
+			}
+
+			publishBackInStock(s.events, notice, product)
+			done = append(done, notice.ID)
+			delivered++
+		}
+		return done, failed
+	})
+	if err != nil {
+		return delivered, err
+	}
+
+	return delivered, deliverErr
+}
+
+// notifyBackInStock queues the wishlist alerts of a product or variant whose stock went from
+// zero to positive, for the back_in_stock job to deliver
+func (s *ProductService) notifyBackInStock(ctx context.Context, product *entity.Product, variantID string, previous, stock int) {
+	if previous != 0 || stock == 0 || product.IsArchived() {
+		return
+	}
+
+	if _, err := s.wishlistRepo.QueueBackInStockAlerts(ctx, product.ID(), variantID, stock); err != nil {
+		// The stock is already saved; a failed alert must not fail the update
+		slog.WarnContext(ctx, "Failed to queue back-in-stock alerts", "product_id", product.ID(), "variant_id", variantID, "error", err)
+	}
+}
+
+// newVariant builds a ProductVariant from a validated request
+func (s *ProductService) newVariant(req *dto.AddVariantRequest, currency string) (*entity.ProductVariant, error) {
+	price, err := s.priceOverride(req.Price, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := value.NewQuantity(req.Stock)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewProductVariant(req.SKU, req.Barcode, req.Options, price, stock)
+}
+
+// priceOverride converts an optional price override into Money
+func (s *ProductService) priceOverride(amount *int64, currency string) (*value.Money, error) {
+	if amount == nil {
+		return nil, nil
+	}
+	return value.NewMoney(*amount, currency)
+}
+
+// ensureSKUsAvailable checks that every SKU in a create request is unique
+func (s *ProductService) ensureSKUsAvailable(ctx context.Context, req *dto.CreateProductRequest) error {
+	seen := make(map[string]bool)
+	skus := make([]string, 0, len(req.Variants)+1)
+	if req.SKU != "" {
+		skus = append(skus, req.SKU)
+	}
+	for _, v := range req.Variants {
+		skus = append(skus, v.SKU)
+	}
+
+	for _, sku := range skus {
+		if seen[sku] {
+			return errors.New("duplicate SKU: " + sku)
+		}
+		seen[sku] = true
+
+		if err := s.ensureSKUAvailable(ctx, sku); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureSKUAvailable checks that no product or variant already uses the SKU
+func (s *ProductService) ensureSKUAvailable(ctx context.Context, sku string) error {
+	if sku == "" {
+		return nil
+	}
+
+	exists, err := s.productRepo.ExistsBySKU(ctx, sku)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("SKU already in use: " + sku)
+	}
+
+	return nil
 }
 
 // toProductResponse converts a Product entity to ProductResponse DTO
 func (s *ProductService) toProductResponse(product *entity.Product) *dto.ProductResponse {
-	return &dto.ProductResponse{
-		ID:          product.ID(),
-		Name:        product.Name(),
-		Description: product.Description(),
-		Price:       product.Price().Amount(),
-		Currency:    product.Price().Currency(),
-		Stock:       product.Stock().Value(),
-		CreatedAt:   product.CreatedAt(),
-		UpdatedAt:   product.UpdatedAt(),
+	return newProductResponse(product)
+}
+
+// newProductResponse converts a Product entity to ProductResponse DTO
+func newProductResponse(product *entity.Product) *dto.ProductResponse {
+	response := &dto.ProductResponse{
+		ID:                product.ID(),
+		SKU:               product.SKU(),
+		Name:              product.Name(),
+		Description:       product.Description(),
+		Category:          product.Category(),
+		Price:             product.Price().Amount(),
+		Currency:          product.Price().Currency(),
+		Stock:             product.Stock().Value(),
+		ArchivedAt:        product.DeletedAt(),
+		AverageRating:     math.Round(product.AverageRating()*100) / 100,
+		ReviewCount:       product.ReviewCount(),
+		LowStockThreshold: product.LowStockThreshold(),
+		ReorderQuantity:   product.ReorderQuantity(),
+		CreatedAt:         product.CreatedAt(),
+		UpdatedAt:         product.UpdatedAt(),
+	}
+
+	for _, o := range product.Options() {
+		response.Options = append(response.Options, dto.ProductOptionResponse{
+			Name:   o.Name(),
+			Values: o.Values(),
+		})
+	}
+
+	if product.HasVariants() {
+		// Products sold through variants report the combined variant stock
+		response.Stock = 0
+		for _, v := range product.Variants() {
+			price, _ := product.PriceFor(v.ID())
+			response.Variants = append(response.Variants, dto.ProductVariantResponse{
+				ID:            v.ID(),
+				SKU:           v.SKU(),
+				Barcode:       v.Barcode(),
+				Options:       v.Options(),
+				Price:         price.Amount(),
+				PriceOverride: v.PriceOverride() != nil,
+				Currency:      price.Currency(),
+				Stock:         v.Stock().Value(),
+			})
+			response.Stock += v.Stock().Value()
+		}
+	}
+
+	primary := product.PrimaryImage()
+	for _, img := range product.Images() {
+		image := newProductImageResponse(img, primary == img)
+		response.Images = append(response.Images, image)
+		if image.Primary {
+			response.PrimaryImage = &image
+		}
+	}
+
+	return response
+}
+
+// newProductImageResponse converts a ProductImage entity to ProductImageResponse DTO
+func newProductImageResponse(img *entity.ProductImage, primary bool) dto.ProductImageResponse {
+	original := img.Original()
+	thumbnails := make(map[string]dto.ImageRenditionResponse, len(img.Thumbnails()))
+	for _, t := range img.Thumbnails() {
+		thumbnails[t.Name] = dto.ImageRenditionResponse{URL: t.URL, Width: t.Width, Height: t.Height}
+	}
+
+	return dto.ProductImageResponse{
+		ID:          img.ID(),
+		URL:         original.URL,
+		AltText:     img.AltText(),
+		ContentType: img.ContentType(),
+		Size:        img.Size(),
+		Width:       original.Width,
+		Height:      original.Height,
+		Primary:     primary,
+		Thumbnails:  thumbnails,
 	}
 }
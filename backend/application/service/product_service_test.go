@@ -4,18 +4,28 @@ import (
 	"context"
 	"ecom-backend/application/dto"
 	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
 	"ecom-backend/domain/value"
+	"ecom-backend/pkg/alert"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Mock repository for service testing
 type mockProductRepo struct {
 	products  map[string]*entity.Product
+	ordered   map[string]bool
 	saveErr   error
 	findErr   error
 	updateErr error
 	deleteErr error
+
+	// archived records the products archived and removed from baskets
+	archived []string
 }
 
 func newMockProductRepo() *mockProductRepo {
@@ -38,36 +48,154 @@ func (m *mockProductRepo) FindByID(ctx context.Context, id string) (*entity.Prod
 	}
 	product, ok := m.products[id]
 	if !ok {
-		return nil, errors.New("product not found")
+		return nil, fmt.Errorf("product %w", repository.ErrNotFound)
 	}
 	return product, nil
 }
 
+func (m *mockProductRepo) FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0, len(ids))
+	for _, id := range ids {
+		if p, ok := m.products[id]; ok {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
 func (m *mockProductRepo) FindAll(ctx context.Context) ([]*entity.Product, error) {
 	products := make([]*entity.Product, 0, len(m.products))
 	for _, p := range m.products {
-		products = append(products, p)
+		if !p.IsArchived() {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+func (m *mockProductRepo) FindArchived(ctx context.Context) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0)
+	for _, p := range m.products {
+		if p.IsArchived() {
+			products = append(products, p)
+		}
 	}
 	return products, nil
 }
 
+func (m *mockProductRepo) FindBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	if m.findErr != nil {
+		return nil, m.findErr
+	}
+	for _, p := range m.products {
+		if p.SKU() == sku {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("product %w", repository.ErrNotFound)
+}
+
+func (m *mockProductRepo) FindAfter(ctx context.Context, afterID string, limit int) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0)
+	for _, p := range m.products {
+		if p.ID() > afterID && !p.IsArchived() {
+			products = append(products, p)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].ID() < products[j].ID() })
+	if len(products) > limit {
+		products = products[:limit]
+	}
+	return products, nil
+}
+
+func (m *mockProductRepo) FindLowStock(ctx context.Context) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0)
+	for _, p := range m.products {
+		low := p.IsLowStock("")
+		for _, v := range p.Variants() {
+			low = low || p.IsLowStock(v.ID())
+		}
+		if low {
+			products = append(products, p)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].Name() < products[j].Name() })
+	return products, nil
+}
+
 func (m *mockProductRepo) Update(ctx context.Context, product *entity.Product) error {
 	if m.updateErr != nil {
 		return m.updateErr
 	}
 	if _, ok := m.products[product.ID()]; !ok {
-		return errors.New("product not found")
+		return fmt.Errorf("product %w", repository.ErrNotFound)
 	}
 	m.products[product.ID()] = product
 	return nil
 }
 
+func (m *mockProductRepo) Archive(ctx context.Context, product *entity.Product) (int, error) {
+	if err := m.Update(ctx, product); err != nil {
+		return 0, err
+	}
+	m.archived = append(m.archived, product.ID())
+	return 0, nil
+}
+
+func (m *mockProductRepo) AddVariant(ctx context.Context, productID string, variant *entity.ProductVariant) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepo) UpdateVariant(ctx context.Context, productID string, variant *entity.ProductVariant) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepo) UpdateVariantStock(ctx context.Context, productID string, variant *entity.ProductVariant) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepo) RemoveVariant(ctx context.Context, productID, variantID string) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepo) UpdateReorderPolicy(ctx context.Context, product *entity.Product) error {
+	return m.Update(ctx, product)
+}
+
+func (m *mockProductRepo) AddImage(ctx context.Context, productID string, image *entity.ProductImage) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepo) DeleteImage(ctx context.Context, productID, imageID string) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepo) ReorderImages(ctx context.Context, productID string, imageIDs []string) error {
+	return m.touch(productID)
+}
+
+func (m *mockProductRepo) SetPrimaryImage(ctx context.Context, productID, imageID string) error {
+	return m.touch(productID)
+}
+
+// touch stands in for the variant and image writes: the stored product is the one the service changed
+func (m *mockProductRepo) touch(productID string) error {
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	if _, ok := m.products[productID]; !ok {
+		return fmt.Errorf("product %w", repository.ErrNotFound)
+	}
+	return nil
+}
+
 func (m *mockProductRepo) Delete(ctx context.Context, id string) error {
 	if m.deleteErr != nil {
 		return m.deleteErr
 	}
 	if _, ok := m.products[id]; !ok {
-		return errors.New("product not found")
+		return fmt.Errorf("product %w", repository.ErrNotFound)
 	}
 	delete(m.products, id)
 	return nil
@@ -78,9 +206,92 @@ func (m *mockProductRepo) ExistsByID(ctx context.Context, id string) (bool, erro
 	return ok, nil
 }
 
+func (m *mockProductRepo) IsReferencedByOrders(ctx context.Context, id string) (bool, error) {
+	return m.ordered[id], nil
+}
+
+func (m *mockProductRepo) ExistsBySKU(ctx context.Context, sku string) (bool, error) {
+	for _, p := range m.products {
+		if p.SKU() == sku {
+			return true, nil
+		}
+		for _, v := range p.Variants() {
+			if v.SKU() == sku {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Mock basket repository
+type mockBasketRepo struct {
+	baskets map[string]*entity.Basket
+}
+
+func newMockBasketRepo() *mockBasketRepo {
+	return &mockBasketRepo{
+		baskets: make(map[string]*entity.Basket),
+	}
+}
+
+func (m *mockBasketRepo) Save(ctx context.Context, basket *entity.Basket) error {
+	m.baskets[basket.ID()] = basket
+	return nil
+}
+
+func (m *mockBasketRepo) FindByID(ctx context.Context, id string) (*entity.Basket, error) {
+	basket, ok := m.baskets[id]
+	if !ok {
+		return nil, fmt.Errorf("basket %w", repository.ErrNotFound)
+	}
+	return basket, nil
+}
+
+func (m *mockBasketRepo) Update(ctx context.Context, basket *entity.Basket) error {
+	m.baskets[basket.ID()] = basket
+	return nil
+}
+
+func (m *mockBasketRepo) Delete(ctx context.Context, id string) error {
+	delete(m.baskets, id)
+	return nil
+}
+
+func (m *mockBasketRepo) UpdateMerged(ctx context.Context, basket *entity.Basket, mergedID string) error {
+	if _, ok := m.baskets[mergedID]; !ok {
+		return fmt.Errorf("basket %w", repository.ErrNotFound)
+	}
+	delete(m.baskets, mergedID)
+	m.baskets[basket.ID()] = basket
+	return nil
+}
+
+func (m *mockBasketRepo) DeleteIdle(ctx context.Context, before time.Time, limit int) ([]*entity.Basket, error) {
+	idle := make([]*entity.Basket, 0)
+	for _, b := range m.baskets {
+		if b.UpdatedAt().Before(before) {
+			idle = append(idle, b)
+		}
+	}
+	sort.Slice(idle, func(i, j int) bool { return idle[i].UpdatedAt().Before(idle[j].UpdatedAt()) })
+	if len(idle) > limit {
+		idle = idle[:limit]
+	}
+	for _, b := range idle {
+		delete(m.baskets, b.ID())
+	}
+	return idle, nil
+}
+
+func (m *mockBasketRepo) ExistsByID(ctx context.Context, id string) (bool, error) {
+	_, ok := m.baskets[id]
+	return ok, nil
+}
+
 func TestProductService_CreateProduct(t *testing.T) {
 	repo := newMockProductRepo()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
 	ctx := context.Background()
 
 	t.Run("Valid product creation", func(t *testing.T) {
@@ -159,7 +370,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 
 func TestProductService_GetProduct(t *testing.T) {
 	repo := newMockProductRepo()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
 	ctx := context.Background()
 
 	// Create a test product
@@ -193,7 +404,7 @@ func TestProductService_GetProduct(t *testing.T) {
 
 func TestProductService_UpdateProduct(t *testing.T) {
 	repo := newMockProductRepo()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
 	ctx := context.Background()
 
 	// Create a test product
@@ -240,7 +451,7 @@ func TestProductService_UpdateProduct(t *testing.T) {
 
 func TestProductService_DeleteProduct(t *testing.T) {
 	repo := newMockProductRepo()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
 	ctx := context.Background()
 
 	// Create a test product
@@ -249,17 +460,34 @@ func TestProductService_DeleteProduct(t *testing.T) {
 	product, _ := entity.NewProduct("To Delete", "Description", price, stock)
 	repo.Save(ctx, product)
 
-	t.Run("Delete existing product", func(t *testing.T) {
+	t.Run("Delete existing product archives it", func(t *testing.T) {
 		err := service.DeleteProduct(ctx, product.ID())
 
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
 
-		// Verify it's deleted
-		exists, _ := repo.ExistsByID(ctx, product.ID())
-		if exists {
-			t.Error("Expected product to be deleted")
+		// Verify it's archived but still resolvable
+		response, err := service.GetProduct(ctx, product.ID())
+		if err != nil {
+			t.Fatalf("Expected archived product to be resolvable, got %v", err)
+		}
+		if response.ArchivedAt == nil {
+			t.Error("Expected product to be archived")
+		}
+
+		products, _ := service.GetAllProducts(ctx)
+		if len(products) != 0 {
+			t.Errorf("Expected archived product to be hidden, got %d products", len(products))
+		}
+		if len(repo.archived) != 1 || repo.archived[0] != product.ID() {
+			t.Errorf("Expected product to be removed from baskets, got %v", repo.archived)
+		}
+	})
+
+	t.Run("Delete archived product", func(t *testing.T) {
+		if err := service.DeleteProduct(ctx, product.ID()); err == nil {
+			t.Error("Expected error archiving an archived product, got nil")
 		}
 	})
 
@@ -272,9 +500,59 @@ func TestProductService_DeleteProduct(t *testing.T) {
 	})
 }
 
+func TestProductService_RestoreAndPurge(t *testing.T) {
+	repo := newMockProductRepo()
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
+	ctx := context.Background()
+
+	price, _ := value.NewMoney(1999, "USD")
+	stock, _ := value.NewQuantity(10)
+	ordered, _ := entity.NewProduct("Ordered", "Description", price, stock)
+	unordered, _ := entity.NewProduct("Unordered", "Description", price, stock)
+	repo.Save(ctx, ordered)
+	repo.Save(ctx, unordered)
+	repo.ordered = map[string]bool{ordered.ID(): true}
+
+	if err := service.PurgeProduct(ctx, unordered.ID()); err == nil {
+		t.Error("Expected error purging an active product, got nil")
+	}
+
+	for _, id := range []string{ordered.ID(), unordered.ID()} {
+		if err := service.DeleteProduct(ctx, id); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	archived, _ := service.GetArchivedProducts(ctx)
+	if len(archived) != 2 {
+		t.Errorf("Expected 2 archived products, got %d", len(archived))
+	}
+
+	if err := service.PurgeProduct(ctx, ordered.ID()); err == nil {
+		t.Error("Expected error purging an ordered product, got nil")
+	}
+	if err := service.PurgeProduct(ctx, unordered.ID()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if exists, _ := repo.ExistsByID(ctx, unordered.ID()); exists {
+		t.Error("Expected purged product to be deleted")
+	}
+
+	restored, err := service.RestoreProduct(ctx, ordered.ID())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if restored.ArchivedAt != nil {
+		t.Error("Expected restored product to be active")
+	}
+	if _, err := service.RestoreProduct(ctx, ordered.ID()); err == nil {
+		t.Error("Expected error restoring an active product, got nil")
+	}
+}
+
 func TestProductService_GetAllProducts(t *testing.T) {
 	repo := newMockProductRepo()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
 	ctx := context.Background()
 
 	// Create test products
@@ -299,7 +577,7 @@ func TestProductService_GetAllProducts(t *testing.T) {
 
 func TestProductService_UpdateStock(t *testing.T) {
 	repo := newMockProductRepo()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
 	ctx := context.Background()
 
 	// Create a test product
@@ -331,3 +609,191 @@ func TestProductService_UpdateStock(t *testing.T) {
 		}
 	})
 }
+
+func TestProductService_Variants(t *testing.T) {
+	repo := newMockProductRepo()
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, NopAlerts{})
+	ctx := context.Background()
+
+	override := int64(2500)
+	req := &dto.CreateProductRequest{
+		Name:     "Shirt",
+		Price:    2000,
+		Currency: "USD",
+		Options: []dto.ProductOptionRequest{
+			{Name: "size", Values: []string{"S", "M"}},
+		},
+		Variants: []dto.AddVariantRequest{
+			{SKU: "SHIRT-S", Options: map[string]string{"size": "S"}, Stock: 3},
+			{SKU: "SHIRT-M", Options: map[string]string{"size": "M"}, Price: &override, Stock: 4},
+		},
+	}
+
+	response, err := service.CreateProduct(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("Create product with variants", func(t *testing.T) {
+		if len(response.Variants) != 2 {
+			t.Fatalf("Expected 2 variants, got %d", len(response.Variants))
+		}
+		if response.Stock != 7 {
+			t.Errorf("Expected combined stock 7, got %d", response.Stock)
+		}
+		if response.Variants[1].Price != 2500 {
+			t.Errorf("Expected override price 2500, got %d", response.Variants[1].Price)
+		}
+	})
+
+	t.Run("Reject duplicate SKU across products", func(t *testing.T) {
+		dup := &dto.CreateProductRequest{
+			SKU:      "SHIRT-S",
+			Name:     "Other",
+			Price:    1000,
+			Currency: "USD",
+		}
+
+		_, err := service.CreateProduct(ctx, dup)
+
+		if err == nil {
+			t.Error("Expected error for duplicate SKU, got nil")
+		}
+	})
+
+	t.Run("Reject product-level stock with variants", func(t *testing.T) {
+		withStock := *req
+		withStock.Name = "Hoodie"
+		withStock.Stock = 5
+		withStock.Variants = []dto.AddVariantRequest{
+			{SKU: "HOODIE-S", Options: map[string]string{"size": "S"}, Stock: 3},
+		}
+
+		_, err := service.CreateProduct(ctx, &withStock)
+
+		if err == nil {
+			t.Error("Expected error for product stock alongside variants, got nil")
+		}
+	})
+
+	t.Run("Reject product-level stock update", func(t *testing.T) {
+		_, err := service.UpdateStock(ctx, response.ID, &dto.UpdateStockRequest{Stock: 5})
+
+		if err == nil {
+			t.Error("Expected error for stock update on a product with variants, got nil")
+		}
+	})
+
+	t.Run("Update variant stock", func(t *testing.T) {
+		updated, err := service.UpdateVariantStock(ctx, response.ID, response.Variants[0].ID, &dto.UpdateStockRequest{Stock: 10})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if updated.Variants[0].Stock != 10 {
+			t.Errorf("Expected variant stock 10, got %d", updated.Variants[0].Stock)
+		}
+	})
+}
+
+// recordingAlerts records raised alerts
+type recordingAlerts struct {
+	alerts []alert.Alert
+}
+
+func (r *recordingAlerts) Notify(ctx context.Context, a alert.Alert) error {
+	r.alerts = append(r.alerts, a)
+	return nil
+}
+
+func (r *recordingAlerts) Deliver(ctx context.Context, a alert.Alert) error {
+	return r.Notify(ctx, a)
+}
+
+func TestProductService_LowStockAlerts(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockProductRepo()
+	raised := &recordingAlerts{}
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, raised)
+
+	mug, _ := service.CreateProduct(ctx, &dto.CreateProductRequest{SKU: "MUG-1", Name: "Mug", Price: 1000, Currency: "USD", Stock: 10})
+	if _, err := service.UpdateReorderPolicy(ctx, mug.ID, &dto.UpdateReorderPolicyRequest{LowStockThreshold: 5, ReorderQuantity: 20}); err != nil {
+		t.Fatalf("UpdateReorderPolicy: %v", err)
+	}
+
+	for _, stock := range []int{6, 4, 2, 8, 5} {
+		if _, err := service.UpdateStock(ctx, mug.ID, &dto.UpdateStockRequest{Stock: stock}); err != nil {
+			t.Fatalf("UpdateStock: %v", err)
+		}
+	}
+
+	// Falling to 4 and to 5 after a restock cross the threshold; falling further to 2 does not
+	if len(raised.alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(raised.alerts))
+	}
+	a := raised.alerts[0]
+	data, ok := a.Data.(dto.LowStockAlert)
+	if a.Type != AlertLowStock || !ok {
+		t.Fatalf("expected a low-stock alert, got %+v", a)
+	}
+	if data.Stock != 4 || data.PreviousStock != 6 || data.ReorderQuantity != 20 || data.SKU != "MUG-1" {
+		t.Errorf("unexpected alert data %+v", data)
+	}
+	if a.Subject != "Low stock: Mug (MUG-1)" || !strings.Contains(a.Text, "Reorder 20 units") {
+		t.Errorf("unexpected alert %q: %q", a.Subject, a.Text)
+	}
+
+	if _, err := service.UpdateReorderPolicy(ctx, mug.ID, &dto.UpdateReorderPolicyRequest{ReorderQuantity: 20}); err == nil {
+		t.Error("expected error for a reorder quantity without a threshold")
+	}
+	if _, err := service.UpdateReorderPolicy(ctx, mug.ID, &dto.UpdateReorderPolicyRequest{LowStockThreshold: -1}); err == nil {
+		t.Error("expected error for a negative threshold")
+	}
+}
+
+func TestProductService_GetLowStockReport(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockProductRepo()
+	raised := &recordingAlerts{}
+	service := NewProductService(repo, newMockWishlistRepo(), NopEvents{}, raised)
+
+	lamp, _ := service.CreateProduct(ctx, &dto.CreateProductRequest{Name: "Lamp", Price: 1000, Currency: "USD", Stock: 3})
+	plenty, _ := service.CreateProduct(ctx, &dto.CreateProductRequest{Name: "Bowl", Price: 1000, Currency: "USD", Stock: 50})
+	untracked, _ := service.CreateProduct(ctx, &dto.CreateProductRequest{Name: "Plate", Price: 1000, Currency: "USD", Stock: 0})
+	shirt, _ := service.CreateProduct(ctx, &dto.CreateProductRequest{
+		Name: "Shirt", Price: 2000, Currency: "USD",
+		Options: []dto.ProductOptionRequest{{Name: "size", Values: []string{"S", "M"}}},
+		Variants: []dto.AddVariantRequest{
+			{SKU: "SHIRT-S", Options: map[string]string{"size": "S"}, Stock: 1},
+			{SKU: "SHIRT-M", Options: map[string]string{"size": "M"}, Stock: 9},
+		},
+	})
+	for _, id := range []string{lamp.ID, plenty.ID, shirt.ID} {
+		service.UpdateReorderPolicy(ctx, id, &dto.UpdateReorderPolicyRequest{LowStockThreshold: 4, ReorderQuantity: 10})
+	}
+
+	report, err := service.GetLowStockReport(ctx)
+	if err != nil {
+		t.Fatalf("GetLowStockReport: %v", err)
+	}
+	if report.Total != 2 || len(report.Items) != 2 {
+		t.Fatalf("expected 2 low-stock items, got %+v", report.Items)
+	}
+	if report.Items[0].VariantID != shirt.Variants[0].ID || report.Items[0].SKU != "SHIRT-S" || report.Items[0].Stock != 1 {
+		t.Errorf("expected the small shirt first, got %+v", report.Items[0])
+	}
+	if report.Items[1].ProductID != lamp.ID || report.Items[1].ReorderQuantity != 10 {
+		t.Errorf("expected the lamp second, got %+v", report.Items[1])
+	}
+	for _, item := range report.Items {
+		if item.ProductID == untracked.ID {
+			t.Error("expected products without a threshold to be left out")
+		}
+	}
+
+	// The variant falling below the threshold alerts for that variant only
+	service.UpdateVariantStock(ctx, shirt.ID, shirt.Variants[1].ID, &dto.UpdateStockRequest{Stock: 2})
+	if len(raised.alerts) != 1 || raised.alerts[0].Data.(dto.LowStockAlert).VariantID != shirt.Variants[1].ID {
+		t.Errorf("expected one alert for the medium shirt, got %+v", raised.alerts)
+	}
+}
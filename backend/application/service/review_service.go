@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/pkg/tracing"
+	"ecom-backend/pkg/validate"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Review pagination limits
+const (
+	defaultReviewLimit = 20
+	maxReviewLimit     = 100
+)
+
+// ReviewService handles product reviews and their moderation
+type ReviewService struct {
+	reviewRepo  repository.ReviewRepository
+	productRepo repository.ProductRepository
+	orderRepo   repository.OrderRepository
+}
+
+// NewReviewService creates a new ReviewService
+func NewReviewService(reviewRepo repository.ReviewRepository, productRepo repository.ProductRepository, orderRepo repository.OrderRepository) *ReviewService {
+	return &ReviewService{
+		reviewRepo:  reviewRepo,
+		productRepo: productRepo,
+		orderRepo:   orderRepo,
+	}
+}
+
+// CreateReview submits a review of a product for moderation. A review naming a delivered
+// order that holds the product is marked as a verified purchase; an order can only
+// verify one review of each product.
+func (s *ReviewService) CreateReview(ctx context.Context, productID string, req *dto.CreateReviewRequest) (*dto.ReviewResponse, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.CreateReview")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.IsArchived() {
+		return nil, errors.New("product is no longer available")
+	}
+
+	verified := false
+	if req.OrderID != "" {
+		if err := s.verifyPurchase(ctx, product.ID(), req.OrderID); err != nil {
+			return nil, err
+		}
+		verified = true
+	}
+
+	review, err := entity.NewReview(product.ID(), req.OrderID, req.Author, req.Rating, req.Title, req.Body, verified)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewRepo.Save(ctx, review); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "review submitted", "review_id", review.ID(), "product_id", product.ID(), "verified_purchase", verified)
+
+	return newReviewResponse(review), nil
+}
+
+// ListProductReviews returns a page of a product's approved reviews
+func (s *ReviewService) ListProductReviews(ctx context.Context, productID string, req *dto.ReviewListRequest) (*dto.ReviewListResponse, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.ListProductReviews")
+	defer span.End()
+
+	if _, err := s.productRepo.FindByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	return s.listReviews(ctx, productID, entity.ReviewApproved, req)
+}
+
+// ListReviews returns a page of reviews of every product with a status, pending by
+// default, for the moderation queue
+func (s *ReviewService) ListReviews(ctx context.Context, req *dto.ReviewListRequest) (*dto.ReviewListResponse, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.ListReviews")
+	defer span.End()
+
+	status := entity.ReviewPending
+	if req.Status != "" {
+		var err error
+		if status, err = entity.ParseReviewStatus(req.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.listReviews(ctx, "", status, req)
+}
+
+// ApproveReview publishes a review and adds its rating to the product
+func (s *ReviewService) ApproveReview(ctx context.Context, id string, req *dto.ModerateReviewRequest) (*dto.ReviewResponse, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.ApproveReview")
+	defer span.End()
+
+	return s.moderate(ctx, id, req, (*entity.Review).Approve)
+}
+
+// RejectReview hides a review, taking its rating off the product if it was approved
+func (s *ReviewService) RejectReview(ctx context.Context, id string, req *dto.ModerateReviewRequest) (*dto.ReviewResponse, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.RejectReview")
+	defer span.End()
+
+	return s.moderate(ctx, id, req, (*entity.Review).Reject)
+}
+
+// moderate applies a moderation decision; the repository recalculates the product rating
+func (s *ReviewService) moderate(ctx context.Context, id string, req *dto.ModerateReviewRequest, decide func(*entity.Review, string) error) (*dto.ReviewResponse, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	review, err := s.reviewRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := decide(review, req.Note); err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewRepo.UpdateStatus(ctx, review); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "review moderated", "review_id", review.ID(), "product_id", review.ProductID(), "status", review.Status())
+
+	return newReviewResponse(review), nil
+}
+
+// verifyPurchase checks that an order was delivered with the product and has not verified a review of it yet
+func (s *ReviewService) verifyPurchase(ctx context.Context, productID, orderID string) error {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if !order.HasProduct(productID) {
+		return errors.New("order does not include this product")
+	}
+	if order.Status() != entity.OrderStatusDelivered {
+		return errors.New("order has not been delivered yet")
+	}
+
+	exists, err := s.reviewRepo.ExistsForOrder(ctx, productID, orderID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("this order was already used to review the product")
+	}
+	return nil
+}
+
+// listReviews validates paging and sorting and fetches a page of reviews
+func (s *ReviewService) listReviews(ctx context.Context, productID string, status entity.ReviewStatus, req *dto.ReviewListRequest) (*dto.ReviewListResponse, error) {
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	if req.Offset < 0 {
+		return nil, errors.New("offset cannot be negative")
+	}
+
+	sort := repository.ReviewSort(req.Sort)
+	switch sort {
+	case "":
+		sort = repository.ReviewSortNewest
+	case repository.ReviewSortNewest, repository.ReviewSortOldest, repository.ReviewSortHighest, repository.ReviewSortLowest:
+	default:
+		return nil, fmt.Errorf("unknown sort %q: expected newest, oldest, highest or lowest", req.Sort)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultReviewLimit
+	}
+	if limit > maxReviewLimit {
+		limit = maxReviewLimit
+	}
+
+	reviews, total, err := s.reviewRepo.Find(ctx, repository.ReviewQuery{
+		ProductID: productID,
+		Status:    status,
+		Sort:      sort,
+		Limit:     limit,
+		Offset:    req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		responses = append(responses, *newReviewResponse(review))
+	}
+
+	return &dto.ReviewListResponse{
+		Total:   total,
+		Limit:   limit,
+		Offset:  req.Offset,
+		Sort:    string(sort),
+		Reviews: responses,
+	}, nil
+}
+
+// newReviewResponse converts a Review entity to ReviewResponse DTO
+func newReviewResponse(review *entity.Review) *dto.ReviewResponse {
+	return &dto.ReviewResponse{
+		ID:               review.ID(),
+		ProductID:        review.ProductID(),
+		Author:           review.Author(),
+		Rating:           review.Rating(),
+		Title:            review.Title(),
+		Body:             review.Body(),
+		VerifiedPurchase: review.VerifiedPurchase(),
+		Status:           string(review.Status()),
+		ModerationNote:   review.ModerationNote(),
+		CreatedAt:        review.CreatedAt(),
+		ModeratedAt:      review.ModeratedAt(),
+	}
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/domain/value"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mock review repository; UpdateStatus recalculates the product rating like the database does
+type mockReviewRepo struct {
+	reviews  map[string]*entity.Review
+	products *mockProductRepo
+}
+
+func newMockReviewRepo(products *mockProductRepo) *mockReviewRepo {
+	return &mockReviewRepo{
+		reviews:  make(map[string]*entity.Review),
+		products: products,
+	}
+}
+
+func (m *mockReviewRepo) Save(ctx context.Context, review *entity.Review) error {
+	m.reviews[review.ID()] = review
+	return nil
+}
+
+func (m *mockReviewRepo) FindByID(ctx context.Context, id string) (*entity.Review, error) {
+	review, ok := m.reviews[id]
+	if !ok {
+		return nil, errors.New("review not found")
+	}
+	return review, nil
+}
+
+func (m *mockReviewRepo) Find(ctx context.Context, query repository.ReviewQuery) ([]*entity.Review, int, error) {
+	matches := make([]*entity.Review, 0)
+	for _, review := range m.reviews {
+		if (query.ProductID == "" || review.ProductID() == query.ProductID) && (query.Status == "" || review.Status() == query.Status) {
+			matches = append(matches, review)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		switch query.Sort {
+		case repository.ReviewSortOldest:
+			return a.CreatedAt().Before(b.CreatedAt())
+		case repository.ReviewSortHighest:
+			return a.Rating() > b.Rating()
+		case repository.ReviewSortLowest:
+			return a.Rating() < b.Rating()
+		default:
+			return a.CreatedAt().After(b.CreatedAt())
+		}
+	})
+
+	total := len(matches)
+	if query.Offset >= total {
+		return []*entity.Review{}, total, nil
+	}
+	end := query.Offset + query.Limit
+	if end > total {
+		end = total
+	}
+	return matches[query.Offset:end], total, nil
+}
+
+func (m *mockReviewRepo) ExistsForOrder(ctx context.Context, productID, orderID string) (bool, error) {
+	for _, review := range m.reviews {
+		if review.ProductID() == productID && review.OrderID() == orderID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockReviewRepo) UpdateStatus(ctx context.Context, review *entity.Review) error {
+	m.reviews[review.ID()] = review
+
+	count, total := 0, 0
+	for _, r := range m.reviews {
+		if r.ProductID() == review.ProductID() && r.IsApproved() {
+			count++
+			total += r.Rating()
+		}
+	}
+	p := m.products.products[review.ProductID()]
+	m.products.products[p.ID()] = entity.ReconstructProduct(p.ID(), p.SKU(), p.Name(), p.Description(), p.Category(),
+		p.Price(), p.Stock(), p.Options(), p.Variants(), p.Images(), "", p.CreatedAt(), p.UpdatedAt(), p.DeletedAt(), count, total,
+		p.LowStockThreshold(), p.ReorderQuantity())
+	return nil
+}
+
+func newReviewFixture(t *testing.T) (*ReviewService, *mockProductRepo, *mockOrderRepo) {
+	t.Helper()
+	products := newMockProductRepo()
+	orders := newMockOrderRepo()
+	return NewReviewService(newMockReviewRepo(products), products, orders), products, orders
+}
+
+// saveOrder stores an order of one unit of the product with the given status
+func saveOrder(t *testing.T, orders *mockOrderRepo, product *entity.Product, status entity.OrderStatus) *entity.Order {
+	t.Helper()
+	qty, _ := value.NewQuantity(1)
+	snapshot, _ := entity.NewProductSnapshot(product, "")
+	item, err := entity.NewOrderItem(product.ID(), qty, product.Price(), snapshot)
+	if err != nil {
+		t.Fatalf("NewOrderItem: %v", err)
+	}
+	now := time.Now()
+	order := entity.ReconstructOrder(uuid.New().String(), []*entity.OrderItem{item}, product.Price(), status, now, now)
+	orders.Save(context.Background(), order)
+	return order
+}
+
+func TestReviewService_CreateReview(t *testing.T) {
+	ctx := context.Background()
+	service, products, _ := newReviewFixture(t)
+	mug := saveProduct(t, products, "Mug", 5)
+	archived := saveProduct(t, products, "Lamp", 5)
+	archived.Archive()
+
+	review, err := service.CreateReview(ctx, mug.ID(), &dto.CreateReviewRequest{Author: "Sam", Rating: 4, Title: "Solid"})
+	if err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+	if review.Status != string(entity.ReviewPending) || review.VerifiedPurchase {
+		t.Errorf("expected an unverified pending review, got %+v", review)
+	}
+
+	if _, err := service.CreateReview(ctx, mug.ID(), &dto.CreateReviewRequest{Author: "Sam", Rating: 6, Title: "Solid"}); err == nil {
+		t.Error("expected error for a rating above 5")
+	}
+	if _, err := service.CreateReview(ctx, archived.ID(), &dto.CreateReviewRequest{Author: "Sam", Rating: 4, Title: "Solid"}); err == nil {
+		t.Error("expected error reviewing an archived product")
+	}
+	if _, err := service.CreateReview(ctx, "missing", &dto.CreateReviewRequest{Author: "Sam", Rating: 4, Title: "Solid"}); err == nil {
+		t.Error("expected error reviewing a missing product")
+	}
+}
+
+func TestReviewService_VerifiedPurchase(t *testing.T) {
+	ctx := context.Background()
+	service, products, orders := newReviewFixture(t)
+	mug := saveProduct(t, products, "Mug", 5)
+	lamp := saveProduct(t, products, "Lamp", 5)
+	delivered := saveOrder(t, orders, mug, entity.OrderStatusDelivered)
+	shipped := saveOrder(t, orders, lamp, entity.OrderStatusShipped)
+
+	review, err := service.CreateReview(ctx, mug.ID(), &dto.CreateReviewRequest{Author: "Sam", Rating: 5, Title: "Great", OrderID: delivered.ID()})
+	if err != nil {
+		t.Fatalf("CreateReview: %v", err)
+	}
+	if !review.VerifiedPurchase {
+		t.Error("expected a verified purchase for a delivered order")
+	}
+
+	tests := []struct {
+		name      string
+		productID string
+		orderID   string
+	}{
+		{"order used twice", mug.ID(), delivered.ID()},
+		{"product not in order", lamp.ID(), delivered.ID()},
+		{"order not delivered", lamp.ID(), shipped.ID()},
+		{"unknown order", mug.ID(), uuid.New().String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &dto.CreateReviewRequest{Author: "Sam", Rating: 5, Title: "Great", OrderID: tt.orderID}
+			if _, err := service.CreateReview(ctx, tt.productID, req); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestReviewService_Moderation(t *testing.T) {
+	ctx := context.Background()
+	service, products, _ := newReviewFixture(t)
+	mug := saveProduct(t, products, "Mug", 5)
+
+	first, _ := service.CreateReview(ctx, mug.ID(), &dto.CreateReviewRequest{Author: "Sam", Rating: 5, Title: "Great"})
+	second, _ := service.CreateReview(ctx, mug.ID(), &dto.CreateReviewRequest{Author: "Alex", Rating: 2, Title: "Chipped"})
+
+	queue, err := service.ListReviews(ctx, &dto.ReviewListRequest{})
+	if err != nil {
+		t.Fatalf("ListReviews: %v", err)
+	}
+	if queue.Total != 2 {
+		t.Errorf("expected 2 pending reviews, got %d", queue.Total)
+	}
+
+	public, _ := service.ListProductReviews(ctx, mug.ID(), &dto.ReviewListRequest{})
+	if public.Total != 0 {
+		t.Errorf("expected pending reviews to be hidden, got %d", public.Total)
+	}
+
+	service.ApproveReview(ctx, first.ID, &dto.ModerateReviewRequest{})
+	service.ApproveReview(ctx, second.ID, &dto.ModerateReviewRequest{})
+	product, _ := products.FindByID(ctx, mug.ID())
+	if product.ReviewCount() != 2 || product.AverageRating() != 3.5 {
+		t.Errorf("expected 2 reviews averaging 3.5, got %d averaging %v", product.ReviewCount(), product.AverageRating())
+	}
+
+	rejected, err := service.RejectReview(ctx, second.ID, &dto.ModerateReviewRequest{Note: "off topic"})
+	if err != nil {
+		t.Fatalf("RejectReview: %v", err)
+	}
+	if rejected.Status != string(entity.ReviewRejected) || rejected.ModerationNote != "off topic" {
+		t.Errorf("expected a rejected review with a note, got %+v", rejected)
+	}
+	product, _ = products.FindByID(ctx, mug.ID())
+	if product.ReviewCount() != 1 || product.AverageRating() != 5 {
+		t.Errorf("expected the rejected rating to be removed, got %d averaging %v", product.ReviewCount(), product.AverageRating())
+	}
+
+	if _, err := service.RejectReview(ctx, second.ID, &dto.ModerateReviewRequest{}); err == nil {
+		t.Error("expected error rejecting a rejected review")
+	}
+	if _, err := service.ApproveReview(ctx, "missing", &dto.ModerateReviewRequest{}); err == nil {
+		t.Error("expected error moderating a missing review")
+	}
+}
+
+func TestReviewService_ListProductReviews(t *testing.T) {
+	ctx := context.Background()
+	service, products, _ := newReviewFixture(t)
+	mug := saveProduct(t, products, "Mug", 5)
+
+	for _, rating := range []int{3, 5, 1} {
+		review, _ := service.CreateReview(ctx, mug.ID(), &dto.CreateReviewRequest{Author: "Sam", Rating: rating, Title: "Review"})
+		service.ApproveReview(ctx, review.ID, &dto.ModerateReviewRequest{})
+	}
+
+	page, err := service.ListProductReviews(ctx, mug.ID(), &dto.ReviewListRequest{Sort: "highest", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListProductReviews: %v", err)
+	}
+	if page.Total != 3 || len(page.Reviews) != 2 {
+		t.Fatalf("expected 2 of 3 reviews, got %d of %d", len(page.Reviews), page.Total)
+	}
+	if page.Reviews[0].Rating != 5 || page.Reviews[1].Rating != 3 {
+		t.Errorf("expected highest ratings first, got %d, %d", page.Reviews[0].Rating, page.Reviews[1].Rating)
+	}
+
+	page, _ = service.ListProductReviews(ctx, mug.ID(), &dto.ReviewListRequest{Sort: "highest", Limit: 2, Offset: 2})
+	if len(page.Reviews) != 1 || page.Reviews[0].Rating != 1 {
+		t.Errorf("expected the lowest rating on the second page, got %+v", page.Reviews)
+	}
+
+	defaults, _ := service.ListProductReviews(ctx, mug.ID(), &dto.ReviewListRequest{Limit: 500})
+	if defaults.Sort != "newest" || defaults.Limit != maxReviewLimit {
+		t.Errorf("expected newest first capped at %d, got %s and %d", maxReviewLimit, defaults.Sort, defaults.Limit)
+	}
+
+	if _, err := service.ListProductReviews(ctx, mug.ID(), &dto.ReviewListRequest{Sort: "random"}); err == nil {
+		t.Error("expected error for an unknown sort")
+	}
+	if _, err := service.ListProductReviews(ctx, mug.ID(), &dto.ReviewListRequest{Offset: -1}); err == nil {
+		t.Error("expected error for a negative offset")
+	}
+	if _, err := service.ListReviews(ctx, &dto.ReviewListRequest{Status: "hidden"}); err == nil {
+		t.Error("expected error for an unknown status")
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/repository"
+	"ecom-backend/pkg/tracing"
+	"errors"
+)
+
+// Search pagination limits
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchService handles product search
+type SearchService struct {
+	searchRepo repository.ProductSearchRepository
+}
+
+// NewSearchService creates a new SearchService
+func NewSearchService(searchRepo repository.ProductSearchRepository) *SearchService {
+	return &SearchService{
+		searchRepo: searchRepo,
+	}
+}
+
+// SearchProducts runs a ranked full-text search with facet counts
+func (s *SearchService) SearchProducts(ctx context.Context, req *dto.ProductSearchRequest) (*dto.ProductSearchResponse, error) {
+	ctx, span := tracing.Start(ctx, "SearchService.SearchProducts")
+	defer span.End()
+
+	// Validate request
+	if req.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+	if req.Offset < 0 {
+		return nil, errors.New("offset cannot be negative")
+	}
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		return nil, errors.New("min_price cannot be greater than max_price")
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	result, err := s.searchRepo.Search(ctx, &repository.ProductSearchQuery{
+		Text:     req.Query,
+		Category: req.Category,
+		MinPrice: req.MinPrice,
+		MaxPrice: req.MaxPrice,
+		InStock:  req.InStock,
+		Limit:    limit,
+		Offset:   req.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.ProductSearchHitResponse, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		results = append(results, dto.ProductSearchHitResponse{
+			Product: newProductResponse(hit.Product),
+			Rank:    hit.Rank,
+			Highlights: dto.SearchHighlightResponse{
+				Name:        hit.NameSnippet,
+				Description: hit.DescriptionSnippet,
+			},
+		})
+	}
+
+	return &dto.ProductSearchResponse{
+		Query:   req.Query,
+		Total:   result.Total,
+		Limit:   limit,
+		Offset:  req.Offset,
+		Results: results,
+		Facets: dto.SearchFacetsResponse{
+			Categories:   toFacetCountResponses(result.Facets.Categories),
+			PriceBands:   toFacetCountResponses(result.Facets.PriceBands),
+			Availability: toFacetCountResponses(result.Facets.Availability),
+		},
+	}, nil
+}
+
+// toFacetCountResponses converts facet counts to DTOs
+func toFacetCountResponses(counts []repository.FacetCount) []dto.FacetCountResponse {
+	responses := make([]dto.FacetCountResponse, 0, len(counts))
+	for _, c := range counts {
+		responses = append(responses, dto.FacetCountResponse{Value: c.Value, Count: c.Count})
+	}
+	return responses
+}
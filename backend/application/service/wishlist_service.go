@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/pkg/tracing"
+	"ecom-backend/pkg/validate"
+	"errors"
+)
+
+// WishlistService handles wishlist-related business logic
+type WishlistService struct {
+	wishlistRepo repository.WishlistRepository
+	productRepo  repository.ProductRepository
+	baskets      *BasketService
+}
+
+// NewWishlistService creates a new WishlistService. Items move to baskets through baskets,
+// so they are validated like any other basket addition.
+func NewWishlistService(wishlistRepo repository.WishlistRepository, productRepo repository.ProductRepository, baskets *BasketService) *WishlistService {
+	return &WishlistService{
+		wishlistRepo: wishlistRepo,
+		productRepo:  productRepo,
+		baskets:      baskets,
+	}
+}
+
+// CreateWishlist creates a wishlist with an empty default list
+func (s *WishlistService) CreateWishlist(ctx context.Context) (*dto.WishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.CreateWishlist")
+	defer span.End()
+
+	wishlist := entity.NewWishlist()
+
+	if err := s.wishlistRepo.Save(ctx, wishlist); err != nil {
+		return nil, err
+	}
+
+	return s.toWishlistResponse(ctx, wishlist)
+}
+
+// GetWishlist retrieves a wishlist by ID
+func (s *WishlistService) GetWishlist(ctx context.Context, id string) (*dto.WishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.GetWishlist")
+	defer span.End()
+
+	wishlist, err := s.wishlistRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toWishlistResponse(ctx, wishlist)
+}
+
+// CreateList adds a named list to a wishlist
+func (s *WishlistService) CreateList(ctx context.Context, wishlistID string, req *dto.WishlistListRequest) (*dto.WishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.CreateList")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	return s.change(ctx, wishlistID, func(wishlist *entity.Wishlist) error {
+		_, err := wishlist.AddList(req.Name)
+		return err
+	})
+}
+
+// RenameList changes the name of a wishlist list
+func (s *WishlistService) RenameList(ctx context.Context, wishlistID, listID string, req *dto.WishlistListRequest) (*dto.WishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.RenameList")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	return s.change(ctx, wishlistID, func(wishlist *entity.Wishlist) error {
+		return wishlist.RenameList(listID, req.Name)
+	})
+}
+
+// DeleteList removes a list and its items from a wishlist
+func (s *WishlistService) DeleteList(ctx context.Context, wishlistID, listID string) (*dto.WishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.DeleteList")
+	defer span.End()
+
+	return s.change(ctx, wishlistID, func(wishlist *entity.Wishlist) error {
+		return wishlist.RemoveList(listID)
+	})
+}
+
+// AddItem saves a product or variant to a wishlist list. Out-of-stock items can be saved,
+// typically with a back-in-stock alert.
+func (s *WishlistService) AddItem(ctx context.Context, wishlistID, listID string, req *dto.AddWishlistItemRequest) (*dto.WishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.AddItem")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.FindByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.IsArchived() {
+		return nil, errors.New("product is no longer available")
+	}
+	// Resolving the stock checks the variant belongs to the product, and is given when it has variants
+	if _, err := product.StockFor(req.VariantID); err != nil {
+		return nil, err
+	}
+
+	return s.change(ctx, wishlistID, func(wishlist *entity.Wishlist) error {
+		return wishlist.AddItem(listID, product.ID(), req.VariantID, req.NotifyBackInStock)
+	})
+}
+
+// UpdateItem turns the back-in-stock alert of a saved item on or off
+func (s *WishlistService) UpdateItem(ctx context.Context, wishlistID, listID, productID, variantID string, req *dto.UpdateWishlistItemRequest) (*dto.WishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.UpdateItem")
+	defer span.End()
+
+	return s.change(ctx, wishlistID, func(wishlist *entity.Wishlist) error {
+		return wishlist.SetBackInStockAlert(listID, productID, variantID, req.NotifyBackInStock)
+	})
+}
+
+// RemoveItem removes a saved item from a wishlist list
+func (s *WishlistService) RemoveItem(ctx context.Context, wishlistID, listID, productID, variantID string) (*dto.WishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.RemoveItem")
+	defer span.End()
+
+	return s.change(ctx, wishlistID, func(wishlist *entity.Wishlist) error {
+		return wishlist.RemoveItem(listID, productID, variantID)
+	})
+}
+
+// MoveToBasket adds a saved item to a basket and removes it from the list. The basket
+// addition is checked exactly like BasketService.AddItem; when it fails the item stays saved.
+func (s *WishlistService) MoveToBasket(ctx context.Context, wishlistID, listID string, req *dto.MoveToBasketRequest) (*dto.WishlistMoveResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.MoveToBasket")
+	defer span.End()
+
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	wishlist, err := s.wishlistRepo.FindByID(ctx, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := wishlist.List(listID)
+	if err != nil {
+		return nil, err
+	}
+	if !list.Contains(req.ProductID, req.VariantID) {
+		return nil, errors.New("item not found in wishlist")
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	basket, err := s.baskets.AddItem(ctx, req.BasketID, &dto.AddItemRequest{
+		ProductID: req.ProductID,
+		VariantID: req.VariantID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wishlist.RemoveItem(listID, req.ProductID, req.VariantID); err != nil {
+		return nil, err
+	}
+	if err := s.wishlistRepo.Update(ctx, wishlist); err != nil {
+		return nil, err
+	}
+
+	response, err := s.toWishlistResponse(ctx, wishlist)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.WishlistMoveResponse{Wishlist: response, Basket: basket}, nil
+}
+
+// ShareList makes a wishlist list viewable by a public token
+func (s *WishlistService) ShareList(ctx context.Context, wishlistID, listID string) (*dto.WishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.ShareList")
+	defer span.End()
+
+	return s.change(ctx, wishlistID, func(wishlist *entity.Wishlist) error {
+		_, err := wishlist.ShareList(listID)
+		return err
+	})
+}
+
+// UnshareList makes a wishlist list private, invalidating its share token
+func (s *WishlistService) UnshareList(ctx context.Context, wishlistID, listID string) (*dto.WishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.UnshareList")
+	defer span.End()
+
+	return s.change(ctx, wishlistID, func(wishlist *entity.Wishlist) error {
+		return wishlist.UnshareList(listID)
+	})
+}
+
+// GetSharedList retrieves the public view of the list shared with token
+func (s *WishlistService) GetSharedList(ctx context.Context, token string) (*dto.SharedWishlistResponse, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.GetSharedList")
+	defer span.End()
+
+	wishlist, err := s.wishlistRepo.FindByShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	list, err := wishlist.ListByShareToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := findWishlistProducts(ctx, s.productRepo, list)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.WishlistItemResponse, 0, len(list.Items()))
+	for _, item := range list.Items() {
+		response := newWishlistItemResponse(item, products)
+		// Alerts belong to the owner
+		response.NotifyBackInStock = false
+		items = append(items, response)
+	}
+
+	return &dto.SharedWishlistResponse{
+		Name:      list.Name(),
+		Items:     items,
+		UpdatedAt: wishlist.UpdatedAt(),
+	}, nil
+}
+
+// change loads a wishlist, applies a change and saves it
+func (s *WishlistService) change(ctx context.Context, wishlistID string, apply func(*entity.Wishlist) error) (*dto.WishlistResponse, error) {
+	wishlist, err := s.wishlistRepo.FindByID(ctx, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	if err := apply(wishlist); err != nil {
+		return nil, err
+	}
+
+	if err := s.wishlistRepo.Update(ctx, wishlist); err != nil {
+		return nil, err
+	}
+
+	return s.toWishlistResponse(ctx, wishlist)
+}
+
+// toWishlistResponse converts a Wishlist entity to WishlistResponse DTO with the current
+// details of every saved product
+func (s *WishlistService) toWishlistResponse(ctx context.Context, wishlist *entity.Wishlist) (*dto.WishlistResponse, error) {
+	products, err := findWishlistProducts(ctx, s.productRepo, wishlist.Lists()...)
+	if err != nil {
+		return nil, err
+	}
+
+	lists := make([]dto.WishlistListResponse, 0, len(wishlist.Lists()))
+	for _, list := range wishlist.Lists() {
+		items := make([]dto.WishlistItemResponse, 0, len(list.Items()))
+		for _, item := range list.Items() {
+			items = append(items, newWishlistItemResponse(item, products))
+		}
+
+		lists = append(lists, dto.WishlistListResponse{
+			ID:         list.ID(),
+			Name:       list.Name(),
+			Shared:     list.IsShared(),
+			ShareToken: list.ShareToken(),
+			Items:      items,
+			CreatedAt:  list.CreatedAt(),
+		})
+	}
+
+	return &dto.WishlistResponse{
+		ID:        wishlist.ID(),
+		Lists:     lists,
+		CreatedAt: wishlist.CreatedAt(),
+		UpdatedAt: wishlist.UpdatedAt(),
+	}, nil
+}
+
+// findWishlistProducts loads the products saved in the lists in one lookup, keyed by ID
+func findWishlistProducts(ctx context.Context, repo repository.ProductRepository, lists ...*entity.WishlistList) (map[string]*entity.Product, error) {
+	products := make(map[string]*entity.Product)
+
+	ids := make([]string, 0)
+	for _, list := range lists {
+		for _, item := range list.Items() {
+			ids = append(ids, item.ProductID())
+		}
+	}
+	if len(ids) == 0 {
+		return products, nil
+	}
+
+	found, err := repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range found {
+		products[product.ID()] = product
+	}
+
+	return products, nil
+}
+
+// newWishlistItemResponse converts a saved item with the current details of its product
+func newWishlistItemResponse(item *entity.WishlistItem, products map[string]*entity.Product) dto.WishlistItemResponse {
+	response := dto.WishlistItemResponse{
+		ProductID:         item.ProductID(),
+		VariantID:         item.VariantID(),
+		NotifyBackInStock: item.NotifyBackInStock(),
+		AddedAt:           item.AddedAt(),
+	}
+	if product, ok := products[item.ProductID()]; ok {
+		response.Product = newBasketProductResponse(product, item.VariantID())
+	}
+	if current := response.Product; current != nil {
+		response.InStock = !current.Archived && current.Stock > 0
+	}
+	return response
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"ecom-backend/application/dto"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/domain/value"
+	"ecom-backend/pkg/alert"
+	"errors"
+	"testing"
+)
+
+// Mock wishlist repository keeping wishlists in memory
+type mockWishlistRepo struct {
+	wishlists map[string]*entity.Wishlist
+	queued    []repository.BackInStockAlert
+	nextID    int64
+}
+
+func newMockWishlistRepo() *mockWishlistRepo {
+	return &mockWishlistRepo{
+		wishlists: make(map[string]*entity.Wishlist),
+	}
+}
+
+func (m *mockWishlistRepo) Save(ctx context.Context, wishlist *entity.Wishlist) error {
+	m.wishlists[wishlist.ID()] = wishlist
+	return nil
+}
+
+func (m *mockWishlistRepo) FindByID(ctx context.Context, id string) (*entity.Wishlist, error) {
+	wishlist, ok := m.wishlists[id]
+	if !ok {
+		return nil, errors.New("wishlist not found")
+	}
+	return wishlist, nil
+}
+
+func (m *mockWishlistRepo) FindByShareToken(ctx context.Context, token string) (*entity.Wishlist, error) {
+	for _, wishlist := range m.wishlists {
+		if _, err := wishlist.ListByShareToken(token); err == nil {
+			return wishlist, nil
+		}
+	}
+	return nil, errors.New("wishlist not found")
+}
+
+func (m *mockWishlistRepo) Update(ctx context.Context, wishlist *entity.Wishlist) error {
+	m.wishlists[wishlist.ID()] = wishlist
+	return nil
+}
+
+func (m *mockWishlistRepo) QueueBackInStockAlerts(ctx context.Context, productID, variantID string, stock int) (int, error) {
+	queued := 0
+	for _, wishlist := range m.wishlists {
+		for _, list := range wishlist.Lists() {
+			for _, item := range list.Items() {
+				if item.ProductID() == productID && item.VariantID() == variantID && item.NotifyBackInStock() {
+					wishlist.SetBackInStockAlert(list.ID(), productID, variantID, false)
+					m.nextID++
+					m.queued = append(m.queued, repository.BackInStockAlert{
+						ID: m.nextID, WishlistID: wishlist.ID(), ListID: list.ID(),
+						ProductID: productID, VariantID: variantID, Stock: stock,
+					})
+					queued++
+				}
+			}
+		}
+	}
+	return queued, nil
+}
+
+func (m *mockWishlistRepo) ProcessBackInStockAlerts(ctx context.Context, limit int, deliver func(notices []repository.BackInStockAlert) (done, failed []int64)) error {
+	if len(m.queued) == 0 {
+		return nil
+	}
+	if len(m.queued) < limit {
+		limit = len(m.queued)
+	}
+	done, failed := deliver(append([]repository.BackInStockAlert(nil), m.queued[:limit]...))
+
+	kept := m.queued[:0]
+	for _, notice := range m.queued {
+		if containsID(done, notice.ID) {
+			continue
+		}
+		if containsID(failed, notice.ID) {
+			notice.Attempts++
+		}
+		kept = append(kept, notice)
+	}
+	m.queued = kept
+	return nil
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func newWishlistFixture(t *testing.T) (*WishlistService, *BasketService, *mockProductRepo) {
+	t.Helper()
+	products := newMockProductRepo()
+	baskets := NewBasketService(newMockBasketRepo(), products, NopMetrics{}, NopEvents{})
+	return NewWishlistService(newMockWishlistRepo(), products, baskets), baskets, products
+}
+
+func saveProduct(t *testing.T, products *mockProductRepo, name string, stock int) *entity.Product {
+	t.Helper()
+	price, _ := value.NewMoney(1000, "USD")
+	qty, _ := value.NewQuantity(stock)
+	product, err := entity.NewProduct(name, "", price, qty)
+	if err != nil {
+		t.Fatalf("NewProduct: %v", err)
+	}
+	products.Save(context.Background(), product)
+	return product
+}
+
+func TestWishlistService_AddItem(t *testing.T) {
+	ctx := context.Background()
+	service, _, products := newWishlistFixture(t)
+	mug := saveProduct(t, products, "Mug", 0)
+	archived := saveProduct(t, products, "Lamp", 5)
+	archived.Archive()
+
+	wishlist, _ := service.CreateWishlist(ctx)
+	listID := wishlist.Lists[0].ID
+
+	updated, err := service.AddItem(ctx, wishlist.ID, listID, &dto.AddWishlistItemRequest{ProductID: mug.ID(), NotifyBackInStock: true})
+	if err != nil {
+		t.Fatalf("AddItem: %v", err)
+	}
+	item := updated.Lists[0].Items[0]
+	if item.Product == nil || item.Product.Name != "Mug" {
+		t.Errorf("expected current product details, got %+v", item.Product)
+	}
+	if item.InStock || !item.NotifyBackInStock {
+		t.Errorf("expected an out-of-stock item with an alert, got %+v", item)
+	}
+
+	if _, err := service.AddItem(ctx, wishlist.ID, listID, &dto.AddWishlistItemRequest{ProductID: archived.ID()}); err == nil {
+		t.Error("expected error saving an archived product")
+	}
+	if _, err := service.AddItem(ctx, wishlist.ID, "missing", &dto.AddWishlistItemRequest{ProductID: mug.ID()}); err == nil {
+		t.Error("expected error for an unknown list")
+	}
+}
+
+func TestWishlistService_MoveToBasket(t *testing.T) {
+	ctx := context.Background()
+	service, baskets, products := newWishlistFixture(t)
+	mug := saveProduct(t, products, "Mug", 1)
+
+	wishlist, _ := service.CreateWishlist(ctx)
+	listID := wishlist.Lists[0].ID
+	service.AddItem(ctx, wishlist.ID, listID, &dto.AddWishlistItemRequest{ProductID: mug.ID()})
+	basket, _ := baskets.CreateBasket(ctx)
+
+	// Basket validation applies, and a failed move keeps the item saved
+	_, err := service.MoveToBasket(ctx, wishlist.ID, listID, &dto.MoveToBasketRequest{BasketID: basket.ID, ProductID: mug.ID(), Quantity: 2})
+	if err == nil || err.Error() != "insufficient stock" {
+		t.Fatalf("expected insufficient stock, got %v", err)
+	}
+	current, _ := service.GetWishlist(ctx, wishlist.ID)
+	if len(current.Lists[0].Items) != 1 {
+		t.Fatal("expected the item to stay saved after a failed move")
+	}
+
+	moved, err := service.MoveToBasket(ctx, wishlist.ID, listID, &dto.MoveToBasketRequest{BasketID: basket.ID, ProductID: mug.ID()})
+	if err != nil {
+		t.Fatalf("MoveToBasket: %v", err)
+	}
+	if len(moved.Wishlist.Lists[0].Items) != 0 {
+		t.Error("expected the item to leave the list")
+	}
+	if len(moved.Basket.Items) != 1 || moved.Basket.Items[0].Quantity != 1 {
+		t.Errorf("expected one mug in the basket, got %+v", moved.Basket.Items)
+	}
+}
+
+func TestWishlistService_SharedList(t *testing.T) {
+	ctx := context.Background()
+	service, _, products := newWishlistFixture(t)
+	mug := saveProduct(t, products, "Mug", 3)
+
+	wishlist, _ := service.CreateWishlist(ctx)
+	listID := wishlist.Lists[0].ID
+	service.AddItem(ctx, wishlist.ID, listID, &dto.AddWishlistItemRequest{ProductID: mug.ID(), NotifyBackInStock: true})
+
+	shared, err := service.ShareList(ctx, wishlist.ID, listID)
+	if err != nil {
+		t.Fatalf("ShareList: %v", err)
+	}
+	token := shared.Lists[0].ShareToken
+	if !shared.Lists[0].Shared || token == "" {
+		t.Fatal("expected a share token")
+	}
+
+	view, err := service.GetSharedList(ctx, token)
+	if err != nil {
+		t.Fatalf("GetSharedList: %v", err)
+	}
+	if view.Name != entity.DefaultWishlistListName || len(view.Items) != 1 || !view.Items[0].InStock {
+		t.Errorf("unexpected shared view %+v", view)
+	}
+	if view.Items[0].NotifyBackInStock {
+		t.Error("expected the owner's alert to be hidden")
+	}
+
+	service.UnshareList(ctx, wishlist.ID, listID)
+	if _, err := service.GetSharedList(ctx, token); err == nil {
+		t.Error("expected the token to stop working once unshared")
+	}
+}
+
+func TestProductService_UpdateStockFiresBackInStockAlerts(t *testing.T) {
+	ctx := context.Background()
+	products := newMockProductRepo()
+	wishlists := newMockWishlistRepo()
+	published := &recordingEvents{}
+	productService := NewProductService(products, wishlists, published, NopAlerts{})
+	wishlistService := NewWishlistService(wishlists, products, nil)
+
+	mug := saveProduct(t, products, "Mug", 0)
+	wishlist, _ := wishlistService.CreateWishlist(ctx)
+	listID := wishlist.Lists[0].ID
+	wishlistService.AddItem(ctx, wishlist.ID, listID, &dto.AddWishlistItemRequest{ProductID: mug.ID(), NotifyBackInStock: true})
+
+	alerts := func() []dto.BackInStockEvent {
+		if _, err := productService.DeliverBackInStockAlerts(ctx, 10); err != nil {
+			t.Fatalf("DeliverBackInStockAlerts: %v", err)
+		}
+		var found []dto.BackInStockEvent
+		for _, e := range published.events {
+			if e.Type == EventBackInStock {
+				found = append(found, e.Data.(dto.BackInStockEvent))
+			}
+		}
+		return found
+	}
+
+	// Out of stock to out of stock does not fire
+	if _, err := productService.UpdateStock(ctx, mug.ID(), &dto.UpdateStockRequest{Stock: 0}); err != nil {
+		t.Fatalf("UpdateStock: %v", err)
+	}
+	if len(alerts()) != 0 {
+		t.Fatal("expected no alert while out of stock")
+	}
+
+	productService.UpdateStock(ctx, mug.ID(), &dto.UpdateStockRequest{Stock: 4})
+	fired := alerts()
+	if len(fired) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(fired))
+	}
+	if fired[0].WishlistID != wishlist.ID || fired[0].ListID != listID || fired[0].Stock != 4 || fired[0].Name != "Mug" {
+		t.Errorf("unexpected alert %+v", fired[0])
+	}
+
+	// Alerts fire once; selling out and restocking again stays quiet
+	productService.UpdateStock(ctx, mug.ID(), &dto.UpdateStockRequest{Stock: 0})
+	productService.UpdateStock(ctx, mug.ID(), &dto.UpdateStockRequest{Stock: 2})
+	if len(alerts()) != 1 {
+		t.Errorf("expected the alert to fire only once, got %d", len(alerts()))
+	}
+	current, _ := wishlistService.GetWishlist(ctx, wishlist.ID)
+	if current.Lists[0].Items[0].NotifyBackInStock {
+		t.Error("expected the alert to be turned off once fired")
+	}
+}
+
+// failingAlerts refuses every alert, like notifiers that cannot be reached
+type failingAlerts struct{}
+
+func (failingAlerts) Notify(ctx context.Context, a alert.Alert) error { return alert.ErrQueueFull }
+func (failingAlerts) Deliver(ctx context.Context, a alert.Alert) error {
+	return errors.New("webhook: unreachable")
+}
+
+func TestProductService_BackInStockAlertsStayQueuedUntilDelivered(t *testing.T) {
+	ctx := context.Background()
+	products := newMockProductRepo()
+	wishlists := newMockWishlistRepo()
+	published := &recordingEvents{}
+	wishlistService := NewWishlistService(wishlists, products, nil)
+
+	mug := saveProduct(t, products, "Mug", 0)
+	wishlist, _ := wishlistService.CreateWishlist(ctx)
+	wishlistService.AddItem(ctx, wishlist.ID, wishlist.Lists[0].ID, &dto.AddWishlistItemRequest{ProductID: mug.ID(), NotifyBackInStock: true})
+
+	// The restock queues the notice, but the notifiers cannot be reached
+	failing := NewProductService(products, wishlists, published, failingAlerts{})
+	if _, err := failing.UpdateStock(ctx, mug.ID(), &dto.UpdateStockRequest{Stock: 3}); err != nil {
+		t.Fatalf("UpdateStock: %v", err)
+	}
+	if delivered, err := failing.DeliverBackInStockAlerts(ctx, 10); err == nil || delivered != 0 {
+		t.Fatalf("expected the delivery to fail, got %d delivered and %v", delivered, err)
+	}
+	for _, e := range published.events {
+		if e.Type == EventBackInStock {
+			t.Fatal("expected no back-in-stock event for an undelivered notice")
+		}
+	}
+	if len(wishlists.queued) != 1 || wishlists.queued[0].Attempts != 1 {
+		t.Fatalf("expected the undelivered notice to stay queued with its attempt counted, got %+v", wishlists.queued)
+	}
+
+	// A later run delivers it through the alert notifiers and the event stream
+	raised := &recordingAlerts{}
+	delivering := NewProductService(products, wishlists, published, raised)
+	delivered, err := delivering.DeliverBackInStockAlerts(ctx, 10)
+	if err != nil {
+		t.Fatalf("DeliverBackInStockAlerts: %v", err)
+	}
+	if delivered != 1 || len(wishlists.queued) != 0 {
+		t.Fatalf("expected the notice delivered and dequeued, got %d delivered and %d queued", delivered, len(wishlists.queued))
+	}
+	if len(raised.alerts) != 1 || raised.alerts[0].Type != AlertBackInStock {
+		t.Errorf("expected a back-in-stock alert, got %+v", raised.alerts)
+	}
+	if data := raised.alerts[0].Data.(dto.BackInStockEvent); data.WishlistID != wishlist.ID || data.Stock != 3 {
+		t.Errorf("unexpected alert data %+v", data)
+	}
+
+	// Notices for archived products are dropped
+	wishlistService.AddItem(ctx, wishlist.ID, wishlist.Lists[0].ID, &dto.AddWishlistItemRequest{ProductID: mug.ID(), NotifyBackInStock: true})
+	wishlists.QueueBackInStockAlerts(ctx, mug.ID(), "", 5)
+	mug.Archive()
+	if delivered, _ := delivering.DeliverBackInStockAlerts(ctx, 10); delivered != 0 || len(wishlists.queued) != 0 {
+		t.Errorf("expected the archived product's notice dropped, got %d delivered and %d queued", delivered, len(wishlists.queued))
+	}
+}
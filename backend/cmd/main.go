@@ -1,85 +1,280 @@
 package main
 
 import (
+	"context"
+	"ecom-backend/api/graphql"
+	grpcapi "ecom-backend/api/grpc"
 	"ecom-backend/api/handler"
 	"ecom-backend/api/router"
 	"ecom-backend/application/service"
+	"ecom-backend/infrastructure/config"
 	"ecom-backend/infrastructure/database"
+	"ecom-backend/infrastructure/health"
+	"ecom-backend/infrastructure/logging"
+	"ecom-backend/infrastructure/media"
+	"ecom-backend/infrastructure/metrics"
 	"ecom-backend/infrastructure/persistence"
-	"log"
-	"net/http"
+	"ecom-backend/infrastructure/server"
+	"ecom-backend/infrastructure/storage"
+	"ecom-backend/pkg/alert"
+	"ecom-backend/pkg/events"
+	"ecom-backend/pkg/ratelimit"
+	"ecom-backend/pkg/scheduler"
+	"ecom-backend/pkg/tracing"
+	"fmt"
+	"log/slog"
+	"net"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 )
 
 func main() {
-	// Load configuration from environment variables
-	cfg := &database.Config{
-		Host:     getEnv("DB_HOST", "localhost"),
-		Port:     getEnvAsInt("DB_PORT", 5432),
-		User:     getEnv("DB_USER", "postgres"),
-		Password: getEnv("DB_PASSWORD", "postgres"),
-		DBName:   getEnv("DB_NAME", "ecom"),
-		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+	// Load and validate configuration from file, environment and flags
+	cfg, opts, err := config.Load(os.Args[1:], os.LookupEnv)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if opts.PrintConfig {
+		if err := cfg.Write(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Configure structured logging
+	logger, err := logging.New(os.Stdout, cfg.Log.Format, cfg.Log.Level)
+	if err != nil {
+		fatal("Invalid logging configuration", err)
+	}
+	slog.SetDefault(logger)
+
+	if opts.File != "" {
+		slog.Info("Configuration loaded", "file", opts.File)
+	}
+
+	// Configure tracing
+	tracer, err := newTracer(cfg.Tracing.Exporter, cfg.Tracing.File)
+	if err != nil {
+		fatal("Invalid tracing configuration", err)
 	}
+	tracing.SetDefault(tracer)
 
 	// Initialize database connection
-	db, err := database.NewPostgresDB(cfg)
+	db, err := database.NewPostgresDB(&database.Config{
+		Host:            cfg.Database.Host,
+		Port:            cfg.Database.Port,
+		User:            cfg.Database.User,
+		Password:        cfg.Database.Password,
+		DBName:          cfg.Database.Name,
+		SSLMode:         cfg.Database.SSLMode,
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.Database.ConnMaxIdleTime,
+	})
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("Failed to connect to database", err)
 	}
-	defer db.Close()
 
-	log.Println("Database connection established")
+	slog.Info("Database connection established", "host", cfg.Database.Host, "database", cfg.Database.Name)
 
 	// Run migrations
 	if err := database.RunMigrations(db); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+		fatal("Failed to run migrations", err)
 	}
 
-	log.Println("Database migrations completed")
+	slog.Info("Database migrations completed")
+
+	// Initialize metrics
+	appMetrics := metrics.New()
+	appMetrics.RegisterDB(db, cfg.Database.Name)
+
+	// Health checks
+	checks := health.NewRegistry(cfg.Health.CheckTimeout)
+	checks.AddReadiness("database", db.PingContext)
+	checks.AddReadiness("migrations", func(ctx context.Context) error {
+		return database.CheckMigrations(ctx, db)
+	})
 
 	// Initialize repositories (Infrastructure layer)
 	productRepo := persistence.NewProductRepository(db)
 	basketRepo := persistence.NewBasketRepository(db)
 	orderRepo := persistence.NewOrderRepository(db)
+	wishlistRepo := persistence.NewWishlistRepository(db)
+	reviewRepo := persistence.NewReviewRepository(db)
+	searchRepo := persistence.NewProductSearchRepository(db)
+
+	// Initialize media storage and image processing
+	thumbnailSizes, err := media.ParseThumbnailSizes(cfg.Media.ThumbnailSizes)
+	if err != nil {
+		fatal("Invalid media.thumbnail_sizes", err)
+	}
+
+	blobStore, err := storage.NewLocalBlobStore(cfg.Media.StorageDir, cfg.Media.BaseURL)
+	if err != nil {
+		fatal("Failed to initialize media storage", err)
+	}
+
+	imageProcessor := media.NewProcessor(thumbnailSizes, cfg.Media.MaxPixels)
+
+	// In-process broker for order, stock, basket and wishlist events
+	broker := events.NewBroker(cfg.Events.ReplayBuffer)
+
+	// Low-stock and back-in-stock alerts are queued and delivered in the background by each configured notifier
+	alerts := newAlertDispatcher(cfg.Alerts)
 
 	// Initialize services (Application layer)
-	productService := service.NewProductService(productRepo)
-	basketService := service.NewBasketService(basketRepo, productRepo)
-	orderService := service.NewOrderService(orderRepo, basketRepo, productRepo)
+	productService := service.NewProductService(productRepo, wishlistRepo, broker, alerts)
+	basketService := service.NewBasketService(basketRepo, productRepo, appMetrics, broker)
+	orderService := service.NewOrderService(orderRepo, basketRepo, productRepo, appMetrics, broker, alerts)
+	wishlistService := service.NewWishlistService(wishlistRepo, productRepo, basketService)
+	reviewService := service.NewReviewService(reviewRepo, productRepo, orderRepo)
+	searchService := service.NewSearchService(searchRepo)
+	mediaService := service.NewMediaService(productRepo, blobStore, imageProcessor, cfg.Media.MaxUploadBytes)
+
+	// Schedule background jobs; each job's heartbeat fails liveness when it stops running
+	jobs := scheduler.New()
+	heartbeats := make(map[string]*health.Heartbeat)
+	addJob := func(name string, interval time.Duration, job scheduler.Job) {
+		jobs.Add(name, interval, job)
+		heartbeats[name] = checks.Heartbeat("job:"+name, interval+cfg.Workers.HeartbeatTimeout)
+	}
+	if cfg.Workers.Enabled && cfg.Workers.BasketExpiry.Enabled {
+		expiry := cfg.Workers.BasketExpiry
+		addJob("basket_expiry", expiry.Interval, func(ctx context.Context) (int, error) {
+			return basketService.ExpireIdleBaskets(ctx, expiry.TTL, expiry.BatchSize)
+		})
+	}
+	if cfg.Workers.Enabled && cfg.Workers.BackInStock.Enabled {
+		backInStock := cfg.Workers.BackInStock
+		addJob("back_in_stock", backInStock.Interval, func(ctx context.Context) (int, error) {
+			return productService.DeliverBackInStockAlerts(ctx, backInStock.BatchSize)
+		})
+	}
+	jobs.Observe(func(r scheduler.Result) {
+		appMetrics.JobRun(r.Job, r.Duration, r.Processed, r.Err)
+		heartbeats[r.Job].Beat()
+	})
 
 	// Initialize handlers (API layer)
 	productHandler := handler.NewProductHandler(productService)
 	basketHandler := handler.NewBasketHandler(basketService)
 	orderHandler := handler.NewOrderHandler(orderService)
+	wishlistHandler := handler.NewWishlistHandler(wishlistService)
+	reviewHandler := handler.NewReviewHandler(reviewService)
+	searchHandler := handler.NewSearchHandler(searchService)
+	mediaHandler := handler.NewMediaHandler(mediaService)
+	eventsHandler := handler.NewEventsHandler(broker, cfg.Events.HeartbeatInterval)
+	jobsHandler := handler.NewJobsHandler(jobs)
+
+	graphQLHandler, err := graphql.NewHandler(productService, basketService, orderService, searchService, graphql.Limits{
+		MaxDepth:      cfg.GraphQL.MaxDepth,
+		MaxComplexity: cfg.GraphQL.MaxComplexity,
+	})
+	if err != nil {
+		fatal("Failed to build GraphQL schema", err)
+	}
 
 	// Setup router
-	r := router.Setup(productHandler, basketHandler, orderHandler)
+	r := router.Setup(productHandler, basketHandler, orderHandler, wishlistHandler, reviewHandler, searchHandler, mediaHandler, eventsHandler, jobsHandler, graphQLHandler, appMetrics, checks, cfg, ratelimit.NewMemoryStore())
 
-	// Start server
-	port := getEnv("PORT", "8080")
-	addr := ":" + port
+	// Configure the HTTP server
+	serverCfg := server.Config{
+		Addr:              ":" + strconv.Itoa(cfg.Server.Port),
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
+		ShutdownTimeout:   cfg.Server.ShutdownTimeout,
+		DrainDelay:        cfg.Server.DrainDelay,
+	}
+
+	srv := server.New(serverCfg, r)
+	checks.AddReadiness("shutdown", srv.ReadinessCheck)
+	srv.RegisterOnShutdown(broker.Close)
+	srv.OnShutdown("tracing", tracer.Shutdown)
+	srv.OnShutdown("database", func(ctx context.Context) error {
+		return db.Close()
+	})
 
-	log.Printf("Server starting on %s", addr)
-	if err := http.ListenAndServe(addr, r); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	if cfg.Workers.Enabled {
+		srv.AddWorker("scheduler", jobs.Run)
 	}
+	srv.AddWorker("alerts", alerts.Run)
+
+	// Serve gRPC on its own port alongside HTTP
+	if cfg.GRPC.Enabled {
+		grpcServer := grpcapi.NewServer(productService, basketService, orderService, grpcapi.Options{
+			APIKeys:       cfg.Auth.APIKeys,
+			APIKeyHeader:  cfg.Auth.APIKeyHeader,
+			RequireAPIKey: cfg.Auth.RequireAPIKey,
+		})
+		grpcListener, err := net.Listen("tcp", ":"+strconv.Itoa(cfg.GRPC.Port))
+		if err != nil {
+			fatal("Failed to listen for gRPC", err)
+		}
+		srv.AddWorker("grpc", func(ctx context.Context) {
+			grpcapi.Serve(ctx, grpcServer, grpcListener, server.DrainContext(ctx))
+		})
+	}
+
+	// Serve until SIGINT or SIGTERM, then drain and shut down
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := srv.Run(ctx); err != nil {
+		fatal("Server failed", err)
+	}
+}
+
+// fatal logs an error and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
 
-// getEnv retrieves an environment variable or returns a default value
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// newTracer creates a tracer for the named exporter: none, stdout or file
+func newTracer(exporter, file string) (*tracing.Tracer, error) {
+	switch exporter {
+	case "none", "":
+		return tracing.NewTracer(nil), nil
+	case "stdout":
+		return tracing.NewTracer(tracing.NewJSONExporter(os.Stdout)), nil
+	case "file":
+		fileExporter, err := tracing.NewFileExporter(file)
+		if err != nil {
+			return nil, err
+		}
+		return tracing.NewTracer(fileExporter), nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q: expected none, stdout or file", exporter)
 	}
-	return defaultValue
 }
 
-// getEnvAsInt retrieves an environment variable as int or returns a default value
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := os.Getenv(key)
-	if value, err := strconv.Atoi(valueStr); err == nil {
-		return value
+// newAlertDispatcher creates a dispatcher that delivers alerts through the configured notifiers
+func newAlertDispatcher(cfg config.AlertsConfig) *alert.Dispatcher {
+	dispatcher := alert.NewDispatcher(cfg.QueueSize, cfg.Timeout)
+	for _, name := range cfg.Notifiers {
+		switch name {
+		case "log":
+			dispatcher.Add(name, alert.NewLogNotifier(slog.Default()))
+		case "webhook":
+			dispatcher.Add(name, alert.NewWebhookNotifier(cfg.Webhook.URL, nil))
+		case "email":
+			dispatcher.Add(name, alert.NewEmailNotifier(alert.EmailConfig{
+				Addr:     cfg.Email.SMTPAddr,
+				Username: cfg.Email.Username,
+				Password: cfg.Email.Password,
+				From:     cfg.Email.From,
+				To:       cfg.Email.To,
+			}))
+		}
 	}
-	return defaultValue
+	return dispatcher
 }
@@ -11,12 +11,19 @@ import (
 // BasketItem represents an item in the basket
 type BasketItem struct {
 	productID string
+	variantID string
 	quantity  *value.Quantity
 	price     *value.Money // price at the time of adding to basket
 }
 
 // NewBasketItem creates a new basket item
 func NewBasketItem(productID string, quantity *value.Quantity, price *value.Money) (*BasketItem, error) {
+	return NewBasketVariantItem(productID, "", quantity, price)
+}
+
+// NewBasketVariantItem creates a new basket item for a product variant.
+// An empty variantID refers to a product without variants.
+func NewBasketVariantItem(productID, variantID string, quantity *value.Quantity, price *value.Money) (*BasketItem, error) {
 	if productID == "" {
 		return nil, errors.New("product ID cannot be empty")
 	}
@@ -29,6 +36,7 @@ func NewBasketItem(productID string, quantity *value.Quantity, price *value.Mone
 
 	return &BasketItem{
 		productID: productID,
+		variantID: variantID,
 		quantity:  quantity,
 		price:     price,
 	}, nil
@@ -39,6 +47,11 @@ func (bi *BasketItem) ProductID() string {
 	return bi.productID
 }
 
+// VariantID returns the variant ID, empty for products without variants
+func (bi *BasketItem) VariantID() string {
+	return bi.variantID
+}
+
 // Quantity returns the quantity
 func (bi *BasketItem) Quantity() *value.Quantity {
 	return bi.quantity
@@ -54,6 +67,11 @@ func (bi *BasketItem) Subtotal() (*value.Money, error) {
 	return bi.price.Multiply(bi.quantity.Value())
 }
 
+// matches checks if the item refers to the given product and variant
+func (bi *BasketItem) matches(productID, variantID string) bool {
+	return bi.productID == productID && bi.variantID == variantID
+}
+
 // Basket represents a shopping basket
 type Basket struct {
 	id        string
@@ -105,15 +123,20 @@ func (b *Basket) UpdatedAt() time.Time {
 
 // AddItem adds an item to the basket or updates quantity if item already exists
 func (b *Basket) AddItem(productID string, quantity *value.Quantity, price *value.Money) error {
+	return b.AddVariantItem(productID, "", quantity, price)
+}
+
+// AddVariantItem adds a product variant to the basket or updates quantity if it already exists
+func (b *Basket) AddVariantItem(productID, variantID string, quantity *value.Quantity, price *value.Money) error {
 	// Check if item already exists
 	for i, item := range b.items {
-		if item.productID == productID {
+		if item.matches(productID, variantID) {
 			// Update quantity
 			newQuantity, err := item.quantity.Add(quantity)
 			if err != nil {
 				return err
 			}
-			newItem, err := NewBasketItem(productID, newQuantity, price)
+			newItem, err := NewBasketVariantItem(productID, variantID, newQuantity, price)
 			if err != nil {
 				return err
 			}
@@ -124,7 +147,7 @@ func (b *Basket) AddItem(productID string, quantity *value.Quantity, price *valu
 	}
 
 	// Add new item
-	item, err := NewBasketItem(productID, quantity, price)
+	item, err := NewBasketVariantItem(productID, variantID, quantity, price)
 	if err != nil {
 		return err
 	}
@@ -135,8 +158,13 @@ func (b *Basket) AddItem(productID string, quantity *value.Quantity, price *valu
 
 // RemoveItem removes an item from the basket
 func (b *Basket) RemoveItem(productID string) error {
+	return b.RemoveVariantItem(productID, "")
+}
+
+// RemoveVariantItem removes a product variant from the basket
+func (b *Basket) RemoveVariantItem(productID, variantID string) error {
 	for i, item := range b.items {
-		if item.productID == productID {
+		if item.matches(productID, variantID) {
 			b.items = append(b.items[:i], b.items[i+1:]...)
 			b.updatedAt = time.Now()
 			return nil
@@ -147,13 +175,35 @@ func (b *Basket) RemoveItem(productID string) error {
 
 // UpdateItemQuantity updates the quantity of an item
 func (b *Basket) UpdateItemQuantity(productID string, quantity *value.Quantity) error {
+	return b.UpdateVariantItemQuantity(productID, "", quantity)
+}
+
+// UpdateVariantItemQuantity updates the quantity of a product variant in the basket
+func (b *Basket) UpdateVariantItemQuantity(productID, variantID string, quantity *value.Quantity) error {
 	if quantity.IsZero() {
-		return b.RemoveItem(productID)
+		return b.RemoveVariantItem(productID, variantID)
 	}
 
 	for i, item := range b.items {
-		if item.productID == productID {
-			newItem, err := NewBasketItem(productID, quantity, item.price)
+		if item.matches(productID, variantID) {
+			newItem, err := NewBasketVariantItem(productID, variantID, quantity, item.price)
+			if err != nil {
+				return err
+			}
+			b.items[i] = newItem
+			b.updatedAt = time.Now()
+			return nil
+		}
+	}
+	return errors.New("item not found in basket")
+}
+
+// RepriceVariantItem sets the price of a product variant in the basket, e.g. to the
+// product's current price once the customer has accepted a change
+func (b *Basket) RepriceVariantItem(productID, variantID string, price *value.Money) error {
+	for i, item := range b.items {
+		if item.matches(productID, variantID) {
+			newItem, err := NewBasketVariantItem(productID, variantID, item.quantity, price)
 			if err != nil {
 				return err
 			}
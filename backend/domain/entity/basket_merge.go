@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"ecom-backend/domain/value"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MergeStrategy decides the quantity of a line held by both baskets in a merge
+type MergeStrategy string
+
+const (
+	// MergeSum adds the quantities of both baskets
+	MergeSum MergeStrategy = "sum"
+	// MergeMax keeps the larger of the two quantities
+	MergeMax MergeStrategy = "max"
+	// MergeNewest keeps the line from the basket changed most recently
+	MergeNewest MergeStrategy = "newest"
+)
+
+// ParseMergeStrategy parses a merge strategy name; an empty name means MergeSum
+func ParseMergeStrategy(name string) (MergeStrategy, error) {
+	switch MergeStrategy(name) {
+	case "", MergeSum:
+		return MergeSum, nil
+	case MergeMax, MergeNewest:
+		return MergeStrategy(name), nil
+	default:
+		return "", fmt.Errorf("unknown merge strategy %q: expected sum, max or newest", name)
+	}
+}
+
+// Merge adjustment reasons
+const (
+	MergeLimitedByStock = "limited_by_stock" // the quantity was reduced to the stock available
+	MergeOutOfStock     = "out_of_stock"     // nothing is in stock, so the line was removed
+	MergeUnavailable    = "unavailable"      // the product or variant is archived or gone
+)
+
+// MergeAdjustment records a merged line that could not get the quantity its strategy asked for
+type MergeAdjustment struct {
+	ProductID string
+	VariantID string
+	Requested int // quantity the strategy asked for
+	Quantity  int // quantity kept, zero when the line was removed
+	Available int // stock available when merging
+	Reason    string
+}
+
+// Merge moves the items of other into the basket. Lines held by both baskets are resolved
+// with strategy, and every line coming from other is limited to the current stock in
+// products, which must hold the product of each of other's items. Lines only in this
+// basket are left alone. A line held by both keeps this basket's price, except when
+// MergeNewest picks other's line. The returned adjustments list every line that was
+// reduced or dropped.
+func (b *Basket) Merge(other *Basket, strategy MergeStrategy, products map[string]*Product) ([]MergeAdjustment, error) {
+	if other == nil || other.id == b.id {
+		return nil, errors.New("cannot merge a basket into itself")
+	}
+	if _, err := ParseMergeStrategy(string(strategy)); err != nil {
+		return nil, err
+	}
+
+	otherIsNewer := other.updatedAt.After(b.updatedAt)
+	adjustments := make([]MergeAdjustment, 0)
+
+	for _, incoming := range other.items {
+		existing := b.findItem(incoming.productID, incoming.variantID)
+
+		requested := incoming.quantity.Value()
+		price := incoming.price
+		if existing != nil {
+			price = existing.price
+			switch strategy {
+			case MergeSum:
+				requested += existing.quantity.Value()
+			case MergeMax:
+				requested = max(requested, existing.quantity.Value())
+			case MergeNewest:
+				if otherIsNewer {
+					price = incoming.price
+				} else {
+					requested = existing.quantity.Value()
+				}
+			}
+		}
+
+		available, reason := availableStock(products[incoming.productID], incoming.variantID)
+		quantity := requested
+		if reason != "" {
+			// Keep whatever this basket already held rather than adding an unavailable line
+			quantity = 0
+			if existing != nil {
+				quantity = existing.quantity.Value()
+			}
+		} else if requested > available {
+			quantity = available
+			reason = MergeLimitedByStock
+			if available == 0 {
+				reason = MergeOutOfStock
+			}
+		}
+
+		if reason != "" {
+			adjustments = append(adjustments, MergeAdjustment{
+				ProductID: incoming.productID,
+				VariantID: incoming.variantID,
+				Requested: requested,
+				Quantity:  quantity,
+				Available: available,
+				Reason:    reason,
+			})
+		}
+
+		if err := b.setLine(incoming.productID, incoming.variantID, quantity, price); err != nil {
+			return nil, err
+		}
+	}
+
+	b.updatedAt = time.Now()
+	return adjustments, nil
+}
+
+// availableStock returns the stock of a product or variant, or a reason it cannot be ordered
+func availableStock(product *Product, variantID string) (int, string) {
+	if product == nil || product.IsArchived() {
+		return 0, MergeUnavailable
+	}
+	stock, err := product.StockFor(variantID)
+	if err != nil {
+		return 0, MergeUnavailable
+	}
+	return stock.Value(), ""
+}
+
+// findItem returns the line for a product variant, or nil when the basket does not hold it
+func (b *Basket) findItem(productID, variantID string) *BasketItem {
+	for _, item := range b.items {
+		if item.matches(productID, variantID) {
+			return item
+		}
+	}
+	return nil
+}
+
+// setLine sets the quantity and price of a line, adding or removing it as needed
+func (b *Basket) setLine(productID, variantID string, quantity int, price *value.Money) error {
+	if quantity == 0 {
+		if b.findItem(productID, variantID) != nil {
+			return b.RemoveVariantItem(productID, variantID)
+		}
+		return nil
+	}
+
+	qty, err := value.NewQuantity(quantity)
+	if err != nil {
+		return err
+	}
+	item, err := NewBasketVariantItem(productID, variantID, qty, price)
+	if err != nil {
+		return err
+	}
+
+	for i, existing := range b.items {
+		if existing.matches(productID, variantID) {
+			b.items[i] = item
+			return nil
+		}
+	}
+	b.items = append(b.items, item)
+	return nil
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"ecom-backend/domain/value"
 	"testing"
+	"time"
 )
 
 func TestNewBasket(t *testing.T) {
@@ -108,3 +109,150 @@ func TestBasket_Clear(t *testing.T) {
 		t.Error("expected basket to be empty after clear")
 	}
 }
+
+func TestBasket_AddVariantItem(t *testing.T) {
+	basket := NewBasket()
+	price, _ := value.NewMoney(1000, "USD")
+	qty, _ := value.NewQuantity(1)
+
+	basket.AddVariantItem("product-1", "variant-s", qty, price)
+	basket.AddVariantItem("product-1", "variant-m", qty, price)
+	basket.AddVariantItem("product-1", "variant-s", qty, price)
+
+	if len(basket.Items()) != 2 {
+		t.Errorf("expected 2 lines, got %d", len(basket.Items()))
+	}
+	if basket.ItemCount() != 3 {
+		t.Errorf("expected item count 3, got %d", basket.ItemCount())
+	}
+
+	if err := basket.RemoveItem("product-1"); err == nil {
+		t.Error("expected error when removing without the variant ID")
+	}
+	if err := basket.RemoveVariantItem("product-1", "variant-m"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if basket.ItemCount() != 2 {
+		t.Errorf("expected item count 2, got %d", basket.ItemCount())
+	}
+}
+
+func TestBasket_RepriceVariantItem(t *testing.T) {
+	basket := NewBasket()
+	price, _ := value.NewMoney(1000, "USD")
+	qty, _ := value.NewQuantity(2)
+	basket.AddVariantItem("product-1", "variant-s", qty, price)
+
+	newPrice, _ := value.NewMoney(1200, "USD")
+	if err := basket.RepriceVariantItem("product-1", "variant-s", newPrice); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	total, _ := basket.Total()
+	if total.Amount() != 2400 {
+		t.Errorf("expected total 2400, got %d", total.Amount())
+	}
+	if basket.ItemCount() != 2 {
+		t.Errorf("expected the quantity to be kept, got %d", basket.ItemCount())
+	}
+	if err := basket.RepriceVariantItem("product-1", "variant-m", newPrice); err == nil {
+		t.Error("expected error for an item not in the basket")
+	}
+}
+
+func TestBasket_Merge(t *testing.T) {
+	newProduct := func(name string, stock int) *Product {
+		price, _ := value.NewMoney(1000, "USD")
+		qty, _ := value.NewQuantity(stock)
+		product, _ := NewProduct(name, "", price, qty)
+		return product
+	}
+	mug := newProduct("Mug", 10)
+	lamp := newProduct("Lamp", 4)
+	poster := newProduct("Poster", 0)
+	vase := newProduct("Vase", 10)
+	vase.Archive()
+	products := map[string]*Product{mug.ID(): mug, lamp.ID(): lamp, poster.ID(): poster, vase.ID(): vase}
+
+	add := func(b *Basket, p *Product, quantity int, amount int64) {
+		qty, _ := value.NewQuantity(quantity)
+		price, _ := value.NewMoney(amount, "USD")
+		if err := b.AddItem(p.ID(), qty, price); err != nil {
+			t.Fatalf("AddItem: %v", err)
+		}
+	}
+
+	tests := []struct {
+		strategy    MergeStrategy
+		guestNewer  bool
+		wantMug     int
+		wantMugCost int64
+		wantLamp    int
+	}{
+		{MergeSum, false, 5, 900, 4},
+		{MergeMax, false, 3, 900, 3},
+		{MergeNewest, false, 2, 900, 3},
+		{MergeNewest, true, 3, 1000, 2},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			account := NewBasket()
+			add(account, mug, 2, 900)
+			add(account, lamp, 3, 1000)
+
+			guest := NewBasket()
+			add(guest, mug, 3, 1000)
+			add(guest, lamp, 2, 1000)
+			add(guest, poster, 1, 1000)
+			add(guest, vase, 1, 1000)
+
+			// The newest basket is the one changed last
+			if tt.guestNewer {
+				guest.updatedAt = account.updatedAt.Add(time.Minute)
+			} else {
+				account.updatedAt = guest.updatedAt.Add(time.Minute)
+			}
+
+			adjustments, err := account.Merge(guest, tt.strategy, products)
+			if err != nil {
+				t.Fatalf("Merge: %v", err)
+			}
+
+			quantities := make(map[string]int)
+			for _, item := range account.Items() {
+				quantities[item.ProductID()] = item.Quantity().Value()
+				if item.ProductID() == mug.ID() && item.Price().Amount() != tt.wantMugCost {
+					t.Errorf("expected mug price %d, got %d", tt.wantMugCost, item.Price().Amount())
+				}
+			}
+			if quantities[mug.ID()] != tt.wantMug || quantities[lamp.ID()] != tt.wantLamp {
+				t.Errorf("expected %d mugs and %d lamps, got %v", tt.wantMug, tt.wantLamp, quantities)
+			}
+			if _, ok := quantities[poster.ID()]; ok {
+				t.Error("expected the out of stock poster to be left out")
+			}
+			if _, ok := quantities[vase.ID()]; ok {
+				t.Error("expected the archived vase to be left out")
+			}
+
+			reasons := make(map[string]string)
+			for _, a := range adjustments {
+				reasons[a.ProductID] = a.Reason
+			}
+			if reasons[poster.ID()] != MergeOutOfStock || reasons[vase.ID()] != MergeUnavailable {
+				t.Errorf("unexpected adjustments %+v", adjustments)
+			}
+			if limited := reasons[lamp.ID()] == MergeLimitedByStock; limited != (tt.strategy == MergeSum) {
+				t.Errorf("expected the lamp limited by stock only when summing, got %+v", adjustments)
+			}
+		})
+	}
+
+	basket := NewBasket()
+	if _, err := basket.Merge(basket, MergeSum, products); err == nil {
+		t.Error("expected error merging a basket into itself")
+	}
+	if _, err := basket.Merge(NewBasket(), "first", products); err == nil {
+		t.Error("expected error for an unknown strategy")
+	}
+}
@@ -19,15 +19,69 @@ const (
 	OrderStatusCancelled OrderStatus = "CANCELLED"
 )
 
+// ProductSnapshot records the product details of an order item at the time of checkout,
+// so that later edits or deletion of the product do not change past orders
+type ProductSnapshot struct {
+	name        string
+	sku         string
+	description string
+}
+
+// NewProductSnapshot captures the current details of a product or one of its variants.
+// Variant lines record the variant's SKU.
+func NewProductSnapshot(product *Product, variantID string) (ProductSnapshot, error) {
+	snapshot := ProductSnapshot{
+		name:        product.Name(),
+		sku:         product.SKU(),
+		description: product.Description(),
+	}
+	if variantID != "" {
+		variant, err := product.Variant(variantID)
+		if err != nil {
+			return ProductSnapshot{}, err
+		}
+		snapshot.sku = variant.SKU()
+	}
+	return snapshot, nil
+}
+
+// ReconstructProductSnapshot reconstructs a ProductSnapshot from persistence
+func ReconstructProductSnapshot(name, sku, description string) ProductSnapshot {
+	return ProductSnapshot{name: name, sku: sku, description: description}
+}
+
+// Name returns the product name at checkout
+func (ps ProductSnapshot) Name() string {
+	return ps.name
+}
+
+// SKU returns the product or variant SKU at checkout
+func (ps ProductSnapshot) SKU() string {
+	return ps.sku
+}
+
+// Description returns the product description at checkout
+func (ps ProductSnapshot) Description() string {
+	return ps.description
+}
+
 // OrderItem represents an item in an order
 type OrderItem struct {
 	productID string
+	variantID string
 	quantity  *value.Quantity
 	price     *value.Money
+	product   ProductSnapshot
 }
 
 // NewOrderItem creates a new order item
-func NewOrderItem(productID string, quantity *value.Quantity, price *value.Money) (*OrderItem, error) {
+func NewOrderItem(productID string, quantity *value.Quantity, price *value.Money, product ProductSnapshot) (*OrderItem, error) {
+	return NewOrderVariantItem(productID, "", quantity, price, product)
+}
+
+// NewOrderVariantItem creates a new order item for a product variant.
+// An empty variantID refers to a product without variants.
+func NewOrderVariantItem(productID, variantID string, quantity *value.Quantity, price *value.Money, product ProductSnapshot) (*OrderItem, error) {
 	if productID == "" {
 		return nil, errors.New("product ID cannot be empty")
 	}
@@ -40,8 +94,10 @@ func NewOrderItem(productID string, quantity *value.Quantity, price *value.Money
 
 	return &OrderItem{
 		productID: productID,
+		variantID: variantID,
 		quantity:  quantity,
 		price:     price,
+		product:   product,
 	}, nil
 }
 
@@ -50,6 +106,11 @@ func (oi *OrderItem) ProductID() string {
 	return oi.productID
 }
 
+// VariantID returns the variant ID, empty for products without variants
+func (oi *OrderItem) VariantID() string {
+	return oi.variantID
+}
+
 // Quantity returns the quantity
 func (oi *OrderItem) Quantity() *value.Quantity {
 	return oi.quantity
@@ -60,6 +121,11 @@ func (oi *OrderItem) Price() *value.Money {
 	return oi.price
 }
 
+// Product returns the product details recorded at checkout
+func (oi *OrderItem) Product() ProductSnapshot {
+	return oi.product
+}
+
 // Subtotal calculates the subtotal for this item
 func (oi *OrderItem) Subtotal() (*value.Money, error) {
 	return oi.price.Multiply(oi.quantity.Value())
@@ -75,8 +141,9 @@ type Order struct {
 	updatedAt time.Time
 }
 
-// NewOrder creates a new order from basket items
-func NewOrder(basketItems []*BasketItem) (*Order, error) {
+// NewOrder creates a new order from basket items, recording a snapshot of each item's product.
+// products must hold the product of every basket item, keyed by product ID.
+func NewOrder(basketItems []*BasketItem, products map[string]*Product) (*Order, error) {
 	if len(basketItems) == 0 {
 		return nil, errors.New("cannot create order with empty basket")
 	}
@@ -84,7 +151,16 @@ func NewOrder(basketItems []*BasketItem) (*Order, error) {
 	// Convert basket items to order items
 	orderItems := make([]*OrderItem, 0, len(basketItems))
 	for _, bi := range basketItems {
-		orderItem, err := NewOrderItem(bi.ProductID(), bi.Quantity(), bi.Price())
+		product, ok := products[bi.ProductID()]
+		if !ok {
+			return nil, errors.New("product not found")
+		}
+		snapshot, err := NewProductSnapshot(product, bi.VariantID())
+		if err != nil {
+			return nil, err
+		}
+
+		orderItem, err := NewOrderVariantItem(bi.ProductID(), bi.VariantID(), bi.Quantity(), bi.Price(), snapshot)
 		if err != nil {
 			return nil, err
 		}
@@ -208,3 +284,13 @@ func (o *Order) Cancel() error {
 func (o *Order) IsCancellable() bool {
 	return o.status != OrderStatusDelivered && o.status != OrderStatusCancelled
 }
+
+// HasProduct checks if any item of the order is the product or one of its variants
+func (o *Order) HasProduct(productID string) bool {
+	for _, item := range o.items {
+		if item.productID == productID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"ecom-backend/domain/value"
+	"testing"
+)
+
+func TestNewOrder_RecordsProductSnapshots(t *testing.T) {
+	shirt := newShirt(t)
+	shirt.AssignSKU("SHIRT")
+	stock, _ := value.NewQuantity(5)
+	variant, _ := NewProductVariant("SHIRT-S-RED", "", map[string]string{"size": "S", "colour": "red"}, nil, stock)
+	if err := shirt.AddVariant(variant); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	price, _ := value.NewMoney(1000, "USD")
+	mug, _ := NewProduct("Mug", "Stoneware mug", price, stock)
+	mug.AssignSKU("MUG")
+
+	basket := NewBasket()
+	qty, _ := value.NewQuantity(1)
+	basket.AddVariantItem(shirt.ID(), variant.ID(), qty, shirt.Price())
+	basket.AddItem(mug.ID(), qty, mug.Price())
+
+	order, err := NewOrder(basket.Items(), map[string]*Product{shirt.ID(): shirt, mug.ID(): mug})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]ProductSnapshot{
+		shirt.ID(): ReconstructProductSnapshot("Shirt", "SHIRT-S-RED", "Cotton shirt"),
+		mug.ID():   ReconstructProductSnapshot("Mug", "MUG", "Stoneware mug"),
+	}
+	for _, item := range order.Items() {
+		if got := item.Product(); got != want[item.ProductID()] {
+			t.Errorf("expected snapshot %+v, got %+v", want[item.ProductID()], got)
+		}
+	}
+
+	// Later product changes do not reach the order
+	mug.UpdateDetails("Large mug", "", price)
+	for _, item := range order.Items() {
+		if item.ProductID() == mug.ID() && item.Product().Name() != "Mug" {
+			t.Errorf("expected the snapshot to keep the name at checkout, got %q", item.Product().Name())
+		}
+	}
+}
+
+func TestNewOrder_RequiresProducts(t *testing.T) {
+	basket := NewBasket()
+	price, _ := value.NewMoney(1000, "USD")
+	qty, _ := value.NewQuantity(1)
+	basket.AddItem("product-1", qty, price)
+
+	if _, err := NewOrder(basket.Items(), nil); err == nil {
+		t.Error("expected error for a basket item without its product")
+	}
+}
@@ -11,12 +11,24 @@ import (
 // Product represents a product in the catalog
 type Product struct {
 	id          string
+	sku         string
 	name        string
 	description string
+	category    string
 	price       *value.Money
 	stock       *value.Quantity
+	options     []*ProductOption
+	variants    []*ProductVariant
+	images      []*ProductImage
+	primaryID   string // ID of the primary image
 	createdAt   time.Time
 	updatedAt   time.Time
+	deletedAt   *time.Time // set when the product is archived
+	ratingCount int        // number of approved reviews
+	ratingTotal int        // sum of the ratings of approved reviews
+
+	lowStockThreshold int // stock at or below which the product or a variant is low, zero when off
+	reorderQuantity   int // units to order when stock runs low, zero when not set
 }
 
 // NewProduct creates a new Product entity
@@ -38,21 +50,36 @@ func NewProduct(name, description string, price *value.Money, stock *value.Quant
 		description: description,
 		price:       price,
 		stock:       stock,
+		options:     make([]*ProductOption, 0),
+		variants:    make([]*ProductVariant, 0),
+		images:      make([]*ProductImage, 0),
 		createdAt:   now,
 		updatedAt:   now,
 	}, nil
 }
 
 // ReconstructProduct reconstructs a Product from persistence
-func ReconstructProduct(id, name, description string, price *value.Money, stock *value.Quantity, createdAt, updatedAt time.Time) *Product {
+func ReconstructProduct(id, sku, name, description, category string, price *value.Money, stock *value.Quantity, options []*ProductOption, variants []*ProductVariant, images []*ProductImage, primaryImageID string, createdAt, updatedAt time.Time, deletedAt *time.Time, ratingCount, ratingTotal, lowStockThreshold, reorderQuantity int) *Product {
 	return &Product{
 		id:          id,
+		sku:         sku,
 		name:        name,
 		description: description,
+		category:    category,
 		price:       price,
 		stock:       stock,
+		options:     options,
+		variants:    variants,
+		images:      images,
+		primaryID:   primaryImageID,
 		createdAt:   createdAt,
 		updatedAt:   updatedAt,
+		deletedAt:   deletedAt,
+		ratingCount: ratingCount,
+		ratingTotal: ratingTotal,
+
+		lowStockThreshold: lowStockThreshold,
+		reorderQuantity:   reorderQuantity,
 	}
 }
 
@@ -61,6 +88,11 @@ func (p *Product) ID() string {
 	return p.id
 }
 
+// SKU returns the product-level SKU, empty if none is assigned
+func (p *Product) SKU() string {
+	return p.sku
+}
+
 // Name returns the product name
 func (p *Product) Name() string {
 	return p.name
@@ -71,6 +103,11 @@ func (p *Product) Description() string {
 	return p.description
 }
 
+// Category returns the product category, empty if uncategorized
+func (p *Product) Category() string {
+	return p.category
+}
+
 // Price returns the product price
 func (p *Product) Price() *value.Money {
 	return p.price
@@ -81,6 +118,36 @@ func (p *Product) Stock() *value.Quantity {
 	return p.stock
 }
 
+// Options returns the product options
+func (p *Product) Options() []*ProductOption {
+	return p.options
+}
+
+// Variants returns the product variants
+func (p *Product) Variants() []*ProductVariant {
+	return p.variants
+}
+
+// HasVariants checks if the product is sold through variants
+func (p *Product) HasVariants() bool {
+	return len(p.variants) > 0
+}
+
+// Images returns the product images in display order
+func (p *Product) Images() []*ProductImage {
+	return p.images
+}
+
+// PrimaryImage returns the primary image, or nil if the product has no images
+func (p *Product) PrimaryImage() *ProductImage {
+	for _, img := range p.images {
+		if img.id == p.primaryID {
+			return img
+		}
+	}
+	return nil
+}
+
 // CreatedAt returns the creation time
 func (p *Product) CreatedAt() time.Time {
 	return p.createdAt
@@ -91,6 +158,19 @@ func (p *Product) UpdatedAt() time.Time {
 	return p.updatedAt
 }
 
+// ReviewCount returns the number of approved reviews
+func (p *Product) ReviewCount() int {
+	return p.ratingCount
+}
+
+// AverageRating returns the mean rating of approved reviews, zero when there are none
+func (p *Product) AverageRating() float64 {
+	if p.ratingCount == 0 {
+		return 0
+	}
+	return float64(p.ratingTotal) / float64(p.ratingCount)
+}
+
 // UpdateDetails updates product details
 func (p *Product) UpdateDetails(name, description string, price *value.Money) error {
 	if name == "" {
@@ -128,7 +208,316 @@ func (p *Product) ReduceStock(quantity *value.Quantity) error {
 	return nil
 }
 
-// IsAvailable checks if the product has stock
+// DeletedAt returns when the product was archived, or nil if it is active
+func (p *Product) DeletedAt() *time.Time {
+	return p.deletedAt
+}
+
+// IsArchived checks if the product has been archived
+func (p *Product) IsArchived() bool {
+	return p.deletedAt != nil
+}
+
+// Archive hides the product from the catalog while keeping it resolvable for orders
+func (p *Product) Archive() error {
+	if p.IsArchived() {
+		return errors.New("product is already archived")
+	}
+	now := time.Now()
+	p.deletedAt = &now
+	p.updatedAt = now
+	return nil
+}
+
+// Restore returns an archived product to the catalog
+func (p *Product) Restore() error {
+	if !p.IsArchived() {
+		return errors.New("product is not archived")
+	}
+	p.deletedAt = nil
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// LowStockThreshold returns the stock at or below which the product, or each of its
+// variants, is low on stock; zero turns low-stock alerts off
+func (p *Product) LowStockThreshold() int {
+	return p.lowStockThreshold
+}
+
+// ReorderQuantity returns how many units to order when stock runs low
+func (p *Product) ReorderQuantity() int {
+	return p.reorderQuantity
+}
+
+// SetReorderPolicy sets the low-stock threshold and the quantity to reorder at it
+func (p *Product) SetReorderPolicy(threshold, reorderQuantity int) error {
+	if threshold < 0 {
+		return errors.New("low-stock threshold cannot be negative")
+	}
+	if reorderQuantity < 0 {
+		return errors.New("reorder quantity cannot be negative")
+	}
+	if threshold == 0 && reorderQuantity > 0 {
+		return errors.New("reorder quantity needs a low-stock threshold")
+	}
+	p.lowStockThreshold = threshold
+	p.reorderQuantity = reorderQuantity
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// IsLowStock checks if the active product, or with a variantID one of its variants,
+// has a threshold and stock at or below it
+func (p *Product) IsLowStock(variantID string) bool {
+	if p.lowStockThreshold == 0 || p.IsArchived() {
+		return false
+	}
+	stock, err := p.StockFor(variantID)
+	if err != nil {
+		return false
+	}
+	return stock.Value() <= p.lowStockThreshold
+}
+
+// IsAvailable checks if the product is active and has stock
 func (p *Product) IsAvailable() bool {
+	if p.IsArchived() {
+		return false
+	}
+	if p.HasVariants() {
+		for _, v := range p.variants {
+			if v.IsAvailable() {
+				return true
+			}
+		}
+		return false
+	}
 	return !p.stock.IsZero()
 }
+
+// AssignSKU sets the product-level SKU
+func (p *Product) AssignSKU(sku string) {
+	p.sku = sku
+	p.updatedAt = time.Now()
+}
+
+// Categorize assigns the product to a category
+func (p *Product) Categorize(category string) {
+	p.category = category
+	p.updatedAt = time.Now()
+}
+
+// AddOption adds a configurable option to the product
+func (p *Product) AddOption(option *ProductOption) error {
+	if option == nil {
+		return errors.New("option cannot be nil")
+	}
+	if p.HasVariants() {
+		return errors.New("cannot add options to a product that already has variants")
+	}
+	for _, o := range p.options {
+		if o.name == option.name {
+			return errors.New("duplicate option: " + option.name)
+		}
+	}
+
+	p.options = append(p.options, option)
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// AddVariant adds a variant to the product
+func (p *Product) AddVariant(variant *ProductVariant) error {
+	if variant == nil {
+		return errors.New("variant cannot be nil")
+	}
+	if variant.price != nil && variant.price.Currency() != p.price.Currency() {
+		return errors.New("variant price currency must match product currency")
+	}
+	if len(variant.options) != len(p.options) {
+		return errors.New("variant must select a value for every product option")
+	}
+	for _, o := range p.options {
+		selected, ok := variant.options[o.name]
+		if !ok {
+			return errors.New("variant is missing option: " + o.name)
+		}
+		if !o.HasValue(selected) {
+			return errors.New("invalid value for option " + o.name + ": " + selected)
+		}
+	}
+	for _, v := range p.variants {
+		if v.sku == variant.sku {
+			return errors.New("duplicate variant SKU: " + variant.sku)
+		}
+		if sameOptions(v.options, variant.options) {
+			return errors.New("a variant with these options already exists")
+		}
+	}
+
+	// Once a product has variants its stock is tracked per variant only
+	if !p.HasVariants() {
+		p.stock, _ = value.NewQuantity(0)
+	}
+
+	p.variants = append(p.variants, variant)
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// Variant retrieves a variant by ID
+func (p *Product) Variant(id string) (*ProductVariant, error) {
+	for _, v := range p.variants {
+		if v.id == id {
+			return v, nil
+		}
+	}
+	return nil, errors.New("variant not found")
+}
+
+// RemoveVariant removes a variant from the product
+func (p *Product) RemoveVariant(id string) error {
+	for i, v := range p.variants {
+		if v.id == id {
+			p.variants = append(p.variants[:i], p.variants[i+1:]...)
+			p.updatedAt = time.Now()
+			return nil
+		}
+	}
+	return errors.New("variant not found")
+}
+
+// PriceFor returns the effective price of the product or one of its variants.
+// An empty variantID refers to the product itself, which is only allowed when
+// the product has no variants.
+func (p *Product) PriceFor(variantID string) (*value.Money, error) {
+	if variantID == "" {
+		if p.HasVariants() {
+			return nil, errors.New("variant ID is required for products with variants")
+		}
+		return p.price, nil
+	}
+
+	v, err := p.Variant(variantID)
+	if err != nil {
+		return nil, err
+	}
+	if v.price != nil {
+		return v.price, nil
+	}
+	return p.price, nil
+}
+
+// StockFor returns the stock of the product or one of its variants
+func (p *Product) StockFor(variantID string) (*value.Quantity, error) {
+	if variantID == "" {
+		if p.HasVariants() {
+			return nil, errors.New("variant ID is required for products with variants")
+		}
+		return p.stock, nil
+	}
+
+	v, err := p.Variant(variantID)
+	if err != nil {
+		return nil, err
+	}
+	return v.stock, nil
+}
+
+// ReduceStockFor reduces the stock of the product or one of its variants
+func (p *Product) ReduceStockFor(variantID string, quantity *value.Quantity) error {
+	if variantID == "" {
+		if p.HasVariants() {
+			return errors.New("variant ID is required for products with variants")
+		}
+		return p.ReduceStock(quantity)
+	}
+
+	v, err := p.Variant(variantID)
+	if err != nil {
+		return err
+	}
+	if err := v.ReduceStock(quantity); err != nil {
+		return err
+	}
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// AddImage appends an image to the product; the first image becomes the primary image
+func (p *Product) AddImage(image *ProductImage) error {
+	if image == nil {
+		return errors.New("image cannot be nil")
+	}
+	for _, img := range p.images {
+		if img.id == image.id {
+			return errors.New("image already exists")
+		}
+	}
+
+	p.images = append(p.images, image)
+	if p.primaryID == "" {
+		p.primaryID = image.id
+	}
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// RemoveImage removes an image from the product and returns it.
+// If the primary image is removed, the next image in order becomes primary.
+func (p *Product) RemoveImage(id string) (*ProductImage, error) {
+	for i, img := range p.images {
+		if img.id == id {
+			p.images = append(p.images[:i], p.images[i+1:]...)
+			if p.primaryID == id {
+				p.primaryID = ""
+				if len(p.images) > 0 {
+					p.primaryID = p.images[0].id
+				}
+			}
+			p.updatedAt = time.Now()
+			return img, nil
+		}
+	}
+	return nil, errors.New("image not found")
+}
+
+// ReorderImages sets the display order of the product images.
+// The given IDs must be exactly the IDs of the current images.
+func (p *Product) ReorderImages(ids []string) error {
+	if len(ids) != len(p.images) {
+		return errors.New("image order must list every product image exactly once")
+	}
+
+	byID := make(map[string]*ProductImage, len(p.images))
+	for _, img := range p.images {
+		byID[img.id] = img
+	}
+
+	ordered := make([]*ProductImage, 0, len(ids))
+	for _, id := range ids {
+		img, ok := byID[id]
+		if !ok {
+			return errors.New("image order must list every product image exactly once")
+		}
+		delete(byID, id)
+		ordered = append(ordered, img)
+	}
+
+	p.images = ordered
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// SetPrimaryImage marks an image as the primary image
+func (p *Product) SetPrimaryImage(id string) error {
+	for _, img := range p.images {
+		if img.id == id {
+			p.primaryID = id
+			p.updatedAt = time.Now()
+			return nil
+		}
+	}
+	return errors.New("image not found")
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImageRendition is a stored rendering of an image at a given size
+type ImageRendition struct {
+	Name   string // "original" or a configured thumbnail size name
+	Key    string // blob store key
+	URL    string
+	Width  int
+	Height int
+}
+
+// ProductImage represents an uploaded product image and its thumbnails
+type ProductImage struct {
+	id          string
+	altText     string
+	contentType string
+	size        int64
+	original    ImageRendition
+	thumbnails  []ImageRendition
+	createdAt   time.Time
+}
+
+// NewProductImageID generates an ID for an image before it is stored
+func NewProductImageID() string {
+	return uuid.New().String()
+}
+
+// NewProductImage creates a new ProductImage
+func NewProductImage(id, altText, contentType string, size int64, original ImageRendition, thumbnails []ImageRendition) (*ProductImage, error) {
+	if id == "" {
+		return nil, errors.New("image ID cannot be empty")
+	}
+	if contentType == "" {
+		return nil, errors.New("image content type cannot be empty")
+	}
+	if original.Key == "" {
+		return nil, errors.New("image key cannot be empty")
+	}
+	if original.Width <= 0 || original.Height <= 0 {
+		return nil, errors.New("image dimensions must be positive")
+	}
+
+	return &ProductImage{
+		id:          id,
+		altText:     altText,
+		contentType: contentType,
+		size:        size,
+		original:    original,
+		thumbnails:  thumbnails,
+		createdAt:   time.Now(),
+	}, nil
+}
+
+// ReconstructProductImage reconstructs a ProductImage from persistence
+func ReconstructProductImage(id, altText, contentType string, size int64, original ImageRendition, thumbnails []ImageRendition, createdAt time.Time) *ProductImage {
+	return &ProductImage{
+		id:          id,
+		altText:     altText,
+		contentType: contentType,
+		size:        size,
+		original:    original,
+		thumbnails:  thumbnails,
+		createdAt:   createdAt,
+	}
+}
+
+// ID returns the image ID
+func (i *ProductImage) ID() string {
+	return i.id
+}
+
+// AltText returns the image alternative text
+func (i *ProductImage) AltText() string {
+	return i.altText
+}
+
+// ContentType returns the MIME type of the original image
+func (i *ProductImage) ContentType() string {
+	return i.contentType
+}
+
+// Size returns the size of the original image in bytes
+func (i *ProductImage) Size() int64 {
+	return i.size
+}
+
+// Original returns the original rendition
+func (i *ProductImage) Original() ImageRendition {
+	return i.original
+}
+
+// Thumbnails returns the generated thumbnail renditions
+func (i *ProductImage) Thumbnails() []ImageRendition {
+	return i.thumbnails
+}
+
+// Keys returns the blob keys of every rendition
+func (i *ProductImage) Keys() []string {
+	keys := []string{i.original.Key}
+	for _, t := range i.thumbnails {
+		keys = append(keys, t.Key)
+	}
+	return keys
+}
+
+// CreatedAt returns the upload time
+func (i *ProductImage) CreatedAt() time.Time {
+	return i.createdAt
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"ecom-backend/domain/value"
+	"testing"
+)
+
+func newTestImage(t *testing.T, id string) *ProductImage {
+	t.Helper()
+
+	original := ImageRendition{Name: "original", Key: "products/p/" + id + "/original.png", Width: 800, Height: 600}
+	image, err := NewProductImage(id, "", "image/png", 1024, original, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return image
+}
+
+func newPlainProduct(t *testing.T) *Product {
+	t.Helper()
+
+	price, _ := value.NewMoney(1000, "USD")
+	stock, _ := value.NewQuantity(1)
+	product, err := NewProduct("Mug", "Ceramic mug", price, stock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return product
+}
+
+func TestNewProductImage_Validation(t *testing.T) {
+	valid := ImageRendition{Key: "k", Width: 10, Height: 10}
+
+	if _, err := NewProductImage("", "", "image/png", 1, valid, nil); err == nil {
+		t.Error("expected error for empty ID")
+	}
+	if _, err := NewProductImage("id", "", "", 1, valid, nil); err == nil {
+		t.Error("expected error for empty content type")
+	}
+	if _, err := NewProductImage("id", "", "image/png", 1, ImageRendition{Key: "k"}, nil); err == nil {
+		t.Error("expected error for missing dimensions")
+	}
+}
+
+func TestProduct_Images(t *testing.T) {
+	product := newPlainProduct(t)
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := product.AddImage(newTestImage(t, id)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if product.PrimaryImage().ID() != "a" {
+		t.Errorf("expected first image to be primary, got %s", product.PrimaryImage().ID())
+	}
+	if err := product.AddImage(newTestImage(t, "a")); err == nil {
+		t.Error("expected error for duplicate image")
+	}
+
+	if err := product.ReorderImages([]string{"c", "a", "b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product.Images()[0].ID() != "c" {
+		t.Errorf("expected c first after reorder, got %s", product.Images()[0].ID())
+	}
+	if err := product.ReorderImages([]string{"c", "a"}); err == nil {
+		t.Error("expected error when reorder omits an image")
+	}
+
+	if err := product.SetPrimaryImage("b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := product.RemoveImage("b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product.PrimaryImage() == nil || product.PrimaryImage().ID() != "c" {
+		t.Error("expected first remaining image to become primary")
+	}
+	if _, err := product.RemoveImage("missing"); err == nil {
+		t.Error("expected error removing unknown image")
+	}
+}
@@ -99,3 +99,68 @@ func TestProduct_IsAvailable(t *testing.T) {
 		t.Error("expected product not to be available")
 	}
 }
+
+func TestProduct_ArchiveAndRestore(t *testing.T) {
+	price, _ := value.NewMoney(1000, "USD")
+	stock, _ := value.NewQuantity(5)
+	product, _ := NewProduct("Lamp", "Desk lamp", price, stock)
+
+	if err := product.Archive(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !product.IsArchived() || product.DeletedAt() == nil {
+		t.Error("expected product to be archived")
+	}
+	if product.IsAvailable() {
+		t.Error("expected archived product to be unavailable")
+	}
+	if err := product.Archive(); err == nil {
+		t.Error("expected error archiving twice")
+	}
+
+	if err := product.Restore(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product.IsArchived() || !product.IsAvailable() {
+		t.Error("expected restored product to be active and available")
+	}
+	if err := product.Restore(); err == nil {
+		t.Error("expected error restoring an active product")
+	}
+}
+
+func TestProduct_ReorderPolicy(t *testing.T) {
+	price, _ := value.NewMoney(1000, "USD")
+	stock, _ := value.NewQuantity(5)
+	product, _ := NewProduct("Lamp", "Desk lamp", price, stock)
+
+	if product.IsLowStock("") {
+		t.Error("expected no low stock without a threshold")
+	}
+
+	if err := product.SetReorderPolicy(5, 20); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product.LowStockThreshold() != 5 || product.ReorderQuantity() != 20 {
+		t.Errorf("expected threshold 5 and reorder quantity 20, got %d and %d", product.LowStockThreshold(), product.ReorderQuantity())
+	}
+	if !product.IsLowStock("") {
+		t.Error("expected stock at the threshold to be low")
+	}
+
+	more, _ := value.NewQuantity(6)
+	product.UpdateStock(more)
+	if product.IsLowStock("") {
+		t.Error("expected stock above the threshold not to be low")
+	}
+
+	if err := product.SetReorderPolicy(-1, 0); err == nil {
+		t.Error("expected error for a negative threshold")
+	}
+	if err := product.SetReorderPolicy(0, 10); err == nil {
+		t.Error("expected error for a reorder quantity without a threshold")
+	}
+	if err := product.SetReorderPolicy(0, 0); err != nil {
+		t.Errorf("expected the policy to be cleared: %v", err)
+	}
+}
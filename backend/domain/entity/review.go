@@ -0,0 +1,203 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewStatus represents the moderation state of a review
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"
+	ReviewApproved ReviewStatus = "approved"
+	ReviewRejected ReviewStatus = "rejected"
+)
+
+// Review limits
+const (
+	MinReviewRating = 1
+	MaxReviewRating = 5
+
+	maxReviewAuthorSize = 100
+	maxReviewTitleSize  = 200
+	maxReviewBodySize   = 5000
+)
+
+// Review is a customer's rating of a product. Only approved reviews are shown and
+// count towards the product's rating.
+type Review struct {
+	id               string
+	productID        string
+	orderID          string // order the reviewer bought the product with, empty when not given
+	author           string
+	rating           int
+	title            string
+	body             string
+	verifiedPurchase bool
+	status           ReviewStatus
+	moderationNote   string
+	createdAt        time.Time
+	moderatedAt      *time.Time
+}
+
+// NewReview creates a review awaiting moderation. verifiedPurchase must only be set
+// once orderID is known to be a delivered order holding the product.
+func NewReview(productID, orderID, author string, rating int, title, body string, verifiedPurchase bool) (*Review, error) {
+	if productID == "" {
+		return nil, errors.New("product ID cannot be empty")
+	}
+	if rating < MinReviewRating || rating > MaxReviewRating {
+		return nil, fmt.Errorf("rating must be between %d and %d", MinReviewRating, MaxReviewRating)
+	}
+	if verifiedPurchase && orderID == "" {
+		return nil, errors.New("a verified purchase needs an order")
+	}
+
+	author, title, body = strings.TrimSpace(author), strings.TrimSpace(title), strings.TrimSpace(body)
+	switch {
+	case author == "":
+		return nil, errors.New("review author cannot be empty")
+	case title == "":
+		return nil, errors.New("review title cannot be empty")
+	case len(author) > maxReviewAuthorSize:
+		return nil, fmt.Errorf("review author cannot be longer than %d characters", maxReviewAuthorSize)
+	case len(title) > maxReviewTitleSize:
+		return nil, fmt.Errorf("review title cannot be longer than %d characters", maxReviewTitleSize)
+	case len(body) > maxReviewBodySize:
+		return nil, fmt.Errorf("review body cannot be longer than %d characters", maxReviewBodySize)
+	}
+
+	return &Review{
+		id:               uuid.New().String(),
+		productID:        productID,
+		orderID:          orderID,
+		author:           author,
+		rating:           rating,
+		title:            title,
+		body:             body,
+		verifiedPurchase: verifiedPurchase,
+		status:           ReviewPending,
+		createdAt:        time.Now(),
+	}, nil
+}
+
+// ReconstructReview reconstructs a Review from persistence
+func ReconstructReview(id, productID, orderID, author string, rating int, title, body string, verifiedPurchase bool, status ReviewStatus, moderationNote string, createdAt time.Time, moderatedAt *time.Time) *Review {
+	return &Review{
+		id:               id,
+		productID:        productID,
+		orderID:          orderID,
+		author:           author,
+		rating:           rating,
+		title:            title,
+		body:             body,
+		verifiedPurchase: verifiedPurchase,
+		status:           status,
+		moderationNote:   moderationNote,
+		createdAt:        createdAt,
+		moderatedAt:      moderatedAt,
+	}
+}
+
+// ID returns the review ID
+func (r *Review) ID() string {
+	return r.id
+}
+
+// ProductID returns the reviewed product's ID
+func (r *Review) ProductID() string {
+	return r.productID
+}
+
+// OrderID returns the order the reviewer bought the product with, empty when not given
+func (r *Review) OrderID() string {
+	return r.orderID
+}
+
+// Author returns the reviewer's display name
+func (r *Review) Author() string {
+	return r.author
+}
+
+// Rating returns the rating from 1 to 5
+func (r *Review) Rating() int {
+	return r.rating
+}
+
+// Title returns the review title
+func (r *Review) Title() string {
+	return r.title
+}
+
+// Body returns the review text
+func (r *Review) Body() string {
+	return r.body
+}
+
+// VerifiedPurchase reports whether the reviewer received the product in a delivered order
+func (r *Review) VerifiedPurchase() bool {
+	return r.verifiedPurchase
+}
+
+// Status returns the moderation status
+func (r *Review) Status() ReviewStatus {
+	return r.status
+}
+
+// ModerationNote returns the reason given when the review was moderated
+func (r *Review) ModerationNote() string {
+	return r.moderationNote
+}
+
+// CreatedAt returns the creation time
+func (r *Review) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// ModeratedAt returns when the review was last approved or rejected, nil while pending
+func (r *Review) ModeratedAt() *time.Time {
+	return r.moderatedAt
+}
+
+// IsApproved checks if the review is published
+func (r *Review) IsApproved() bool {
+	return r.status == ReviewApproved
+}
+
+// Approve publishes the review. Rejected reviews can be approved on appeal.
+func (r *Review) Approve(note string) error {
+	return r.moderate(ReviewApproved, note)
+}
+
+// Reject hides the review. Approved reviews can be taken down.
+func (r *Review) Reject(note string) error {
+	return r.moderate(ReviewRejected, note)
+}
+
+// moderate moves the review to an approved or rejected status
+func (r *Review) moderate(status ReviewStatus, note string) error {
+	if r.status == status {
+		return fmt.Errorf("review is already %s", status)
+	}
+
+	now := time.Now()
+	r.status = status
+	r.moderationNote = strings.TrimSpace(note)
+	r.moderatedAt = &now
+	return nil
+}
+
+// ParseReviewStatus parses a review status name
+func ParseReviewStatus(name string) (ReviewStatus, error) {
+	switch status := ReviewStatus(name); status {
+	case ReviewPending, ReviewApproved, ReviewRejected:
+		return status, nil
+	default:
+		return "", fmt.Errorf("unknown review status %q: expected pending, approved or rejected", name)
+	}
+}
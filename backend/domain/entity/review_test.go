@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewReview(t *testing.T) {
+	review, err := NewReview("product-1", "", "  Sam ", 4, " Solid mug ", " Keeps coffee warm. ", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if review.Status() != ReviewPending {
+		t.Errorf("expected a pending review, got %s", review.Status())
+	}
+	if review.Author() != "Sam" || review.Title() != "Solid mug" || review.Body() != "Keeps coffee warm." {
+		t.Errorf("expected trimmed fields, got %q %q %q", review.Author(), review.Title(), review.Body())
+	}
+	if review.ModeratedAt() != nil {
+		t.Error("expected no moderation time on a new review")
+	}
+}
+
+func TestNewReview_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		orderID  string
+		author   string
+		rating   int
+		title    string
+		body     string
+		verified bool
+	}{
+		{"rating too low", "", "Sam", 0, "Title", "", false},
+		{"rating too high", "", "Sam", 6, "Title", "", false},
+		{"empty author", "", " ", 3, "Title", "", false},
+		{"empty title", "", "Sam", 3, "", "", false},
+		{"long body", "", "Sam", 3, "Title", strings.Repeat("a", maxReviewBodySize+1), false},
+		{"verified without order", "", "Sam", 3, "Title", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewReview("product-1", tt.orderID, tt.author, tt.rating, tt.title, tt.body, tt.verified); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestReview_Moderate(t *testing.T) {
+	review, _ := NewReview("product-1", "", "Sam", 5, "Great", "", false)
+
+	if err := review.Reject(" spam "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if review.Status() != ReviewRejected || review.ModerationNote() != "spam" || review.ModeratedAt() == nil {
+		t.Errorf("expected a rejected review with a note, got %s %q", review.Status(), review.ModerationNote())
+	}
+	if err := review.Reject(""); err == nil {
+		t.Error("expected error rejecting a rejected review")
+	}
+
+	if err := review.Approve(""); err != nil {
+		t.Fatalf("expected a rejected review to be approved on appeal: %v", err)
+	}
+	if !review.IsApproved() || review.ModerationNote() != "" {
+		t.Errorf("expected an approved review with the note cleared, got %s %q", review.Status(), review.ModerationNote())
+	}
+	if err := review.Approve(""); err == nil {
+		t.Error("expected error approving an approved review")
+	}
+}
+
+func TestParseReviewStatus(t *testing.T) {
+	if status, err := ParseReviewStatus("approved"); err != nil || status != ReviewApproved {
+		t.Errorf("expected approved, got %q (%v)", status, err)
+	}
+	if _, err := ParseReviewStatus("hidden"); err == nil {
+		t.Error("expected error for an unknown status")
+	}
+}
@@ -0,0 +1,204 @@
+package entity
+
+import (
+	"ecom-backend/domain/value"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductOption represents a configurable product attribute such as size or colour
+type ProductOption struct {
+	name   string
+	values []string
+}
+
+// NewProductOption creates a new product option
+func NewProductOption(name string, values []string) (*ProductOption, error) {
+	if name == "" {
+		return nil, errors.New("option name cannot be empty")
+	}
+	if len(values) == 0 {
+		return nil, errors.New("option must have at least one value")
+	}
+
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v == "" {
+			return nil, errors.New("option value cannot be empty")
+		}
+		if seen[v] {
+			return nil, errors.New("duplicate option value: " + v)
+		}
+		seen[v] = true
+	}
+
+	return &ProductOption{
+		name:   name,
+		values: append([]string(nil), values...),
+	}, nil
+}
+
+// Name returns the option name
+func (o *ProductOption) Name() string {
+	return o.name
+}
+
+// Values returns the allowed option values
+func (o *ProductOption) Values() []string {
+	return o.values
+}
+
+// HasValue checks if the option allows the given value
+func (o *ProductOption) HasValue(v string) bool {
+	for _, value := range o.values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// ProductVariant represents a purchasable variant of a product
+type ProductVariant struct {
+	id        string
+	sku       string
+	barcode   string
+	options   map[string]string
+	price     *value.Money // nil means the product price applies
+	stock     *value.Quantity
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewProductVariant creates a new ProductVariant
+func NewProductVariant(sku, barcode string, options map[string]string, price *value.Money, stock *value.Quantity) (*ProductVariant, error) {
+	if sku == "" {
+		return nil, errors.New("variant SKU cannot be empty")
+	}
+	if stock == nil {
+		return nil, errors.New("variant stock cannot be nil")
+	}
+
+	now := time.Now()
+	return &ProductVariant{
+		id:        uuid.New().String(),
+		sku:       sku,
+		barcode:   barcode,
+		options:   copyOptions(options),
+		price:     price,
+		stock:     stock,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// ReconstructProductVariant reconstructs a ProductVariant from persistence
+func ReconstructProductVariant(id, sku, barcode string, options map[string]string, price *value.Money, stock *value.Quantity, createdAt, updatedAt time.Time) *ProductVariant {
+	return &ProductVariant{
+		id:        id,
+		sku:       sku,
+		barcode:   barcode,
+		options:   copyOptions(options),
+		price:     price,
+		stock:     stock,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// ID returns the variant ID
+func (v *ProductVariant) ID() string {
+	return v.id
+}
+
+// SKU returns the variant SKU
+func (v *ProductVariant) SKU() string {
+	return v.sku
+}
+
+// Barcode returns the variant barcode
+func (v *ProductVariant) Barcode() string {
+	return v.barcode
+}
+
+// Options returns the option values selected by this variant
+func (v *ProductVariant) Options() map[string]string {
+	return v.options
+}
+
+// PriceOverride returns the variant price override, or nil if none is set
+func (v *ProductVariant) PriceOverride() *value.Money {
+	return v.price
+}
+
+// Stock returns the variant stock
+func (v *ProductVariant) Stock() *value.Quantity {
+	return v.stock
+}
+
+// CreatedAt returns the creation time
+func (v *ProductVariant) CreatedAt() time.Time {
+	return v.createdAt
+}
+
+// UpdatedAt returns the last update time
+func (v *ProductVariant) UpdatedAt() time.Time {
+	return v.updatedAt
+}
+
+// UpdateDetails updates the variant barcode and price override
+func (v *ProductVariant) UpdateDetails(barcode string, price *value.Money) {
+	v.barcode = barcode
+	v.price = price
+	v.updatedAt = time.Now()
+}
+
+// UpdateStock updates the variant stock
+func (v *ProductVariant) UpdateStock(stock *value.Quantity) error {
+	if stock == nil {
+		return errors.New("variant stock cannot be nil")
+	}
+	v.stock = stock
+	v.updatedAt = time.Now()
+	return nil
+}
+
+// ReduceStock reduces stock by the given quantity
+func (v *ProductVariant) ReduceStock(quantity *value.Quantity) error {
+	newStock, err := v.stock.Subtract(quantity)
+	if err != nil {
+		return errors.New("insufficient stock")
+	}
+	v.stock = newStock
+	v.updatedAt = time.Now()
+	return nil
+}
+
+// IsAvailable checks if the variant has stock
+func (v *ProductVariant) IsAvailable() bool {
+	return !v.stock.IsZero()
+}
+
+// sameOptions checks if two option selections are identical
+func sameOptions(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// copyOptions returns a defensive copy of an option selection
+func copyOptions(options map[string]string) map[string]string {
+	c := make(map[string]string, len(options))
+	for k, v := range options {
+		c[k] = v
+	}
+	return c
+}
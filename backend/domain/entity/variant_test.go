@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"ecom-backend/domain/value"
+	"testing"
+)
+
+func newShirt(t *testing.T) *Product {
+	t.Helper()
+
+	price, _ := value.NewMoney(2000, "USD")
+	stock, _ := value.NewQuantity(0)
+	product, err := NewProduct("Shirt", "Cotton shirt", price, stock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	size, _ := NewProductOption("size", []string{"S", "M", "L"})
+	colour, _ := NewProductOption("colour", []string{"red", "blue"})
+	if err := product.AddOption(size); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := product.AddOption(colour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return product
+}
+
+func TestNewProductOption(t *testing.T) {
+	tests := []struct {
+		name      string
+		optName   string
+		values    []string
+		wantError bool
+	}{
+		{"valid option", "size", []string{"S", "M"}, false},
+		{"empty name", "", []string{"S"}, true},
+		{"no values", "size", nil, true},
+		{"empty value", "size", []string{"S", ""}, true},
+		{"duplicate value", "size", []string{"S", "S"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewProductOption(tt.optName, tt.values)
+			if tt.wantError && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !tt.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestProduct_AddVariant(t *testing.T) {
+	product := newShirt(t)
+	stock, _ := value.NewQuantity(5)
+	product.UpdateStock(stock)
+
+	variant, _ := NewProductVariant("SHIRT-S-RED", "", map[string]string{"size": "S", "colour": "red"}, nil, stock)
+	if err := product.AddVariant(variant); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !product.Stock().IsZero() {
+		t.Errorf("expected product stock to be cleared once it has variants, got %d", product.Stock().Value())
+	}
+
+	tests := []struct {
+		name    string
+		sku     string
+		options map[string]string
+	}{
+		{"duplicate SKU", "SHIRT-S-RED", map[string]string{"size": "M", "colour": "red"}},
+		{"duplicate options", "SHIRT-S-RED-2", map[string]string{"size": "S", "colour": "red"}},
+		{"missing option", "SHIRT-M", map[string]string{"size": "M"}},
+		{"unknown value", "SHIRT-XL-RED", map[string]string{"size": "XL", "colour": "red"}},
+		{"unknown option", "SHIRT-M-RED", map[string]string{"size": "M", "fit": "slim"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewProductVariant(tt.sku, "", tt.options, nil, stock)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := product.AddVariant(v); err == nil {
+				t.Error("expected error but got none")
+			}
+		})
+	}
+
+	if len(product.Variants()) != 1 {
+		t.Errorf("expected 1 variant, got %d", len(product.Variants()))
+	}
+
+	size, _ := NewProductOption("fit", []string{"slim"})
+	if err := product.AddOption(size); err == nil {
+		t.Error("expected error when adding options after variants")
+	}
+}
+
+func TestProduct_PriceFor(t *testing.T) {
+	product := newShirt(t)
+	stock, _ := value.NewQuantity(5)
+	override, _ := value.NewMoney(2500, "USD")
+
+	small, _ := NewProductVariant("SHIRT-S-RED", "", map[string]string{"size": "S", "colour": "red"}, nil, stock)
+	large, _ := NewProductVariant("SHIRT-L-RED", "", map[string]string{"size": "L", "colour": "red"}, override, stock)
+	product.AddVariant(small)
+	product.AddVariant(large)
+
+	price, err := product.PriceFor(small.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price.Amount() != 2000 {
+		t.Errorf("expected inherited price 2000, got %d", price.Amount())
+	}
+
+	price, err = product.PriceFor(large.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price.Amount() != 2500 {
+		t.Errorf("expected override price 2500, got %d", price.Amount())
+	}
+
+	if _, err := product.PriceFor(""); err == nil {
+		t.Error("expected error when no variant is given for a product with variants")
+	}
+
+	eur, _ := value.NewMoney(2500, "EUR")
+	mismatched, _ := NewProductVariant("SHIRT-M-RED", "", map[string]string{"size": "M", "colour": "red"}, eur, stock)
+	if err := product.AddVariant(mismatched); err == nil {
+		t.Error("expected error for price override in a different currency")
+	}
+}
+
+func TestProduct_ReduceStockFor(t *testing.T) {
+	product := newShirt(t)
+	stock, _ := value.NewQuantity(5)
+
+	variant, _ := NewProductVariant("SHIRT-S-RED", "", map[string]string{"size": "S", "colour": "red"}, nil, stock)
+	product.AddVariant(variant)
+
+	qty, _ := value.NewQuantity(3)
+	if err := product.ReduceStockFor(variant.ID(), qty); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variant.Stock().Value() != 2 {
+		t.Errorf("expected variant stock 2, got %d", variant.Stock().Value())
+	}
+	if !product.IsAvailable() {
+		t.Error("expected product to be available")
+	}
+
+	if err := product.ReduceStockFor(variant.ID(), qty); err == nil {
+		t.Error("expected error when reducing more than available stock")
+	}
+	if err := product.ReduceStockFor("unknown", qty); err == nil {
+		t.Error("expected error for unknown variant")
+	}
+}
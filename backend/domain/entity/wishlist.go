@@ -0,0 +1,376 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultWishlistListName names the list every new wishlist starts with
+	DefaultWishlistListName = "Wishlist"
+
+	maxWishlistLists        = 20
+	maxWishlistItems        = 500
+	maxWishlistListNameSize = 100
+)
+
+// WishlistItem is a product or variant saved to a wishlist list
+type WishlistItem struct {
+	productID         string
+	variantID         string
+	notifyBackInStock bool
+	addedAt           time.Time
+}
+
+// ReconstructWishlistItem reconstructs a WishlistItem from persistence
+func ReconstructWishlistItem(productID, variantID string, notifyBackInStock bool, addedAt time.Time) *WishlistItem {
+	return &WishlistItem{
+		productID:         productID,
+		variantID:         variantID,
+		notifyBackInStock: notifyBackInStock,
+		addedAt:           addedAt,
+	}
+}
+
+// ProductID returns the product ID
+func (wi *WishlistItem) ProductID() string {
+	return wi.productID
+}
+
+// VariantID returns the variant ID, empty for products without variants
+func (wi *WishlistItem) VariantID() string {
+	return wi.variantID
+}
+
+// NotifyBackInStock reports whether the customer asked to hear when the item is back in stock
+func (wi *WishlistItem) NotifyBackInStock() bool {
+	return wi.notifyBackInStock
+}
+
+// AddedAt returns when the item was saved
+func (wi *WishlistItem) AddedAt() time.Time {
+	return wi.addedAt
+}
+
+// matches checks if the item refers to the given product and variant
+func (wi *WishlistItem) matches(productID, variantID string) bool {
+	return wi.productID == productID && wi.variantID == variantID
+}
+
+// WishlistList is a named list of saved items, optionally shared by a public token
+type WishlistList struct {
+	id         string
+	name       string
+	shareToken string
+	items      []*WishlistItem
+	createdAt  time.Time
+}
+
+// ReconstructWishlistList reconstructs a WishlistList from persistence
+func ReconstructWishlistList(id, name, shareToken string, items []*WishlistItem, createdAt time.Time) *WishlistList {
+	return &WishlistList{
+		id:         id,
+		name:       name,
+		shareToken: shareToken,
+		items:      items,
+		createdAt:  createdAt,
+	}
+}
+
+// ID returns the list ID
+func (l *WishlistList) ID() string {
+	return l.id
+}
+
+// Name returns the list name
+func (l *WishlistList) Name() string {
+	return l.name
+}
+
+// ShareToken returns the public token of a shared list, empty when the list is private
+func (l *WishlistList) ShareToken() string {
+	return l.shareToken
+}
+
+// IsShared checks if the list can be viewed by its share token
+func (l *WishlistList) IsShared() bool {
+	return l.shareToken != ""
+}
+
+// Items returns the saved items, oldest first
+func (l *WishlistList) Items() []*WishlistItem {
+	return l.items
+}
+
+// CreatedAt returns the creation time
+func (l *WishlistList) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+// Contains checks if the list holds a product variant
+func (l *WishlistList) Contains(productID, variantID string) bool {
+	return l.findItem(productID, variantID) != nil
+}
+
+// findItem returns the item for a product variant, or nil when the list does not hold it
+func (l *WishlistList) findItem(productID, variantID string) *WishlistItem {
+	for _, item := range l.items {
+		if item.matches(productID, variantID) {
+			return item
+		}
+	}
+	return nil
+}
+
+// Wishlist holds a customer's named lists of saved products
+type Wishlist struct {
+	id        string
+	lists     []*WishlistList
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewWishlist creates a wishlist with one empty default list
+func NewWishlist() *Wishlist {
+	now := time.Now()
+	return &Wishlist{
+		id: uuid.New().String(),
+		lists: []*WishlistList{{
+			id:        uuid.New().String(),
+			name:      DefaultWishlistListName,
+			items:     make([]*WishlistItem, 0),
+			createdAt: now,
+		}},
+		createdAt: now,
+		updatedAt: now,
+	}
+}
+
+// ReconstructWishlist reconstructs a Wishlist from persistence
+func ReconstructWishlist(id string, lists []*WishlistList, createdAt, updatedAt time.Time) *Wishlist {
+	return &Wishlist{
+		id:        id,
+		lists:     lists,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// ID returns the wishlist ID
+func (w *Wishlist) ID() string {
+	return w.id
+}
+
+// Lists returns the named lists in the order they were created
+func (w *Wishlist) Lists() []*WishlistList {
+	return w.lists
+}
+
+// CreatedAt returns the creation time
+func (w *Wishlist) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// UpdatedAt returns the last update time
+func (w *Wishlist) UpdatedAt() time.Time {
+	return w.updatedAt
+}
+
+// List returns a list by ID
+func (w *Wishlist) List(listID string) (*WishlistList, error) {
+	for _, list := range w.lists {
+		if list.id == listID {
+			return list, nil
+		}
+	}
+	return nil, errors.New("wishlist list not found")
+}
+
+// ListByShareToken returns the list shared with token
+func (w *Wishlist) ListByShareToken(token string) (*WishlistList, error) {
+	for _, list := range w.lists {
+		if token != "" && list.shareToken == token {
+			return list, nil
+		}
+	}
+	return nil, errors.New("wishlist list not found")
+}
+
+// AddList adds an empty named list
+func (w *Wishlist) AddList(name string) (*WishlistList, error) {
+	if len(w.lists) >= maxWishlistLists {
+		return nil, fmt.Errorf("a wishlist cannot have more than %d lists", maxWishlistLists)
+	}
+	name, err := w.checkListName("", name)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &WishlistList{
+		id:        uuid.New().String(),
+		name:      name,
+		items:     make([]*WishlistItem, 0),
+		createdAt: time.Now(),
+	}
+	w.lists = append(w.lists, list)
+	w.updatedAt = time.Now()
+	return list, nil
+}
+
+// RenameList changes the name of a list
+func (w *Wishlist) RenameList(listID, name string) error {
+	list, err := w.List(listID)
+	if err != nil {
+		return err
+	}
+	name, err = w.checkListName(listID, name)
+	if err != nil {
+		return err
+	}
+
+	list.name = name
+	w.updatedAt = time.Now()
+	return nil
+}
+
+// RemoveList removes a list and its items. The last list cannot be removed.
+func (w *Wishlist) RemoveList(listID string) error {
+	for i, list := range w.lists {
+		if list.id == listID {
+			if len(w.lists) == 1 {
+				return errors.New("cannot remove the only list of a wishlist")
+			}
+			w.lists = append(w.lists[:i], w.lists[i+1:]...)
+			w.updatedAt = time.Now()
+			return nil
+		}
+	}
+	return errors.New("wishlist list not found")
+}
+
+// AddItem saves a product variant to a list. Saving an item the list already holds
+// keeps it in place and only changes its back-in-stock alert.
+func (w *Wishlist) AddItem(listID, productID, variantID string, notifyBackInStock bool) error {
+	if productID == "" {
+		return errors.New("product ID cannot be empty")
+	}
+	list, err := w.List(listID)
+	if err != nil {
+		return err
+	}
+
+	if item := list.findItem(productID, variantID); item != nil {
+		item.notifyBackInStock = notifyBackInStock
+		w.updatedAt = time.Now()
+		return nil
+	}
+	if len(list.items) >= maxWishlistItems {
+		return fmt.Errorf("a wishlist list cannot hold more than %d items", maxWishlistItems)
+	}
+
+	list.items = append(list.items, &WishlistItem{
+		productID:         productID,
+		variantID:         variantID,
+		notifyBackInStock: notifyBackInStock,
+		addedAt:           time.Now(),
+	})
+	w.updatedAt = time.Now()
+	return nil
+}
+
+// RemoveItem removes a product variant from a list
+func (w *Wishlist) RemoveItem(listID, productID, variantID string) error {
+	list, err := w.List(listID)
+	if err != nil {
+		return err
+	}
+
+	for i, item := range list.items {
+		if item.matches(productID, variantID) {
+			list.items = append(list.items[:i], list.items[i+1:]...)
+			w.updatedAt = time.Now()
+			return nil
+		}
+	}
+	return errors.New("item not found in wishlist")
+}
+
+// SetBackInStockAlert turns the back-in-stock alert of a saved item on or off
+func (w *Wishlist) SetBackInStockAlert(listID, productID, variantID string, notify bool) error {
+	list, err := w.List(listID)
+	if err != nil {
+		return err
+	}
+
+	item := list.findItem(productID, variantID)
+	if item == nil {
+		return errors.New("item not found in wishlist")
+	}
+	item.notifyBackInStock = notify
+	w.updatedAt = time.Now()
+	return nil
+}
+
+// ShareList makes a list viewable by a public token and returns the token.
+// Sharing a list that is already shared keeps its token.
+func (w *Wishlist) ShareList(listID string) (string, error) {
+	list, err := w.List(listID)
+	if err != nil {
+		return "", err
+	}
+	if list.shareToken != "" {
+		return list.shareToken, nil
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return "", err
+	}
+	list.shareToken = token
+	w.updatedAt = time.Now()
+	return token, nil
+}
+
+// UnshareList makes a list private again; its old token stops working
+func (w *Wishlist) UnshareList(listID string) error {
+	list, err := w.List(listID)
+	if err != nil {
+		return err
+	}
+
+	list.shareToken = ""
+	w.updatedAt = time.Now()
+	return nil
+}
+
+// checkListName trims a list name and checks it is valid and unused by any list but exceptID
+func (w *Wishlist) checkListName(exceptID, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("list name cannot be empty")
+	}
+	if len(name) > maxWishlistListNameSize {
+		return "", fmt.Errorf("list name cannot be longer than %d characters", maxWishlistListNameSize)
+	}
+	for _, list := range w.lists {
+		if list.id != exceptID && strings.EqualFold(list.name, name) {
+			return "", fmt.Errorf("a list named %q already exists", list.name)
+		}
+	}
+	return name, nil
+}
+
+// newShareToken returns an unguessable URL-safe token
+func newShareToken() (string, error) {
+	buf := make([]byte, 18)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
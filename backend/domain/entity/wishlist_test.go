@@ -0,0 +1,134 @@
+package entity
+
+import "testing"
+
+func TestNewWishlist(t *testing.T) {
+	wishlist := NewWishlist()
+
+	if wishlist.ID() == "" {
+		t.Error("expected wishlist to have an ID")
+	}
+	if len(wishlist.Lists()) != 1 {
+		t.Fatalf("expected 1 default list, got %d", len(wishlist.Lists()))
+	}
+	if wishlist.Lists()[0].Name() != DefaultWishlistListName {
+		t.Errorf("expected default list name %q, got %q", DefaultWishlistListName, wishlist.Lists()[0].Name())
+	}
+}
+
+func TestWishlist_AddList(t *testing.T) {
+	wishlist := NewWishlist()
+
+	list, err := wishlist.AddList("  Birthday ideas ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list.Name() != "Birthday ideas" {
+		t.Errorf("expected trimmed name, got %q", list.Name())
+	}
+
+	if _, err := wishlist.AddList("birthday IDEAS"); err == nil {
+		t.Error("expected error for a duplicate list name")
+	}
+	if _, err := wishlist.AddList(" "); err == nil {
+		t.Error("expected error for an empty list name")
+	}
+	if err := wishlist.RenameList(list.ID(), "Birthday Ideas"); err != nil {
+		t.Errorf("expected a list to keep its own name with a different case: %v", err)
+	}
+	if err := wishlist.RenameList(list.ID(), DefaultWishlistListName); err == nil {
+		t.Error("expected error renaming to another list's name")
+	}
+}
+
+func TestWishlist_RemoveList(t *testing.T) {
+	wishlist := NewWishlist()
+	defaultID := wishlist.Lists()[0].ID()
+
+	if err := wishlist.RemoveList(defaultID); err == nil {
+		t.Error("expected error removing the only list")
+	}
+
+	list, _ := wishlist.AddList("Later")
+	if err := wishlist.RemoveList(defaultID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(wishlist.Lists()) != 1 || wishlist.Lists()[0].ID() != list.ID() {
+		t.Error("expected only the new list to remain")
+	}
+	if err := wishlist.RemoveList("missing"); err == nil {
+		t.Error("expected error for an unknown list")
+	}
+}
+
+func TestWishlist_AddItem(t *testing.T) {
+	wishlist := NewWishlist()
+	listID := wishlist.Lists()[0].ID()
+
+	if err := wishlist.AddItem(listID, "product-1", "", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := wishlist.AddItem(listID, "product-2", "variant-1", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Saving the same item again only changes its alert
+	if err := wishlist.AddItem(listID, "product-1", "", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items := wishlist.Lists()[0].Items()
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	if items[0].ProductID() != "product-1" || !items[0].NotifyBackInStock() {
+		t.Error("expected product-1 to keep its place with the alert turned on")
+	}
+
+	if err := wishlist.SetBackInStockAlert(listID, "product-2", "variant-1", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items[1].NotifyBackInStock() {
+		t.Error("expected the alert of product-2 to be off")
+	}
+
+	if err := wishlist.RemoveItem(listID, "product-2", ""); err == nil {
+		t.Error("expected error removing a variant that is not saved")
+	}
+	if err := wishlist.RemoveItem(listID, "product-2", "variant-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(wishlist.Lists()[0].Items()) != 1 {
+		t.Error("expected 1 item after removal")
+	}
+}
+
+func TestWishlist_ShareList(t *testing.T) {
+	wishlist := NewWishlist()
+	list := wishlist.Lists()[0]
+
+	token, err := wishlist.ShareList(list.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token == "" || !list.IsShared() {
+		t.Fatal("expected the list to be shared")
+	}
+
+	again, _ := wishlist.ShareList(list.ID())
+	if again != token {
+		t.Error("expected sharing again to keep the token")
+	}
+	if found, err := wishlist.ListByShareToken(token); err != nil || found.ID() != list.ID() {
+		t.Error("expected to find the list by its token")
+	}
+
+	if err := wishlist.UnshareList(list.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list.IsShared() {
+		t.Error("expected the list to be private")
+	}
+	if _, err := wishlist.ListByShareToken(token); err == nil {
+		t.Error("expected the old token to stop working")
+	}
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"ecom-backend/domain/entity"
+	"time"
 )
 
 // BasketRepository defines the interface for basket persistence
@@ -19,6 +20,14 @@ type BasketRepository interface {
 	// Delete removes a basket
 	Delete(ctx context.Context, id string) error
 
+	// UpdateMerged updates a basket that another basket was merged into and deletes the
+	// merged basket, atomically
+	UpdateMerged(ctx context.Context, basket *entity.Basket, mergedID string) error
+
+	// DeleteIdle removes up to limit baskets not updated since before, oldest first,
+	// returning the deleted baskets with their items
+	DeleteIdle(ctx context.Context, before time.Time, limit int) ([]*entity.Basket, error)
+
 	// ExistsByID checks if a basket exists
 	ExistsByID(ctx context.Context, id string) (bool, error)
 }
@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrNotFound is wrapped by repository errors for a record that does not exist, as in
+// "basket not found"
+var ErrNotFound = errors.New("not found")
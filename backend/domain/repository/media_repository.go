@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrUnsupportedImage is returned by an ImageProcessor for data it cannot accept
+var ErrUnsupportedImage = errors.New("unsupported image")
+
+// BlobInfo describes a stored blob
+type BlobInfo struct {
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// BlobStore defines the interface for binary media storage
+type BlobStore interface {
+	// Put stores data under the given key, replacing any existing blob
+	Put(ctx context.Context, key, contentType string, data io.Reader) error
+
+	// Open returns a reader for the blob stored under the key
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, *BlobInfo, error)
+
+	// Delete removes the blob stored under the key
+	Delete(ctx context.Context, key string) error
+
+	// URL returns the public URL the blob is served from
+	URL(key string) string
+}
+
+// Thumbnail is a resized rendering of an uploaded image
+type Thumbnail struct {
+	Name        string
+	Width       int
+	Height      int
+	ContentType string
+	Extension   string
+	Data        []byte
+}
+
+// ProcessedImage is a validated upload along with its generated thumbnails
+type ProcessedImage struct {
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Thumbnails  []Thumbnail
+}
+
+// ImageProcessor defines the interface for validating images and generating thumbnails
+type ImageProcessor interface {
+	// Process sniffs and decodes an uploaded image and generates its thumbnails
+	Process(data []byte) (*ProcessedImage, error)
+}
@@ -3,13 +3,22 @@ package repository
 import (
 	"context"
 	"ecom-backend/domain/entity"
+	"errors"
 )
 
+// ErrInsufficientStock is returned by OrderRepository.Place when an item cannot be taken from stock
+var ErrInsufficientStock = errors.New("insufficient stock")
+
 // OrderRepository defines the interface for order persistence
 type OrderRepository interface {
 	// Save persists an order
 	Save(ctx context.Context, order *entity.Order) error
 
+	// Place saves a new order and takes each item's quantity from its product's or variant's
+	// stock in one transaction. Nothing is saved when an item's product is archived or has too
+	// little stock left; the error then wraps ErrInsufficientStock.
+	Place(ctx context.Context, order *entity.Order) error
+
 	// FindByID retrieves an order by ID
 	FindByID(ctx context.Context, id string) (*entity.Order, error)
 
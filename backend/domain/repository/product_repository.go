@@ -10,18 +10,75 @@ type ProductRepository interface {
 	// Save persists a product
 	Save(ctx context.Context, product *entity.Product) error
 
-	// FindByID retrieves a product by ID
+	// FindByID retrieves a product by ID, including archived products
 	FindByID(ctx context.Context, id string) (*entity.Product, error)
 
-	// FindAll retrieves all products
+	// FindByIDs retrieves the products with the given IDs, including archived products.
+	// IDs that do not exist are skipped.
+	FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error)
+
+	// FindAll retrieves all active products
 	FindAll(ctx context.Context) ([]*entity.Product, error)
 
+	// FindArchived retrieves all archived products
+	FindArchived(ctx context.Context) ([]*entity.Product, error)
+
+	// FindBySKU retrieves a product by its own SKU, including archived products
+	FindBySKU(ctx context.Context, sku string) (*entity.Product, error)
+
+	// FindAfter retrieves up to limit active products ordered by ID, starting after afterID
+	FindAfter(ctx context.Context, afterID string, limit int) ([]*entity.Product, error)
+
+	// FindLowStock retrieves active products with a low-stock threshold whose stock, or the
+	// stock of any of their variants, is at or below it
+	FindLowStock(ctx context.Context) ([]*entity.Product, error)
+
 	// Update updates an existing product
 	Update(ctx context.Context, product *entity.Product) error
 
-	// Delete removes a product
+	// AddVariant saves a new variant of a product
+	AddVariant(ctx context.Context, productID string, variant *entity.ProductVariant) error
+
+	// UpdateVariant saves a variant's barcode and price override without touching its stock
+	UpdateVariant(ctx context.Context, productID string, variant *entity.ProductVariant) error
+
+	// UpdateVariantStock saves the stock of one variant
+	UpdateVariantStock(ctx context.Context, productID string, variant *entity.ProductVariant) error
+
+	// RemoveVariant deletes a variant of a product
+	RemoveVariant(ctx context.Context, productID, variantID string) error
+
+	// UpdateReorderPolicy saves a product's low-stock threshold and reorder quantity
+	// without touching its stock
+	UpdateReorderPolicy(ctx context.Context, product *entity.Product) error
+
+	// AddImage appends an image to a product's images, making it primary when the product has none
+	AddImage(ctx context.Context, productID string, image *entity.ProductImage) error
+
+	// DeleteImage removes an image from a product; if it was primary, the next image in order
+	// becomes primary
+	DeleteImage(ctx context.Context, productID, imageID string) error
+
+	// ReorderImages sets the display order of a product's images. Images not listed keep
+	// their relative order after the listed ones.
+	ReorderImages(ctx context.Context, productID string, imageIDs []string) error
+
+	// SetPrimaryImage marks one of a product's images as primary
+	SetPrimaryImage(ctx context.Context, productID, imageID string) error
+
+	// Archive saves an archived product and removes it from every basket in one transaction,
+	// returning the number of baskets changed
+	Archive(ctx context.Context, product *entity.Product) (int, error)
+
+	// Delete permanently removes a product
 	Delete(ctx context.Context, id string) error
 
 	// ExistsByID checks if a product exists
 	ExistsByID(ctx context.Context, id string) (bool, error)
+
+	// IsReferencedByOrders checks if any order item refers to the product
+	IsReferencedByOrders(ctx context.Context, id string) (bool, error)
+
+	// ExistsBySKU checks if a product or variant uses the given SKU
+	ExistsBySKU(ctx context.Context, sku string) (bool, error)
 }
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"ecom-backend/domain/entity"
+)
+
+// ProductSearchQuery describes a full-text product search
+type ProductSearchQuery struct {
+	Text     string
+	Category string
+	MinPrice *int64 // inclusive, in cents
+	MaxPrice *int64 // inclusive, in cents
+	InStock  *bool
+	Limit    int
+	Offset   int
+}
+
+// ProductSearchHit is a single ranked search result
+type ProductSearchHit struct {
+	Product            *entity.Product
+	Rank               float64
+	NameSnippet        string // HTML-escaped name with matched terms wrapped in <mark></mark>
+	DescriptionSnippet string // HTML-escaped description fragment with matched terms wrapped in <mark></mark>
+}
+
+// FacetCount is the number of matching products for a facet value
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// ProductSearchFacets holds facet counts over all matching products
+type ProductSearchFacets struct {
+	Categories   []FacetCount
+	PriceBands   []FacetCount
+	Availability []FacetCount
+}
+
+// ProductSearchResult is a page of ranked hits along with facet counts
+type ProductSearchResult struct {
+	Hits   []*ProductSearchHit
+	Total  int
+	Facets ProductSearchFacets
+}
+
+// PriceBand is a labelled price range used for faceting
+type PriceBand struct {
+	Label string
+	Min   int64 // inclusive, in cents
+	Max   int64 // exclusive, in cents; zero means unbounded
+}
+
+// Contains checks if an amount falls within the band
+func (b PriceBand) Contains(amount int64) bool {
+	return amount >= b.Min && (b.Max == 0 || amount < b.Max)
+}
+
+// DefaultPriceBands are the price bands reported in search facets
+var DefaultPriceBands = []PriceBand{
+	{Label: "0-10", Min: 0, Max: 1000},
+	{Label: "10-25", Min: 1000, Max: 2500},
+	{Label: "25-50", Min: 2500, Max: 5000},
+	{Label: "50-100", Min: 5000, Max: 10000},
+	{Label: "100+", Min: 10000},
+}
+
+// Availability facet values
+const (
+	AvailabilityInStock    = "in_stock"
+	AvailabilityOutOfStock = "out_of_stock"
+)
+
+// ProductSearchRepository defines the interface for full-text product search
+type ProductSearchRepository interface {
+	// Search returns products matching the query, ordered by relevance
+	Search(ctx context.Context, query *ProductSearchQuery) (*ProductSearchResult, error)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"ecom-backend/domain/entity"
+)
+
+// ReviewSort orders a list of reviews
+type ReviewSort string
+
+const (
+	ReviewSortNewest  ReviewSort = "newest"
+	ReviewSortOldest  ReviewSort = "oldest"
+	ReviewSortHighest ReviewSort = "highest"
+	ReviewSortLowest  ReviewSort = "lowest"
+)
+
+// ReviewQuery selects a page of reviews
+type ReviewQuery struct {
+	ProductID string              // empty for every product
+	Status    entity.ReviewStatus // empty for every status
+	Sort      ReviewSort
+	Limit     int
+	Offset    int
+}
+
+// ReviewRepository defines the interface for review persistence
+type ReviewRepository interface {
+	// Save persists a new review
+	Save(ctx context.Context, review *entity.Review) error
+
+	// FindByID retrieves a review by ID
+	FindByID(ctx context.Context, id string) (*entity.Review, error)
+
+	// Find returns a page of the reviews matching the query and the number of matches
+	Find(ctx context.Context, query ReviewQuery) ([]*entity.Review, int, error)
+
+	// ExistsForOrder checks if a review of the product was already written for the order
+	ExistsForOrder(ctx context.Context, productID, orderID string) (bool, error)
+
+	// UpdateStatus saves a moderation decision and recalculates the rating count and
+	// total of the review's product from its approved reviews, atomically
+	UpdateStatus(ctx context.Context, review *entity.Review) error
+}
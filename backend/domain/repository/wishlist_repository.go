@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"ecom-backend/domain/entity"
+	"time"
+)
+
+// BackInStockAlert is a queued notice that a product or variant saved to a wishlist list,
+// with its alert turned on, is back in stock
+type BackInStockAlert struct {
+	ID         int64
+	WishlistID string
+	ListID     string
+	ProductID  string
+	VariantID  string
+	Stock      int
+	QueuedAt   time.Time
+	Attempts   int
+}
+
+// WishlistRepository defines the interface for wishlist persistence
+type WishlistRepository interface {
+	// Save persists a wishlist
+	Save(ctx context.Context, wishlist *entity.Wishlist) error
+
+	// FindByID retrieves a wishlist by ID
+	FindByID(ctx context.Context, id string) (*entity.Wishlist, error)
+
+	// FindByShareToken retrieves the wishlist holding the list shared with token
+	FindByShareToken(ctx context.Context, token string) (*entity.Wishlist, error)
+
+	// Update updates an existing wishlist
+	Update(ctx context.Context, wishlist *entity.Wishlist) error
+
+	// QueueBackInStockAlerts turns off every back-in-stock alert for a product or variant and
+	// queues a notice for each list that had one, together so each alert is queued only once.
+	// It returns the number queued.
+	QueueBackInStockAlerts(ctx context.Context, productID, variantID string, stock int) (int, error)
+
+	// ProcessBackInStockAlerts locks up to limit queued notices, oldest first, skipping any another
+	// instance holds, and passes them to deliver. The notices deliver reports done are removed,
+	// the ones it reports failed stay queued with another attempt counted, all in one transaction.
+	ProcessBackInStockAlerts(ctx context.Context, limit int, deliver func(notices []BackInStockAlert) (done, failed []int64)) error
+}
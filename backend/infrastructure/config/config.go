@@ -0,0 +1,425 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Config is the complete application configuration.
+//
+// Every leaf field can be set from the config file (by its yaml key), from the
+// environment variable in its env tag (after the env prefix of its section, if
+// any), and from a command-line flag named after
+// its dotted yaml path, such as -database.max_open_conns. Fields tagged secret
+// are redacted when the configuration is printed.
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	CORS      CORSConfig      `yaml:"cors"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Workers   WorkersConfig   `yaml:"workers"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	GraphQL   GraphQLConfig   `yaml:"graphql"`
+	GRPC      GRPCConfig      `yaml:"grpc"`
+	Events    EventsConfig    `yaml:"events"`
+	Alerts    AlertsConfig    `yaml:"alerts"`
+	Log       LogConfig       `yaml:"log"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Media     MediaConfig     `yaml:"media"`
+	Health    HealthConfig    `yaml:"health"`
+}
+
+// ServerConfig holds HTTP server settings
+type ServerConfig struct {
+	Port              int           `yaml:"port" env:"PORT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes" env:"HTTP_MAX_HEADER_BYTES"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	DrainDelay        time.Duration `yaml:"drain_delay" env:"SHUTDOWN_DRAIN_DELAY"`
+}
+
+// DatabaseConfig holds PostgreSQL connection and pool settings
+type DatabaseConfig struct {
+	Host            string        `yaml:"host" env:"DB_HOST"`
+	Port            int           `yaml:"port" env:"DB_PORT"`
+	User            string        `yaml:"user" env:"DB_USER"`
+	Password        string        `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name            string        `yaml:"name" env:"DB_NAME"`
+	SSLMode         string        `yaml:"sslmode" env:"DB_SSLMODE"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+}
+
+// CORSConfig holds cross-origin settings
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods   []string      `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	AllowedHeaders   []string      `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	ExposedHeaders   []string      `yaml:"exposed_headers" env:"CORS_EXPOSED_HEADERS"`
+	AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+	MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE"`
+}
+
+// AuthConfig holds API key settings
+type AuthConfig struct {
+	APIKeys       []string `yaml:"api_keys" env:"AUTH_API_KEYS" secret:"true"`
+	APIKeyHeader  string   `yaml:"api_key_header" env:"AUTH_API_KEY_HEADER"`
+	RequireAPIKey bool     `yaml:"require_api_key" env:"AUTH_REQUIRE_API_KEY"`
+}
+
+// RateLimitConfig holds per-client request limits for each route group
+type RateLimitConfig struct {
+	Enabled           bool          `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	TrustForwardedFor bool          `yaml:"trust_forwarded_for" env:"RATE_LIMIT_TRUST_FORWARDED_FOR"`
+	TrustedProxies    int           `yaml:"trusted_proxies" env:"RATE_LIMIT_TRUSTED_PROXIES"`
+	Default           RateLimitRule `yaml:"default" env:"RATE_LIMIT_DEFAULT_"`
+	Basket            RateLimitRule `yaml:"basket" env:"RATE_LIMIT_BASKET_"`
+	Checkout          RateLimitRule `yaml:"checkout" env:"RATE_LIMIT_CHECKOUT_"`
+}
+
+// RateLimitRule is a token-bucket limit; zero requests per minute disables the group's limit
+type RateLimitRule struct {
+	RequestsPerMinute int `yaml:"requests_per_minute" env:"RPM"`
+	Burst             int `yaml:"burst" env:"BURST"`
+}
+
+// GraphQLConfig holds GraphQL query limits
+type GraphQLConfig struct {
+	MaxDepth      int `yaml:"max_depth" env:"GRAPHQL_MAX_DEPTH"`
+	MaxComplexity int `yaml:"max_complexity" env:"GRAPHQL_MAX_COMPLEXITY"`
+}
+
+// GRPCConfig holds gRPC server settings
+type GRPCConfig struct {
+	Enabled bool `yaml:"enabled" env:"GRPC_ENABLED"`
+	Port    int  `yaml:"port" env:"GRPC_PORT"`
+}
+
+// EventsConfig holds Server-Sent Events settings
+type EventsConfig struct {
+	ReplayBuffer      int           `yaml:"replay_buffer" env:"EVENTS_REPLAY_BUFFER"`
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval" env:"EVENTS_HEARTBEAT_INTERVAL"`
+}
+
+// AlertsConfig holds low-stock alert delivery settings
+type AlertsConfig struct {
+	Notifiers []string           `yaml:"notifiers" env:"ALERTS_NOTIFIERS"`
+	QueueSize int                `yaml:"queue_size" env:"ALERTS_QUEUE_SIZE"`
+	Timeout   time.Duration      `yaml:"timeout" env:"ALERTS_TIMEOUT"`
+	Webhook   AlertWebhookConfig `yaml:"webhook" env:"ALERTS_WEBHOOK_"`
+	Email     AlertEmailConfig   `yaml:"email" env:"ALERTS_EMAIL_"`
+}
+
+// AlertWebhookConfig holds the webhook alerts are posted to
+type AlertWebhookConfig struct {
+	URL string `yaml:"url" env:"URL" secret:"true"`
+}
+
+// AlertEmailConfig holds the SMTP server and addresses alerts are emailed with
+type AlertEmailConfig struct {
+	SMTPAddr string   `yaml:"smtp_addr" env:"SMTP_ADDR"`
+	Username string   `yaml:"username" env:"USERNAME"`
+	Password string   `yaml:"password" env:"PASSWORD" secret:"true"`
+	From     string   `yaml:"from" env:"FROM"`
+	To       []string `yaml:"to" env:"TO"`
+}
+
+// WorkersConfig holds background worker settings
+type WorkersConfig struct {
+	Enabled          bool               `yaml:"enabled" env:"WORKERS_ENABLED"`
+	HeartbeatTimeout time.Duration      `yaml:"heartbeat_timeout" env:"WORKERS_HEARTBEAT_TIMEOUT"`
+	BasketExpiry     BasketExpiryConfig `yaml:"basket_expiry" env:"WORKERS_BASKET_EXPIRY_"`
+	BackInStock      BackInStockConfig  `yaml:"back_in_stock" env:"WORKERS_BACK_IN_STOCK_"`
+}
+
+// BasketExpiryConfig holds the idle basket cleanup job settings
+type BasketExpiryConfig struct {
+	Enabled   bool          `yaml:"enabled" env:"ENABLED"`
+	TTL       time.Duration `yaml:"ttl" env:"TTL"`
+	Interval  time.Duration `yaml:"interval" env:"INTERVAL"`
+	BatchSize int           `yaml:"batch_size" env:"BATCH_SIZE"`
+}
+
+// BackInStockConfig holds the queued back-in-stock alert delivery job settings
+type BackInStockConfig struct {
+	Enabled   bool          `yaml:"enabled" env:"ENABLED"`
+	Interval  time.Duration `yaml:"interval" env:"INTERVAL"`
+	BatchSize int           `yaml:"batch_size" env:"BATCH_SIZE"`
+}
+
+// LogConfig holds logging settings
+type LogConfig struct {
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+	Level  string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+// TracingConfig holds span export settings
+type TracingConfig struct {
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	File     string `yaml:"file" env:"TRACING_FILE"`
+}
+
+// MediaConfig holds product image storage settings
+type MediaConfig struct {
+	StorageDir     string `yaml:"storage_dir" env:"MEDIA_STORAGE_DIR"`
+	BaseURL        string `yaml:"base_url" env:"MEDIA_BASE_URL"`
+	ThumbnailSizes string `yaml:"thumbnail_sizes" env:"MEDIA_THUMBNAIL_SIZES"`
+	MaxUploadBytes int64  `yaml:"max_upload_bytes" env:"MEDIA_MAX_UPLOAD_BYTES"`
+	MaxPixels      int    `yaml:"max_pixels" env:"MEDIA_MAX_PIXELS"`
+}
+
+// HealthConfig holds health check settings
+type HealthConfig struct {
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT"`
+}
+
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:              8080,
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Password:        "postgres",
+			Name:            "ecom",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 5 * time.Minute,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+			AllowedHeaders: []string{"Content-Type", "Authorization", "X-API-Key", "X-Request-ID", "traceparent"},
+			ExposedHeaders: []string{
+				"X-Request-ID", "traceparent", "Location",
+				"Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy",
+			},
+			MaxAge: 10 * time.Minute,
+		},
+		Auth: AuthConfig{
+			APIKeyHeader: "X-API-Key",
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:        true,
+			TrustedProxies: 1,
+			Default:        RateLimitRule{RequestsPerMinute: 600, Burst: 100},
+			Basket:         RateLimitRule{RequestsPerMinute: 120, Burst: 30},
+			Checkout:       RateLimitRule{RequestsPerMinute: 10, Burst: 5},
+		},
+		GraphQL: GraphQLConfig{
+			MaxDepth:      10,
+			MaxComplexity: 2000,
+		},
+		GRPC: GRPCConfig{
+			Enabled: true,
+			Port:    9090,
+		},
+		Events: EventsConfig{
+			ReplayBuffer:      1000,
+			HeartbeatInterval: 15 * time.Second,
+		},
+		Alerts: AlertsConfig{
+			Notifiers: []string{"log"},
+			QueueSize: 100,
+			Timeout:   10 * time.Second,
+			Email: AlertEmailConfig{
+				SMTPAddr: "localhost:1025",
+				From:     "inventory@localhost",
+			},
+		},
+		Workers: WorkersConfig{
+			Enabled:          true,
+			HeartbeatTimeout: 2 * time.Minute,
+			BasketExpiry: BasketExpiryConfig{
+				Enabled:   true,
+				TTL:       30 * 24 * time.Hour,
+				Interval:  time.Hour,
+				BatchSize: 500,
+			},
+			BackInStock: BackInStockConfig{
+				Enabled:   true,
+				Interval:  time.Minute,
+				BatchSize: 100,
+			},
+		},
+		Log: LogConfig{
+			Format: "json",
+			Level:  "info",
+		},
+		Tracing: TracingConfig{
+			Exporter: "none",
+			File:     "traces.jsonl",
+		},
+		Media: MediaConfig{
+			StorageDir:     "./uploads",
+			BaseURL:        "http://localhost:8080/api/v1/media",
+			ThumbnailSizes: "small:150,medium:400,large:800",
+			MaxUploadBytes: 10 << 20,
+			MaxPixels:      40_000_000,
+		},
+		Health: HealthConfig{
+			CheckTimeout: 2 * time.Second,
+		},
+	}
+}
+
+// Validate checks every setting and reports all problems at once
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "server.port must be between 1 and 65535, got %d", c.Server.Port)
+	check(c.Server.ReadTimeout > 0, "server.read_timeout must be positive")
+	check(c.Server.ReadHeaderTimeout > 0, "server.read_header_timeout must be positive")
+	check(c.Server.WriteTimeout > 0, "server.write_timeout must be positive")
+	check(c.Server.IdleTimeout > 0, "server.idle_timeout must be positive")
+	check(c.Server.MaxHeaderBytes >= 4096, "server.max_header_bytes must be at least 4096, got %d", c.Server.MaxHeaderBytes)
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	check(c.Server.DrainDelay >= 0 && c.Server.DrainDelay < c.Server.ShutdownTimeout,
+		"server.drain_delay must be non-negative and shorter than server.shutdown_timeout")
+
+	check(c.Database.Host != "", "database.host is required")
+	check(c.Database.Port > 0 && c.Database.Port <= 65535, "database.port must be between 1 and 65535, got %d", c.Database.Port)
+	check(c.Database.User != "", "database.user is required")
+	check(c.Database.Name != "", "database.name is required")
+	check(oneOf(c.Database.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full"),
+		"database.sslmode must be one of disable, allow, prefer, require, verify-ca or verify-full, got %q", c.Database.SSLMode)
+	check(c.Database.MaxOpenConns > 0, "database.max_open_conns must be positive, got %d", c.Database.MaxOpenConns)
+	check(c.Database.MaxIdleConns >= 0 && c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns must be between 0 and database.max_open_conns, got %d", c.Database.MaxIdleConns)
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime must not be negative")
+	check(c.Database.ConnMaxIdleTime >= 0, "database.conn_max_idle_time must not be negative")
+
+	check(len(c.CORS.AllowedOrigins) > 0, "cors.allowed_origins must list at least one origin")
+	for _, origin := range c.CORS.AllowedOrigins {
+		check(origin == "*" || validOrigin(origin),
+			"cors.allowed_origins: %q is not an origin such as https://shop.example.com or https://*.example.com", origin)
+		check(origin != "*" || !c.CORS.AllowCredentials, "cors.allow_credentials cannot be combined with the \"*\" origin; list the origins instead")
+	}
+	check(len(c.CORS.AllowedMethods) > 0, "cors.allowed_methods must list at least one method")
+	for _, method := range c.CORS.AllowedMethods {
+		check(oneOf(strings.ToUpper(method), "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"), "cors.allowed_methods: unknown method %q", method)
+	}
+	check(c.CORS.MaxAge >= 0, "cors.max_age must not be negative")
+
+	check(c.Auth.APIKeyHeader != "", "auth.api_key_header is required")
+	check(!c.Auth.RequireAPIKey || len(c.Auth.APIKeys) > 0, "auth.api_keys must not be empty when auth.require_api_key is set")
+	for _, key := range c.Auth.APIKeys {
+		check(len(key) >= 16, "auth.api_keys entries must be at least 16 characters")
+	}
+
+	check(!c.RateLimit.TrustForwardedFor || c.RateLimit.TrustedProxies > 0,
+		"rate_limit.trusted_proxies must be positive when rate_limit.trust_forwarded_for is set")
+	for name, rule := range map[string]RateLimitRule{
+		"default":  c.RateLimit.Default,
+		"basket":   c.RateLimit.Basket,
+		"checkout": c.RateLimit.Checkout,
+	} {
+		check(rule.RequestsPerMinute >= 0, "rate_limit.%s.requests_per_minute must not be negative", name)
+		check(rule.RequestsPerMinute == 0 || rule.Burst > 0, "rate_limit.%s.burst must be positive", name)
+	}
+
+	check(c.GraphQL.MaxDepth > 0, "graphql.max_depth must be positive, got %d", c.GraphQL.MaxDepth)
+	check(c.GraphQL.MaxComplexity > 0, "graphql.max_complexity must be positive, got %d", c.GraphQL.MaxComplexity)
+
+	if c.GRPC.Enabled {
+		check(c.GRPC.Port > 0 && c.GRPC.Port <= 65535, "grpc.port must be between 1 and 65535, got %d", c.GRPC.Port)
+		check(c.GRPC.Port != c.Server.Port, "grpc.port must differ from server.port")
+	}
+
+	check(c.Events.ReplayBuffer >= 0, "events.replay_buffer cannot be negative, got %d", c.Events.ReplayBuffer)
+	check(c.Events.HeartbeatInterval > 0, "events.heartbeat_interval must be positive")
+
+	for _, notifier := range c.Alerts.Notifiers {
+		check(oneOf(notifier, "log", "webhook", "email"), "alerts.notifiers: unknown notifier %q: expected log, webhook or email", notifier)
+	}
+	check(c.Alerts.QueueSize > 0, "alerts.queue_size must be positive, got %d", c.Alerts.QueueSize)
+	check(c.Alerts.Timeout > 0, "alerts.timeout must be positive")
+	if slices.Contains(c.Alerts.Notifiers, "webhook") {
+		check(validURL(c.Alerts.Webhook.URL), "alerts.webhook.url must be an absolute http(s) URL when the webhook notifier is on")
+	}
+	if slices.Contains(c.Alerts.Notifiers, "email") {
+		_, _, err := net.SplitHostPort(c.Alerts.Email.SMTPAddr)
+		check(err == nil, "alerts.email.smtp_addr must be a host:port, got %q", c.Alerts.Email.SMTPAddr)
+		check(strings.Contains(c.Alerts.Email.From, "@"), "alerts.email.from must be an email address, got %q", c.Alerts.Email.From)
+		check(len(c.Alerts.Email.To) > 0, "alerts.email.to must list at least one address when the email notifier is on")
+	}
+
+	check(c.Workers.HeartbeatTimeout > 0, "workers.heartbeat_timeout must be positive")
+	if c.Workers.BasketExpiry.Enabled {
+		check(c.Workers.BasketExpiry.TTL >= time.Hour, "workers.basket_expiry.ttl must be at least 1h")
+		check(c.Workers.BasketExpiry.Interval > 0, "workers.basket_expiry.interval must be positive")
+		check(c.Workers.BasketExpiry.BatchSize > 0 && c.Workers.BasketExpiry.BatchSize <= 10000,
+			"workers.basket_expiry.batch_size must be between 1 and 10000, got %d", c.Workers.BasketExpiry.BatchSize)
+	}
+	if c.Workers.BackInStock.Enabled {
+		check(c.Workers.BackInStock.Interval > 0, "workers.back_in_stock.interval must be positive")
+		check(c.Workers.BackInStock.BatchSize > 0 && c.Workers.BackInStock.BatchSize <= 10000,
+			"workers.back_in_stock.batch_size must be between 1 and 10000, got %d", c.Workers.BackInStock.BatchSize)
+	}
+
+	check(oneOf(strings.ToLower(c.Log.Format), "json", "text"), "log.format must be json or text, got %q", c.Log.Format)
+	check(oneOf(strings.ToLower(c.Log.Level), "debug", "info", "warn", "error"), "log.level must be debug, info, warn or error, got %q", c.Log.Level)
+
+	check(oneOf(c.Tracing.Exporter, "none", "stdout", "file"), "tracing.exporter must be none, stdout or file, got %q", c.Tracing.Exporter)
+	check(c.Tracing.Exporter != "file" || c.Tracing.File != "", "tracing.file is required when tracing.exporter is file")
+
+	check(c.Media.StorageDir != "", "media.storage_dir is required")
+	check(validURL(c.Media.BaseURL), "media.base_url must be an absolute http(s) URL, got %q", c.Media.BaseURL)
+	check(c.Media.MaxUploadBytes > 0, "media.max_upload_bytes must be positive")
+	check(c.Media.MaxPixels > 0, "media.max_pixels must be positive")
+
+	check(c.Health.CheckTimeout > 0, "health.check_timeout must be positive")
+
+	return errors.Join(errs...)
+}
+
+// oneOf reports whether value is one of the allowed values
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+// validOrigin checks for a scheme and host with no path, allowing a leading "*." wildcard subdomain
+func validOrigin(origin string) bool {
+	if i := strings.Index(origin, "://*."); i > 0 {
+		origin = origin[:i+3] + origin[i+5:]
+	}
+	u, err := url.Parse(origin)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && !strings.Contains(u.Host, "*") &&
+		u.Path == "" && u.RawQuery == ""
+}
+
+// validURL checks for an absolute http(s) URL
+func validURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
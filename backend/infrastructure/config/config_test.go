@@ -0,0 +1,232 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// env returns a lookup function backed by a map
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("expected defaults to be valid: %v", err)
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  port: 9000
+  write_timeout: 1m
+database:
+  host: db.internal
+  port: 6000
+  max_open_conns: 50
+cors:
+  allowed_origins: [https://shop.example.com]
+`)
+
+	cfg, opts, err := Load(
+		[]string{"-config", path, "-database.port=7000"},
+		env(map[string]string{"DB_PORT": "6500", "DB_HOST": "env-db", "DB_USER": ""}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if opts.File != path {
+		t.Errorf("expected config file %s, got %s", path, opts.File)
+	}
+	if cfg.Server.Port != 9000 || cfg.Server.WriteTimeout != time.Minute {
+		t.Errorf("expected file values for server, got %+v", cfg.Server)
+	}
+	if cfg.Database.Host != "env-db" {
+		t.Errorf("expected environment to override file, got %q", cfg.Database.Host)
+	}
+	if cfg.Database.Port != 7000 {
+		t.Errorf("expected flag to override environment, got %d", cfg.Database.Port)
+	}
+	if cfg.Database.User != "postgres" {
+		t.Errorf("expected empty variable to be ignored, got %q", cfg.Database.User)
+	}
+	if cfg.Database.MaxOpenConns != 50 || cfg.Database.MaxIdleConns != 5 {
+		t.Errorf("expected file pool size with default idle conns, got %+v", cfg.Database)
+	}
+	if len(cfg.CORS.AllowedOrigins) != 1 || cfg.CORS.AllowedOrigins[0] != "https://shop.example.com" {
+		t.Errorf("unexpected origins %v", cfg.CORS.AllowedOrigins)
+	}
+}
+
+func TestLoad_ConfigFileFromEnvironment(t *testing.T) {
+	path := writeFile(t, "config.yml", "log:\n  level: debug\n")
+
+	cfg, _, err := Load(nil, env(map[string]string{"CONFIG_FILE": path}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Log.Level != "debug" {
+		t.Errorf("expected level from CONFIG_FILE, got %q", cfg.Log.Level)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		file    string
+		wantErr string
+	}{
+		{
+			name:    "malformed integer",
+			env:     map[string]string{"DB_PORT": "54x3"},
+			wantErr: `environment variable DB_PORT: invalid integer "54x3"`,
+		},
+		{
+			name:    "malformed duration",
+			args:    []string{"-server.idle_timeout=10"},
+			wantErr: `flag -server.idle_timeout: invalid duration "10"`,
+		},
+		{
+			name:    "unknown file key",
+			file:    "database:\n  hostname: db\n",
+			wantErr: "field hostname not found",
+		},
+		{
+			name:    "failed validation",
+			env:     map[string]string{"DB_MAX_OPEN_CONNS": "0", "LOG_FORMAT": "xml"},
+			wantErr: "database.max_open_conns must be positive",
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"-database.hostname=db"},
+			wantErr: "flag provided but not defined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if tt.file != "" {
+				args = append(args, "-config", writeFile(t, "config.yaml", tt.file))
+			}
+
+			_, _, err := Load(args, env(tt.env))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	cfg := Default()
+	cfg.Server.Port = 0
+	cfg.Database.MaxIdleConns = 100
+	cfg.CORS.AllowedOrigins = []string{"shop.example.com"}
+	cfg.Auth.RequireAPIKey = true
+	cfg.RateLimit.TrustForwardedFor = true
+	cfg.RateLimit.TrustedProxies = 0
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation to fail")
+	}
+	for _, want := range []string{"server.port", "database.max_idle_conns", "cors.allowed_origins", "auth.api_keys", "rate_limit.trusted_proxies"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %s, got:\n%v", want, err)
+		}
+	}
+}
+
+func TestWrite_RedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Password = "s3cret-password"
+	cfg.Auth.APIKeys = []string{"0123456789abcdef0123"}
+
+	var buf bytes.Buffer
+	if err := cfg.Write(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "s3cret-password") || strings.Contains(out, "0123456789abcdef0123") {
+		t.Errorf("expected secrets to be redacted:\n%s", out)
+	}
+	if !strings.Contains(out, "password: '[REDACTED]'") || !strings.Contains(out, "conn_max_lifetime: 5m0s") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	if cfg.Database.Password != "s3cret-password" || cfg.Auth.APIKeys[0] != "0123456789abcdef0123" {
+		t.Error("expected printing to leave the config unchanged")
+	}
+
+}
+
+func TestWrite_LoadsBack(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Default().Write(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path := writeFile(t, "printed.yaml", buf.String())
+	if _, _, err := Load([]string{"-config", path}, env(nil)); err != nil {
+		t.Errorf("expected printed config to load: %v", err)
+	}
+}
+
+func TestValidate_CORSOrigins(t *testing.T) {
+	cfg := Default()
+	cfg.CORS.AllowedOrigins = []string{"https://shop.example.com", "https://*.example.org", "http://localhost:3000"}
+	cfg.CORS.AllowCredentials = true
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected exact and wildcard origins to be valid: %v", err)
+	}
+
+	for _, origin := range []string{"*.example.org", "https://ex*ample.org", "https://shop.example.com/path", "*"} {
+		cfg.CORS.AllowedOrigins = []string{origin}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("expected %q to be rejected with credentials enabled", origin)
+		}
+	}
+}
+
+func TestValidate_Alerts(t *testing.T) {
+	cfg := Default()
+	cfg.Alerts.Notifiers = []string{"log", "webhook", "email", "sms"}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation to fail")
+	}
+	for _, want := range []string{`unknown notifier "sms"`, "alerts.webhook.url", "alerts.email.to"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %s, got:\n%v", want, err)
+		}
+	}
+
+	cfg.Alerts.Notifiers = []string{"webhook", "email"}
+	cfg.Alerts.Webhook.URL = "https://hooks.example.com/inventory"
+	cfg.Alerts.Email.To = []string{"buyer@example.com"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected webhook and email alerts to be valid: %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces secret values when the configuration is printed
+const redacted = "[REDACTED]"
+
+// Options are the command-line options that control loading itself
+type Options struct {
+	// File is the config file passed with -config or CONFIG_FILE
+	File string
+
+	// PrintConfig asks for the effective configuration to be printed instead of starting the server
+	PrintConfig bool
+}
+
+// Load builds the configuration from defaults, then the config file, then the
+// environment, then command-line flags, and validates the result. Errors name
+// the offending source and setting.
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, Options, error) {
+	var opts Options
+	cfg := Default()
+	fields := collectFields(cfg)
+
+	fs := flag.NewFlagSet("ecom-backend", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&opts.File, "config", "", "path to a YAML config file (or CONFIG_FILE)")
+	fs.BoolVar(&opts.PrintConfig, "print-config", false, "print the effective configuration with secrets redacted and exit")
+
+	// Flag values are recorded during parsing and applied after the file and environment
+	type flagValue struct {
+		field *field
+		raw   string
+	}
+	var flagValues []flagValue
+	for i := range fields {
+		f := &fields[i]
+		fs.Func(f.path, "overrides "+f.env, func(raw string) error {
+			flagValues = append(flagValues, flagValue{field: f, raw: raw})
+			return nil
+		})
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fs.SetOutput(os.Stderr)
+			fs.PrintDefaults()
+		}
+		return nil, opts, fmt.Errorf("invalid command-line arguments: %w", err)
+	}
+	if fs.NArg() > 0 {
+		return nil, opts, fmt.Errorf("unexpected command-line arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	if opts.File == "" {
+		opts.File, _ = lookupEnv("CONFIG_FILE")
+	}
+	if opts.File != "" {
+		if err := loadFile(cfg, opts.File); err != nil {
+			return nil, opts, err
+		}
+	}
+
+	var errs []error
+	for _, f := range fields {
+		// Empty variables are treated as unset, as with a blank line in an env file
+		raw, ok := lookupEnv(f.env)
+		if !ok || raw == "" {
+			continue
+		}
+		if err := f.set(raw); err != nil {
+			errs = append(errs, fmt.Errorf("environment variable %s: %w", f.env, err))
+		}
+	}
+	for _, fv := range flagValues {
+		if err := fv.field.set(fv.raw); err != nil {
+			errs = append(errs, fmt.Errorf("flag -%s: %w", fv.field.path, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, opts, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, opts, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	return cfg, opts, nil
+}
+
+// loadFile overlays a YAML config file, rejecting unknown keys
+func loadFile(cfg *Config, path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	default:
+		return fmt.Errorf("config file %s: unsupported format, expected .yaml or .yml", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Write prints the configuration as YAML with secret values redacted
+func (c *Config) Write(w io.Writer) error {
+	copied := *c
+	for _, f := range collectFields(&copied) {
+		if f.secret {
+			f.redact()
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&copied); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// field is a settable leaf of the configuration
+type field struct {
+	path   string
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// collectFields lists every leaf field of cfg with its dotted yaml path
+func collectFields(cfg *Config) []field {
+	var fields []field
+	var walk func(v reflect.Value, prefix, envPrefix string)
+	walk = func(v reflect.Value, prefix, envPrefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+			path := name
+			if prefix != "" {
+				path = prefix + "." + name
+			}
+
+			// An env tag on a nested section is a prefix for the section's variables
+			if sf.Type.Kind() == reflect.Struct && sf.Type != durationType {
+				walk(v.Field(i), path, envPrefix+sf.Tag.Get("env"))
+				continue
+			}
+
+			fields = append(fields, field{
+				path:   path,
+				env:    envPrefix + sf.Tag.Get("env"),
+				secret: sf.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "", "")
+	return fields
+}
+
+// set parses raw into the field's type
+func (f field) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	switch {
+	case f.value.Type() == durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q, expected a value such as 30s or 5m", raw)
+		}
+		f.value.SetInt(int64(d))
+	case f.value.Kind() == reflect.String:
+		f.value.SetString(raw)
+	case f.value.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q, expected true or false", raw)
+		}
+		f.value.SetBool(b)
+	case f.value.Kind() == reflect.Int || f.value.Kind() == reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		f.value.SetInt(n)
+	case f.value.Kind() == reflect.Slice && f.value.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		f.value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported setting type %s", f.value.Type())
+	}
+	return nil
+}
+
+// redact replaces a non-empty secret value
+func (f field) redact() {
+	switch f.value.Kind() {
+	case reflect.String:
+		if f.value.String() != "" {
+			f.value.SetString(redacted)
+		}
+	case reflect.Slice:
+		// Replace rather than modify the slice, which is shared with the original config
+		values := reflect.MakeSlice(f.value.Type(), f.value.Len(), f.value.Len())
+		for i := 0; i < values.Len(); i++ {
+			values.Index(i).SetString(redacted)
+		}
+		f.value.Set(values)
+	}
+}
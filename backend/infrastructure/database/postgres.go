@@ -1,11 +1,12 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // Config holds database configuration
@@ -16,6 +17,12 @@ type Config struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// Connection pool settings
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
 }
 
 // NewPostgresDB creates a new PostgreSQL database connection
@@ -25,15 +32,19 @@ func NewPostgresDB(cfg *Config) (*sql.DB, error) {
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
 	)
 
-	db, err := sql.Open("postgres", dsn)
+	connector, err := pq.NewConnector(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
+	// Wrap the driver so every query is recorded as a trace span
+	db := sql.OpenDB(tracedConnector{Connector: connector})
+
 	// Set connection pool settings
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	// Verify connection
 	if err := db.Ping(); err != nil {
@@ -43,71 +54,247 @@ func NewPostgresDB(cfg *Config) (*sql.DB, error) {
 	return db, nil
 }
 
-// RunMigrations executes database migrations
-func RunMigrations(db *sql.DB) error {
-	migrations := []string{
-		// Products table
-		`CREATE TABLE IF NOT EXISTS products (
-			id VARCHAR(36) PRIMARY KEY,
-			name VARCHAR(255) NOT NULL,
-			description TEXT,
-			price_amount BIGINT NOT NULL,
-			price_currency VARCHAR(3) NOT NULL,
-			stock INTEGER NOT NULL,
-			created_at TIMESTAMP NOT NULL,
-			updated_at TIMESTAMP NOT NULL
-		)`,
-
-		// Baskets table
-		`CREATE TABLE IF NOT EXISTS baskets (
-			id VARCHAR(36) PRIMARY KEY,
-			created_at TIMESTAMP NOT NULL,
-			updated_at TIMESTAMP NOT NULL
-		)`,
-
-		// Basket items table
-		`CREATE TABLE IF NOT EXISTS basket_items (
-			id SERIAL PRIMARY KEY,
-			basket_id VARCHAR(36) NOT NULL REFERENCES baskets(id) ON DELETE CASCADE,
-			product_id VARCHAR(36) NOT NULL,
-			quantity INTEGER NOT NULL,
-			price_amount BIGINT NOT NULL,
-			price_currency VARCHAR(3) NOT NULL,
-			UNIQUE(basket_id, product_id)
-		)`,
-
-		// Orders table
-		`CREATE TABLE IF NOT EXISTS orders (
-			id VARCHAR(36) PRIMARY KEY,
-			total_amount BIGINT NOT NULL,
-			total_currency VARCHAR(3) NOT NULL,
-			status VARCHAR(20) NOT NULL,
-			created_at TIMESTAMP NOT NULL,
-			updated_at TIMESTAMP NOT NULL
-		)`,
-
-		// Order items table
-		`CREATE TABLE IF NOT EXISTS order_items (
-			id SERIAL PRIMARY KEY,
-			order_id VARCHAR(36) NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
-			product_id VARCHAR(36) NOT NULL,
-			quantity INTEGER NOT NULL,
-			price_amount BIGINT NOT NULL,
-			price_currency VARCHAR(3) NOT NULL
-		)`,
-
-		// Indexes
-		`CREATE INDEX IF NOT EXISTS idx_basket_items_basket_id ON basket_items(basket_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items(order_id)`,
-		`CREATE INDEX IF NOT EXISTS idx_orders_status ON orders(status)`,
-		`CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders(created_at)`,
-	}
+// migrations are idempotent schema statements applied in order. New statements are only ever
+// appended, so the number of statements is the schema version.
+var migrations = []string{
+	// Products table
+	`CREATE TABLE IF NOT EXISTS products (
+		id VARCHAR(36) PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		description TEXT,
+		price_amount BIGINT NOT NULL,
+		price_currency VARCHAR(3) NOT NULL,
+		stock INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+
+	// Baskets table
+	`CREATE TABLE IF NOT EXISTS baskets (
+		id VARCHAR(36) PRIMARY KEY,
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+
+	// Basket items table
+	`CREATE TABLE IF NOT EXISTS basket_items (
+		id SERIAL PRIMARY KEY,
+		basket_id VARCHAR(36) NOT NULL REFERENCES baskets(id) ON DELETE CASCADE,
+		product_id VARCHAR(36) NOT NULL,
+		quantity INTEGER NOT NULL,
+		price_amount BIGINT NOT NULL,
+		price_currency VARCHAR(3) NOT NULL,
+		UNIQUE(basket_id, product_id)
+	)`,
+
+	// Orders table
+	`CREATE TABLE IF NOT EXISTS orders (
+		id VARCHAR(36) PRIMARY KEY,
+		total_amount BIGINT NOT NULL,
+		total_currency VARCHAR(3) NOT NULL,
+		status VARCHAR(20) NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+
+	// Order items table
+	`CREATE TABLE IF NOT EXISTS order_items (
+		id SERIAL PRIMARY KEY,
+		order_id VARCHAR(36) NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+		product_id VARCHAR(36) NOT NULL,
+		quantity INTEGER NOT NULL,
+		price_amount BIGINT NOT NULL,
+		price_currency VARCHAR(3) NOT NULL
+	)`,
+
+	// Indexes
+	`CREATE INDEX IF NOT EXISTS idx_basket_items_basket_id ON basket_items(basket_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items(order_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_orders_status ON orders(status)`,
+	`CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders(created_at)`,
+
+	// Product SKUs, options and variants
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS sku VARCHAR(64)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku ON products(sku) WHERE sku IS NOT NULL`,
+
+	`CREATE TABLE IF NOT EXISTS product_options (
+		product_id VARCHAR(36) NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		option_values TEXT[] NOT NULL,
+		PRIMARY KEY (product_id, name)
+	)`,
+
+	`CREATE TABLE IF NOT EXISTS product_variants (
+		id VARCHAR(36) PRIMARY KEY,
+		product_id VARCHAR(36) NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+		sku VARCHAR(64) NOT NULL UNIQUE,
+		barcode VARCHAR(64),
+		options JSONB NOT NULL,
+		price_amount BIGINT,
+		price_currency VARCHAR(3),
+		stock INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_product_variants_product_id ON product_variants(product_id)`,
+
+	// Basket and order lines reference a variant when the product has variants
+	`ALTER TABLE basket_items ADD COLUMN IF NOT EXISTS variant_id VARCHAR(36) NOT NULL DEFAULT ''`,
+	`ALTER TABLE basket_items DROP CONSTRAINT IF EXISTS basket_items_basket_id_product_id_key`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_basket_items_line ON basket_items(basket_id, product_id, variant_id)`,
+	`ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_id VARCHAR(36) NOT NULL DEFAULT ''`,
+
+	// Product categories and full-text search
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS category VARCHAR(100) NOT NULL DEFAULT ''`,
+	`CREATE INDEX IF NOT EXISTS idx_products_category ON products(category)`,
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(description, '')), 'B')
+		) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN(search_vector)`,
+
+	// Product images
+	`CREATE TABLE IF NOT EXISTS product_images (
+		id VARCHAR(36) PRIMARY KEY,
+		product_id VARCHAR(36) NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+		alt_text TEXT NOT NULL DEFAULT '',
+		content_type VARCHAR(50) NOT NULL,
+		size_bytes BIGINT NOT NULL,
+		original_key TEXT NOT NULL,
+		original_url TEXT NOT NULL,
+		width INTEGER NOT NULL,
+		height INTEGER NOT NULL,
+		thumbnails JSONB NOT NULL,
+		created_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_product_images_product_id ON product_images(product_id, position)`,
+
+	// Product archival
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP`,
+	`CREATE INDEX IF NOT EXISTS idx_products_deleted_at ON products(deleted_at) WHERE deleted_at IS NOT NULL`,
+	`CREATE INDEX IF NOT EXISTS idx_order_items_product_id ON order_items(product_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_basket_items_product_id ON basket_items(product_id)`,
 
+	// Schema version tracking
+	`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL
+	)`,
+
+	// Order item product snapshots, backfilled from the current product for existing orders
+	`ALTER TABLE order_items ADD COLUMN IF NOT EXISTS product_name VARCHAR(255)`,
+	`ALTER TABLE order_items ADD COLUMN IF NOT EXISTS product_sku VARCHAR(64) NOT NULL DEFAULT ''`,
+	`ALTER TABLE order_items ADD COLUMN IF NOT EXISTS product_description TEXT NOT NULL DEFAULT ''`,
+	`UPDATE order_items oi
+		SET product_name = p.name,
+			product_sku = COALESCE((SELECT v.sku FROM product_variants v WHERE v.id = oi.variant_id), p.sku, ''),
+			product_description = p.description
+		FROM products p
+		WHERE oi.product_name IS NULL AND p.id = oi.product_id`,
+	`UPDATE order_items SET product_name = '' WHERE product_name IS NULL`,
+	`ALTER TABLE order_items ALTER COLUMN product_name SET NOT NULL`,
+
+	// Idle basket expiry
+	`CREATE INDEX IF NOT EXISTS idx_baskets_updated_at ON baskets(updated_at)`,
+
+	// Wishlists with named lists, sharing and back-in-stock alerts
+	`CREATE TABLE IF NOT EXISTS wishlists (
+		id VARCHAR(36) PRIMARY KEY,
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS wishlist_lists (
+		id VARCHAR(36) PRIMARY KEY,
+		wishlist_id VARCHAR(36) NOT NULL REFERENCES wishlists(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		share_token VARCHAR(64) UNIQUE,
+		created_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_wishlist_lists_wishlist_id ON wishlist_lists(wishlist_id, position)`,
+	`CREATE TABLE IF NOT EXISTS wishlist_items (
+		list_id VARCHAR(36) NOT NULL REFERENCES wishlist_lists(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		product_id VARCHAR(36) NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+		variant_id VARCHAR(36) NOT NULL DEFAULT '',
+		notify_back_in_stock BOOLEAN NOT NULL DEFAULT FALSE,
+		added_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (list_id, product_id, variant_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_wishlist_items_back_in_stock ON wishlist_items(product_id, variant_id) WHERE notify_back_in_stock`,
+
+	// Product reviews with moderation, and the rating of approved reviews kept on the product
+	`CREATE TABLE IF NOT EXISTS reviews (
+		id VARCHAR(36) PRIMARY KEY,
+		product_id VARCHAR(36) NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+		order_id VARCHAR(36) NOT NULL DEFAULT '',
+		author VARCHAR(100) NOT NULL,
+		rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+		title VARCHAR(200) NOT NULL,
+		body TEXT NOT NULL DEFAULT '',
+		verified_purchase BOOLEAN NOT NULL DEFAULT FALSE,
+		status VARCHAR(20) NOT NULL,
+		moderation_note TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL,
+		moderated_at TIMESTAMP
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_reviews_product_status ON reviews(product_id, status, created_at)`,
+	`CREATE INDEX IF NOT EXISTS idx_reviews_status_created_at ON reviews(status, created_at)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_reviews_product_order ON reviews(product_id, order_id) WHERE order_id <> ''`,
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS rating_count INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS rating_total INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS low_stock_threshold INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_quantity INTEGER NOT NULL DEFAULT 0`,
+	`CREATE INDEX IF NOT EXISTS idx_products_low_stock ON products(name) WHERE low_stock_threshold > 0 AND deleted_at IS NULL`,
+	// Stock of products with variants is tracked per variant; clear product stock left behind by older builds
+	`UPDATE products p SET stock = 0 WHERE p.stock <> 0 AND EXISTS(SELECT 1 FROM product_variants v WHERE v.product_id = p.id)`,
+
+	// Back-in-stock notices waiting for delivery, queued as the wishlist alert is turned off
+	`CREATE TABLE IF NOT EXISTS back_in_stock_notifications (
+		id BIGSERIAL PRIMARY KEY,
+		wishlist_id VARCHAR(36) NOT NULL,
+		list_id VARCHAR(36) NOT NULL,
+		product_id VARCHAR(36) NOT NULL,
+		variant_id VARCHAR(36) NOT NULL DEFAULT '',
+		stock INTEGER NOT NULL,
+		queued_at TIMESTAMP NOT NULL
+	)`,
+	`ALTER TABLE back_in_stock_notifications ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0`,
+}
+
+// MigrationVersion is the schema version this build expects
+var MigrationVersion = len(migrations)
+
+// RunMigrations executes database migrations and records the resulting schema version
+func RunMigrations(db *sql.DB) error {
 	for _, migration := range migrations {
 		if _, err := db.Exec(migration); err != nil {
 			return fmt.Errorf("failed to execute migration: %w", err)
 		}
 	}
 
+	if _, err := db.Exec(
+		`INSERT INTO schema_migrations (version, applied_at) VALUES ($1, NOW()) ON CONFLICT (version) DO NOTHING`,
+		MigrationVersion,
+	); err != nil {
+		return fmt.Errorf("failed to record migration version: %w", err)
+	}
+
+	return nil
+}
+
+// CheckMigrations verifies that the database schema is at the version this build expects
+func CheckMigrations(ctx context.Context, db *sql.DB) error {
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return fmt.Errorf("failed to read migration version: %w", err)
+	}
+	if !version.Valid || int(version.Int64) < MigrationVersion {
+		return fmt.Errorf("schema is at version %d, expected %d", version.Int64, MigrationVersion)
+	}
 	return nil
 }
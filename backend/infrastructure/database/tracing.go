@@ -0,0 +1,248 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+
+	"ecom-backend/pkg/tracing"
+)
+
+// maxStatementLength caps the SQL recorded on query spans
+const maxStatementLength = 1024
+
+// tracedConnector wraps a driver connector so every connection records query spans
+type tracedConnector struct {
+	driver.Connector
+}
+
+// Connect opens a traced connection
+func (c tracedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{conn: conn}, nil
+}
+
+// tracedConn records a client span for each query, exec, prepare and transaction.
+// It deliberately does not implement driver.NamedValueChecker so the driver's own
+// argument conversion keeps applying.
+type tracedConn struct {
+	conn driver.Conn
+}
+
+func (c *tracedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *tracedConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *tracedConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	_, span := startQuerySpan(ctx, "PREPARE", query)
+	defer span.End()
+
+	var stmt driver.Stmt
+	var err error
+	if p, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = p.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return &tracedStmt{stmt: stmt, query: query}, nil
+}
+
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	_, span := startSpan(ctx, "db BEGIN")
+	defer span.End()
+
+	var tx driver.Tx
+	var err error
+	if b, ok := c.conn.(driver.ConnBeginTx); ok {
+		tx, err = b.BeginTx(ctx, opts)
+	} else {
+		tx, err = c.conn.Begin()
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return &tracedTx{tx: tx, ctx: ctx}, nil
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := startQuerySpan(ctx, operationName(query), query)
+	defer span.End()
+
+	rows, err := q.QueryContext(ctx, query, args)
+	if err != nil && err != driver.ErrSkip {
+		span.RecordError(err)
+	}
+	return rows, err
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := startQuerySpan(ctx, operationName(query), query)
+	defer span.End()
+
+	result, err := e.ExecContext(ctx, query, args)
+	if err != nil && err != driver.ErrSkip {
+		span.RecordError(err)
+		return result, err
+	}
+	if err == nil {
+		if n, rerr := result.RowsAffected(); rerr == nil {
+			span.SetAttribute("db.rows_affected", n)
+		}
+	}
+	return result, err
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	if p, ok := c.conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) IsValid() bool {
+	if v, ok := c.conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// tracedStmt records a span for each execution of a prepared statement
+type tracedStmt struct {
+	stmt  driver.Stmt
+	query string
+}
+
+func (s *tracedStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *tracedStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *tracedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.stmt.Exec(args)
+}
+
+func (s *tracedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.stmt.Query(args)
+}
+
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := s.stmt.(driver.StmtExecContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := startQuerySpan(ctx, operationName(s.query), s.query)
+	defer span.End()
+
+	result, err := e.ExecContext(ctx, args)
+	span.RecordError(err)
+	return result, err
+}
+
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := s.stmt.(driver.StmtQueryContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := startQuerySpan(ctx, operationName(s.query), s.query)
+	defer span.End()
+
+	rows, err := q.QueryContext(ctx, args)
+	span.RecordError(err)
+	return rows, err
+}
+
+// tracedTx records spans for commit and rollback under the context that began the transaction
+type tracedTx struct {
+	tx  driver.Tx
+	ctx context.Context
+}
+
+func (t *tracedTx) Commit() error {
+	_, span := startSpan(t.ctx, "db COMMIT")
+	defer span.End()
+
+	err := t.tx.Commit()
+	span.RecordError(err)
+	return err
+}
+
+func (t *tracedTx) Rollback() error {
+	_, span := startSpan(t.ctx, "db ROLLBACK")
+	defer span.End()
+
+	err := t.tx.Rollback()
+	span.RecordError(err)
+	return err
+}
+
+// startSpan begins a client span for a database call
+func startSpan(ctx context.Context, name string) (context.Context, *tracing.Span) {
+	return tracing.Start(ctx, name,
+		tracing.WithKind(tracing.SpanKindClient),
+		tracing.WithAttributes("db.system", "postgresql"),
+	)
+}
+
+// startQuerySpan begins a client span carrying the normalized statement
+func startQuerySpan(ctx context.Context, operation, query string) (context.Context, *tracing.Span) {
+	ctx, span := startSpan(ctx, "db "+operation)
+	span.SetAttribute("db.operation", operation)
+	span.SetAttribute("db.statement", normalizeStatement(query))
+	return ctx, span
+}
+
+// operationName returns the leading SQL keyword of a statement, e.g. SELECT
+func operationName(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
+
+// normalizeStatement collapses whitespace and truncates long statements
+func normalizeStatement(query string) string {
+	statement := strings.Join(strings.Fields(query), " ")
+	if len(statement) > maxStatementLength {
+		statement = statement[:maxStatementLength] + "..."
+	}
+	return statement
+}
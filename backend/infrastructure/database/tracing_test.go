@@ -0,0 +1,22 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeStatement(t *testing.T) {
+	got := normalizeStatement("SELECT id,\n\t\tname\n  FROM products   WHERE id = $1")
+	if got != "SELECT id, name FROM products WHERE id = $1" {
+		t.Errorf("unexpected statement %q", got)
+	}
+
+	long := normalizeStatement("SELECT " + strings.Repeat("x", maxStatementLength))
+	if len(long) != maxStatementLength+3 || !strings.HasSuffix(long, "...") {
+		t.Errorf("expected truncated statement, got length %d", len(long))
+	}
+
+	if op := operationName("\n  insert INTO products"); op != "INSERT" {
+		t.Errorf("expected INSERT, got %q", op)
+	}
+}
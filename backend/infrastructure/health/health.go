@@ -0,0 +1,205 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Check statuses
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Check reports a dependency as healthy by returning nil
+type Check func(ctx context.Context) error
+
+// CheckResult is the outcome of a single check
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the combined outcome of a set of checks
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Healthy reports whether every check passed
+func (r Report) Healthy() bool {
+	return r.Status == StatusOK
+}
+
+type registeredCheck struct {
+	name      string
+	check     Check
+	readiness bool
+}
+
+// Registry holds liveness and readiness checks and runs them with a per-check timeout
+type Registry struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks []registeredCheck
+}
+
+// NewRegistry creates a Registry that gives each check at most timeout to complete
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+// AddLiveness registers a check that must pass for the process to be considered alive.
+// Liveness checks also count towards readiness.
+func (r *Registry) AddLiveness(name string, check Check) {
+	r.add(name, check, false)
+}
+
+// AddReadiness registers a check that must pass for the process to receive traffic
+func (r *Registry) AddReadiness(name string, check Check) {
+	r.add(name, check, true)
+}
+
+func (r *Registry) add(name string, check Check, readiness bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, registeredCheck{name: name, check: check, readiness: readiness})
+}
+
+// Heartbeat registers a liveness check that fails when the returned heartbeat
+// has not beaten within maxAge. Background workers call Beat on each iteration.
+func (r *Registry) Heartbeat(name string, maxAge time.Duration) *Heartbeat {
+	hb := &Heartbeat{maxAge: maxAge}
+	hb.Beat()
+	r.AddLiveness(name, hb.Check)
+	return hb
+}
+
+// Liveness runs the liveness checks
+func (r *Registry) Liveness(ctx context.Context) Report {
+	return r.run(ctx, false)
+}
+
+// Readiness runs the liveness and readiness checks
+func (r *Registry) Readiness(ctx context.Context) Report {
+	return r.run(ctx, true)
+}
+
+// run executes the selected checks concurrently
+func (r *Registry) run(ctx context.Context, readiness bool) Report {
+	r.mu.RLock()
+	checks := make([]registeredCheck, 0, len(r.checks))
+	for _, c := range r.checks {
+		if readiness || !c.readiness {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(checks, func(i, j int) bool { return checks[i].name < checks[j].name })
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c registeredCheck) {
+			defer wg.Done()
+			results[i] = r.runCheck(ctx, c.check)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+// runCheck runs one check under the registry timeout
+func (r *Registry) runCheck(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = errors.New("check timed out")
+	}
+
+	result := CheckResult{
+		Status:    StatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// LivenessHandler serves the liveness report, with 503 when a check fails
+func (r *Registry) LivenessHandler() http.Handler {
+	return reportHandler(r.Liveness)
+}
+
+// ReadinessHandler serves the readiness report, with 503 when a check fails
+func (r *Registry) ReadinessHandler() http.Handler {
+	return reportHandler(r.Readiness)
+}
+
+func reportHandler(run func(ctx context.Context) Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := run(req.Context())
+
+		status := http.StatusOK
+		if !report.Healthy() {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(report)
+	})
+}
+
+// Heartbeat tracks when a background worker last made progress
+type Heartbeat struct {
+	maxAge time.Duration
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// Beat records progress now
+func (h *Heartbeat) Beat() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.last = time.Now()
+}
+
+// Check fails when the last beat is older than the maximum age
+func (h *Heartbeat) Check(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if age := time.Since(h.last); age > h.maxAge {
+		return errors.New("no heartbeat for " + age.Round(time.Second).String())
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Readiness(t *testing.T) {
+	r := NewRegistry(50 * time.Millisecond)
+	r.AddLiveness("process", func(ctx context.Context) error { return nil })
+	r.AddReadiness("database", func(ctx context.Context) error { return errors.New("connection refused") })
+	r.AddReadiness("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := r.Readiness(context.Background())
+	if report.Healthy() {
+		t.Fatal("expected readiness to fail")
+	}
+	if len(report.Checks) != 3 {
+		t.Fatalf("expected 3 checks, got %d", len(report.Checks))
+	}
+	if c := report.Checks["database"]; c.Status != StatusFail || c.Error != "connection refused" {
+		t.Errorf("unexpected database result: %+v", c)
+	}
+	if c := report.Checks["slow"]; c.Status != StatusFail {
+		t.Errorf("expected slow check to time out, got %+v", c)
+	}
+	if c := report.Checks["process"]; c.Status != StatusOK {
+		t.Errorf("expected process check to pass, got %+v", c)
+	}
+
+	liveness := r.Liveness(context.Background())
+	if !liveness.Healthy() || len(liveness.Checks) != 1 {
+		t.Errorf("expected liveness to run only liveness checks and pass, got %+v", liveness)
+	}
+}
+
+func TestRegistry_Heartbeat(t *testing.T) {
+	r := NewRegistry(time.Second)
+	hb := r.Heartbeat("worker", 20*time.Millisecond)
+
+	if report := r.Liveness(context.Background()); !report.Healthy() {
+		t.Fatalf("expected fresh heartbeat to pass, got %+v", report)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if report := r.Liveness(context.Background()); report.Healthy() {
+		t.Error("expected stale heartbeat to fail")
+	}
+
+	hb.Beat()
+	if report := r.Liveness(context.Background()); !report.Healthy() {
+		t.Errorf("expected heartbeat to recover after Beat, got %+v", report)
+	}
+}
+
+func TestReadinessHandler(t *testing.T) {
+	r := NewRegistry(time.Second)
+	failing := true
+	r.AddReadiness("shutdown", func(ctx context.Context) error {
+		if failing {
+			return errors.New("server is shutting down")
+		}
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	r.ReadinessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+
+	var report Report
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if report.Status != StatusFail || report.Checks["shutdown"].Error != "server is shutting down" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	failing = false
+	w = httptest.NewRecorder()
+	r.ReadinessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"ecom-backend/pkg/tracing"
+	"encoding/json"
+	"testing"
+)
+
+func TestNew_AddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, FormatJSON, "debug")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := WithRequestID(context.Background(), "req-123")
+	logger.With("component", "test").InfoContext(ctx, "hello")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected JSON output, got %q", buf.String())
+	}
+	if record["request_id"] != "req-123" || record["component"] != "test" {
+		t.Errorf("unexpected record: %v", record)
+	}
+}
+
+func TestNew_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, FormatText, "warn")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Info("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("expected info to be filtered at warn level, got %q", buf.String())
+	}
+
+	if _, err := New(&buf, "xml", "info"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if _, err := New(&buf, FormatJSON, "loud"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
+
+func TestNew_AddsTraceIDs(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&buf, FormatJSON, "info")
+
+	ctx, span := tracing.NewTracer(nil).Start(context.Background(), "op")
+	logger.InfoContext(ctx, "hello")
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if record["trace_id"] != span.SpanContext().TraceID.String() || record["span_id"] != span.SpanContext().SpanID.String() {
+		t.Errorf("expected trace and span IDs, got %v", record)
+	}
+}
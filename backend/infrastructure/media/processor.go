@@ -0,0 +1,156 @@
+package media
+
+import (
+	"bytes"
+	"ecom-backend/domain/repository"
+	"fmt"
+	"image"
+	_ "image/gif" // register the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// jpegQuality is the quality used when encoding JPEG thumbnails
+const jpegQuality = 85
+
+// ThumbnailSize is a named bounding box for generated thumbnails
+type ThumbnailSize struct {
+	Name         string
+	MaxDimension int
+}
+
+// ParseThumbnailSizes parses a list such as "small:150,medium:400"
+func ParseThumbnailSizes(spec string) ([]ThumbnailSize, error) {
+	sizes := make([]ThumbnailSize, 0)
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, dim, ok := strings.Cut(part, ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid thumbnail size %q: expected name:pixels", part)
+		}
+		if name == "original" || seen[name] {
+			return nil, fmt.Errorf("invalid thumbnail size name %q", name)
+		}
+
+		n, err := strconv.Atoi(dim)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid thumbnail size %q: pixels must be a positive integer", part)
+		}
+
+		seen[name] = true
+		sizes = append(sizes, ThumbnailSize{Name: name, MaxDimension: n})
+	}
+
+	return sizes, nil
+}
+
+// Processor validates uploaded images and generates thumbnails in pure Go
+type Processor struct {
+	sizes     []ThumbnailSize
+	maxPixels int
+}
+
+// NewProcessor creates a new Processor.
+// maxPixels bounds the decoded image size to guard against decompression bombs.
+func NewProcessor(sizes []ThumbnailSize, maxPixels int) *Processor {
+	return &Processor{
+		sizes:     sizes,
+		maxPixels: maxPixels,
+	}
+}
+
+// Process sniffs the content type, decodes the image and renders each thumbnail size
+func (p *Processor) Process(data []byte) (*repository.ProcessedImage, error) {
+	contentType := http.DetectContentType(data)
+
+	var ext string
+	switch contentType {
+	case "image/jpeg":
+		ext = ".jpg"
+	case "image/png":
+		ext = ".png"
+	case "image/gif":
+		ext = ".gif"
+	default:
+		return nil, fmt.Errorf("%w: content type %s is not allowed", repository.ErrUnsupportedImage, contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrUnsupportedImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("%w: invalid dimensions", repository.ErrUnsupportedImage)
+	}
+	if p.maxPixels > 0 && cfg.Width*cfg.Height > p.maxPixels {
+		return nil, fmt.Errorf("%w: image exceeds %d pixels", repository.ErrUnsupportedImage, p.maxPixels)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrUnsupportedImage, err)
+	}
+
+	processed := &repository.ProcessedImage{
+		ContentType: contentType,
+		Extension:   ext,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		Thumbnails:  make([]repository.Thumbnail, 0, len(p.sizes)),
+	}
+
+	for _, size := range p.sizes {
+		thumb, err := p.thumbnail(src, contentType, size)
+		if err != nil {
+			return nil, err
+		}
+		processed.Thumbnails = append(processed.Thumbnails, *thumb)
+	}
+
+	return processed, nil
+}
+
+// thumbnail renders src to fit within the size's bounding box, never upscaling
+func (p *Processor) thumbnail(src image.Image, contentType string, size ThumbnailSize) (*repository.Thumbnail, error) {
+	w, h := fitWithin(src.Bounds().Dx(), src.Bounds().Dy(), size.MaxDimension)
+	dst := Resize(src, w, h)
+
+	var buf bytes.Buffer
+	thumb := &repository.Thumbnail{Name: size.Name, Width: w, Height: h}
+
+	// JPEG sources stay JPEG; anything that may carry transparency is encoded as PNG
+	if contentType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		thumb.ContentType, thumb.Extension = "image/jpeg", ".jpg"
+	} else {
+		if err := png.Encode(&buf, dst); err != nil {
+			return nil, err
+		}
+		thumb.ContentType, thumb.Extension = "image/png", ".png"
+	}
+
+	thumb.Data = buf.Bytes()
+	return thumb, nil
+}
+
+// fitWithin scales w x h down to fit a bound x bound box, preserving aspect ratio
+func fitWithin(w, h, bound int) (int, int) {
+	if w <= bound && h <= bound {
+		return w, h
+	}
+	if w >= h {
+		return bound, max(1, h*bound/w)
+	}
+	return max(1, w*bound/h), bound
+}
@@ -0,0 +1,83 @@
+package media
+
+import (
+	"bytes"
+	"ecom-backend/domain/repository"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseThumbnailSizes(t *testing.T) {
+	sizes, err := ParseThumbnailSizes("small:150, medium:400")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sizes) != 2 || sizes[1].Name != "medium" || sizes[1].MaxDimension != 400 {
+		t.Errorf("unexpected sizes: %+v", sizes)
+	}
+
+	for _, spec := range []string{"small", "small:0", "small:1,small:2", "original:10"} {
+		if _, err := ParseThumbnailSizes(spec); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestProcessor_Process(t *testing.T) {
+	processor := NewProcessor([]ThumbnailSize{{Name: "small", MaxDimension: 50}}, 1_000_000)
+
+	processed, err := processor.Process(encodePNG(t, 200, 100))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if processed.ContentType != "image/png" || processed.Width != 200 || processed.Height != 100 {
+		t.Errorf("unexpected original: %s %dx%d", processed.ContentType, processed.Width, processed.Height)
+	}
+	if len(processed.Thumbnails) != 1 {
+		t.Fatalf("expected 1 thumbnail, got %d", len(processed.Thumbnails))
+	}
+
+	thumb := processed.Thumbnails[0]
+	if thumb.Width != 50 || thumb.Height != 25 {
+		t.Errorf("expected 50x25 thumbnail, got %dx%d", thumb.Width, thumb.Height)
+	}
+	decoded, err := png.Decode(bytes.NewReader(thumb.Data))
+	if err != nil {
+		t.Fatalf("thumbnail is not a valid PNG: %v", err)
+	}
+	if decoded.Bounds().Dx() != 50 {
+		t.Errorf("expected encoded width 50, got %d", decoded.Bounds().Dx())
+	}
+}
+
+func TestProcessor_RejectsInvalidImages(t *testing.T) {
+	processor := NewProcessor(nil, 1000)
+
+	if _, err := processor.Process([]byte("not an image")); !errors.Is(err, repository.ErrUnsupportedImage) {
+		t.Errorf("expected ErrUnsupportedImage for text, got %v", err)
+	}
+	if _, err := processor.Process(encodePNG(t, 100, 100)); !errors.Is(err, repository.ErrUnsupportedImage) {
+		t.Errorf("expected ErrUnsupportedImage above pixel limit, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every application metric
+const namespace = "ecom"
+
+// Metrics owns the Prometheus registry and the application's collectors.
+// It implements service.MetricsRecorder for business events.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	orders           *prometheus.CounterVec
+	checkoutFailures *prometheus.CounterVec
+	basketAdds       prometheus.Counter
+	basketUnits      prometheus.Counter
+	revenue          *prometheus.CounterVec
+
+	jobRuns      *prometheus.CounterVec
+	jobDuration  *prometheus.HistogramVec
+	jobProcessed *prometheus.CounterVec
+}
+
+// New creates a Metrics with its own registry, including Go runtime and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		orders: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_total",
+			Help:      "Orders entering each status; PENDING counts orders created at checkout.",
+		}, []string{"status"}),
+		checkoutFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "checkout_failures_total",
+			Help:      "Failed checkouts by reason.",
+		}, []string{"reason"}),
+		basketAdds: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "basket_adds_total",
+			Help:      "Successful add-to-basket operations.",
+		}),
+		basketUnits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "basket_added_units_total",
+			Help:      "Units added to baskets.",
+		}),
+		revenue: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "revenue_minor_units_total",
+			Help:      "Order revenue at checkout in minor currency units (e.g. cents).",
+		}, []string{"currency"}),
+		jobRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_runs_total",
+			Help:      "Background job runs by job and outcome (success or error).",
+		}, []string{"job", "outcome"}),
+		jobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "job_duration_seconds",
+			Help:      "Background job run duration by job.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 4, 8),
+		}, []string{"job"}),
+		jobProcessed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_processed_total",
+			Help:      "Items processed by background jobs, such as expired baskets deleted.",
+		}, []string{"job"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.orders,
+		m.checkoutFailures,
+		m.basketAdds,
+		m.basketUnits,
+		m.revenue,
+		m.jobRuns,
+		m.jobDuration,
+		m.jobProcessed,
+	)
+
+	return m
+}
+
+// RegisterDB exports connection pool statistics for db
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the registry in the Prometheus text exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveHTTPRequest records a completed HTTP request
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// OrderStatusChanged counts an order entering a status
+func (m *Metrics) OrderStatusChanged(status string) {
+	m.orders.WithLabelValues(status).Inc()
+}
+
+// CheckoutFailed counts a failed checkout
+func (m *Metrics) CheckoutFailed(reason string) {
+	m.checkoutFailures.WithLabelValues(reason).Inc()
+}
+
+// BasketItemAdded counts an add-to-basket operation
+func (m *Metrics) BasketItemAdded(quantity int) {
+	m.basketAdds.Inc()
+	m.basketUnits.Add(float64(quantity))
+}
+
+// RevenueRecorded adds order revenue in minor units
+func (m *Metrics) RevenueRecorded(currency string, amount int64) {
+	m.revenue.WithLabelValues(currency).Add(float64(amount))
+}
+
+// JobRun records a finished background job run
+func (m *Metrics) JobRun(job string, duration time.Duration, processed int, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.jobRuns.WithLabelValues(job, outcome).Inc()
+	m.jobDuration.WithLabelValues(job).Observe(duration.Seconds())
+	m.jobProcessed.WithLabelValues(job).Add(float64(processed))
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+
+	server := httptest.NewServer(m.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") {
+		t.Errorf("expected text exposition format, got %s", resp.Header.Get("Content-Type"))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read scrape: %v", err)
+	}
+	return string(body)
+}
+
+func TestMetrics_Scrape(t *testing.T) {
+	m := New()
+
+	// sql.Open does not connect, so pool stats are available without a database
+	db, err := sql.Open("postgres", "host=localhost dbname=metrics_test sslmode=disable")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+	m.RegisterDB(db, "metrics_test")
+
+	m.ObserveHTTPRequest("GET", "/api/v1/products/{id}", 200, 30*time.Millisecond)
+	m.OrderStatusChanged("PENDING")
+	m.OrderStatusChanged("PENDING")
+	m.OrderStatusChanged("CONFIRMED")
+	m.CheckoutFailed("insufficient_stock")
+	m.BasketItemAdded(3)
+	m.RevenueRecorded("USD", 4599)
+	m.JobRun("basket_expiry", 2*time.Second, 40, nil)
+	m.JobRun("basket_expiry", time.Second, 0, errors.New("database unavailable"))
+
+	body := scrape(t, m)
+
+	for _, want := range []string{
+		`ecom_http_requests_total{method="GET",route="/api/v1/products/{id}",status="200"} 1`,
+		`ecom_http_request_duration_seconds_count{method="GET",route="/api/v1/products/{id}"} 1`,
+		`ecom_orders_total{status="PENDING"} 2`,
+		`ecom_orders_total{status="CONFIRMED"} 1`,
+		`ecom_checkout_failures_total{reason="insufficient_stock"} 1`,
+		`ecom_basket_adds_total 1`,
+		`ecom_basket_added_units_total 3`,
+		`ecom_revenue_minor_units_total{currency="USD"} 4599`,
+		`ecom_job_runs_total{job="basket_expiry",outcome="success"} 1`,
+		`ecom_job_runs_total{job="basket_expiry",outcome="error"} 1`,
+		`ecom_job_processed_total{job="basket_expiry"} 40`,
+		`ecom_job_duration_seconds_count{job="basket_expiry"} 2`,
+		`go_sql_max_open_connections{db_name="metrics_test"}`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected scrape to contain %q", want)
+		}
+	}
+}
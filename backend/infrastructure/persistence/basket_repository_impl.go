@@ -7,6 +7,8 @@ import (
 	"ecom-backend/domain/repository"
 	"ecom-backend/domain/value"
 	"errors"
+	"fmt"
+	"time"
 )
 
 // BasketRepositoryImpl implements BasketRepository using PostgreSQL
@@ -39,7 +41,7 @@ func (r *BasketRepositoryImpl) Save(ctx context.Context, basket *entity.Basket)
 		return err
 	}
 
-	return tx.Commit()
+	return commitTx(ctx, tx, "basket.save", basket.ID())
 }
 
 // FindByID retrieves a basket by ID
@@ -53,7 +55,7 @@ func (r *BasketRepositoryImpl) FindByID(ctx context.Context, id string) (*entity
 	err := r.db.QueryRowContext(ctx, query, id).Scan(&basketID, &createdAt, &updatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("basket not found")
+			return nil, fmt.Errorf("basket %w", repository.ErrNotFound)
 		}
 		return nil, err
 	}
@@ -75,34 +77,40 @@ func (r *BasketRepositoryImpl) Update(ctx context.Context, basket *entity.Basket
 	}
 	defer tx.Rollback()
 
-	// Update basket
-	query := `UPDATE baskets SET updated_at = $2 WHERE id = $1`
-	result, err := tx.ExecContext(ctx, query, basket.ID(), basket.UpdatedAt())
-	if err != nil {
+	if err := r.updateBasket(ctx, tx, basket); err != nil {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return commitTx(ctx, tx, "basket.update", basket.ID())
+}
+
+// UpdateMerged updates a basket that another basket was merged into and deletes the
+// merged basket in one transaction
+func (r *BasketRepositoryImpl) UpdateMerged(ctx context.Context, basket *entity.Basket, mergedID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return errors.New("basket not found")
-	}
+	defer tx.Rollback()
 
-	// Delete existing items
-	deleteQuery := `DELETE FROM basket_items WHERE basket_id = $1`
-	_, err = tx.ExecContext(ctx, deleteQuery, basket.ID())
+	// Delete the merged basket first, so a concurrent merge of it fails instead of copying its items twice
+	result, err := tx.ExecContext(ctx, `DELETE FROM baskets WHERE id = $1`, mergedID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("basket %w", repository.ErrNotFound)
+	}
 
-	// Insert updated items
-	if err := r.saveBasketItems(ctx, tx, basket); err != nil {
+	if err := r.updateBasket(ctx, tx, basket); err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	return commitTx(ctx, tx, "basket.merge", basket.ID())
 }
 
 // Delete removes a basket
@@ -119,12 +127,99 @@ func (r *BasketRepositoryImpl) Delete(ctx context.Context, id string) error {
 		return err
 	}
 	if rowsAffected == 0 {
-		return errors.New("basket not found")
+		return fmt.Errorf("basket %w", repository.ErrNotFound)
 	}
 
 	return nil
 }
 
+// DeleteIdle removes up to limit baskets not updated since before, oldest first,
+// returning the deleted baskets with their items. Baskets locked by a concurrent
+// update or another instance's cleanup are skipped.
+func (r *BasketRepositoryImpl) DeleteIdle(ctx context.Context, before time.Time, limit int) ([]*entity.Basket, error) {
+	// Every part of the statement sees the items as they were before the cascade
+	query := `
+		WITH expired AS (
+			SELECT id FROM baskets
+			WHERE updated_at < $1
+			ORDER BY updated_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		), deleted AS (
+			DELETE FROM baskets WHERE id IN (SELECT id FROM expired)
+			RETURNING id, created_at, updated_at
+		)
+		SELECT d.id, d.created_at, d.updated_at,
+			i.product_id, i.variant_id, i.quantity, i.price_amount, i.price_currency
+		FROM deleted d
+		LEFT JOIN basket_items i ON i.basket_id = d.id
+		ORDER BY d.updated_at, d.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	baskets := make([]*entity.Basket, 0)
+	var id string
+	var createdAt, updatedAt sql.NullTime
+	var items []*entity.BasketItem
+	flush := func() {
+		if id != "" {
+			baskets = append(baskets, entity.ReconstructBasket(id, items, createdAt.Time, updatedAt.Time))
+		}
+	}
+
+	for rows.Next() {
+		var basketID string
+		var rowCreatedAt, rowUpdatedAt sql.NullTime
+		var productID, variantID, currency sql.NullString
+		var quantity sql.NullInt64
+		var priceAmount sql.NullInt64
+
+		if err := rows.Scan(&basketID, &rowCreatedAt, &rowUpdatedAt,
+			&productID, &variantID, &quantity, &priceAmount, &currency); err != nil {
+			return nil, err
+		}
+
+		if basketID != id {
+			flush()
+			id, createdAt, updatedAt = basketID, rowCreatedAt, rowUpdatedAt
+			items = make([]*entity.BasketItem, 0)
+		}
+
+		// Empty baskets join to a single row without an item
+		if !productID.Valid {
+			continue
+		}
+
+		price, err := value.NewMoney(priceAmount.Int64, currency.String)
+		if err != nil {
+			return nil, err
+		}
+
+		qty, err := value.NewQuantity(int(quantity.Int64))
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := entity.NewBasketVariantItem(productID.String, variantID.String, qty, price)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return baskets, nil
+}
+
 // ExistsByID checks if a basket exists
 func (r *BasketRepositoryImpl) ExistsByID(ctx context.Context, id string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM baskets WHERE id = $1)`
@@ -135,6 +230,32 @@ func (r *BasketRepositoryImpl) ExistsByID(ctx context.Context, id string) (bool,
 	return exists, err
 }
 
+// updateBasket updates the basket row and replaces its items within a transaction
+func (r *BasketRepositoryImpl) updateBasket(ctx context.Context, tx *sql.Tx, basket *entity.Basket) error {
+	query := `UPDATE baskets SET updated_at = $2 WHERE id = $1`
+	result, err := tx.ExecContext(ctx, query, basket.ID(), basket.UpdatedAt())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("basket %w", repository.ErrNotFound)
+	}
+
+	// Delete existing items
+	deleteQuery := `DELETE FROM basket_items WHERE basket_id = $1`
+	if _, err := tx.ExecContext(ctx, deleteQuery, basket.ID()); err != nil {
+		return err
+	}
+
+	// Insert updated items
+	return r.saveBasketItems(ctx, tx, basket)
+}
+
 // saveBasketItems saves basket items within a transaction
 func (r *BasketRepositoryImpl) saveBasketItems(ctx context.Context, tx *sql.Tx, basket *entity.Basket) error {
 	if len(basket.Items()) == 0 {
@@ -142,14 +263,15 @@ func (r *BasketRepositoryImpl) saveBasketItems(ctx context.Context, tx *sql.Tx,
 	}
 
 	query := `
-		INSERT INTO basket_items (basket_id, product_id, quantity, price_amount, price_currency)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO basket_items (basket_id, product_id, variant_id, quantity, price_amount, price_currency)
+		VALUES ($1, $2, $3, $4, $5, $6)
 	`
 
 	for _, item := range basket.Items() {
 		_, err := tx.ExecContext(ctx, query,
 			basket.ID(),
 			item.ProductID(),
+			item.VariantID(),
 			item.Quantity().Value(),
 			item.Price().Amount(),
 			item.Price().Currency(),
@@ -165,7 +287,7 @@ func (r *BasketRepositoryImpl) saveBasketItems(ctx context.Context, tx *sql.Tx,
 // findBasketItems retrieves basket items
 func (r *BasketRepositoryImpl) findBasketItems(ctx context.Context, basketID string) ([]*entity.BasketItem, error) {
 	query := `
-		SELECT product_id, quantity, price_amount, price_currency
+		SELECT product_id, variant_id, quantity, price_amount, price_currency
 		FROM basket_items
 		WHERE basket_id = $1
 	`
@@ -179,11 +301,11 @@ func (r *BasketRepositoryImpl) findBasketItems(ctx context.Context, basketID str
 	items := make([]*entity.BasketItem, 0)
 
 	for rows.Next() {
-		var productID, currency string
+		var productID, variantID, currency string
 		var quantity int
 		var priceAmount int64
 
-		if err := rows.Scan(&productID, &quantity, &priceAmount, &currency); err != nil {
+		if err := rows.Scan(&productID, &variantID, &quantity, &priceAmount, &currency); err != nil {
 			return nil, err
 		}
 
@@ -197,7 +319,7 @@ func (r *BasketRepositoryImpl) findBasketItems(ctx context.Context, basketID str
 			return nil, err
 		}
 
-		item, err := entity.NewBasketItem(productID, qty, price)
+		item, err := entity.NewBasketVariantItem(productID, variantID, qty, price)
 		if err != nil {
 			return nil, err
 		}
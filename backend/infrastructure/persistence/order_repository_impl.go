@@ -7,6 +7,8 @@ import (
 	"ecom-backend/domain/repository"
 	"ecom-backend/domain/value"
 	"errors"
+	"fmt"
+	"time"
 )
 
 // OrderRepositoryImpl implements OrderRepository using PostgreSQL
@@ -27,12 +29,76 @@ func (r *OrderRepositoryImpl) Save(ctx context.Context, order *entity.Order) err
 	}
 	defer tx.Rollback()
 
-	// Insert order
+	if err := r.insertOrder(ctx, tx, order); err != nil {
+		return err
+	}
+
+	return commitTx(ctx, tx, "order.save", order.ID())
+}
+
+// Place persists a new order and reduces the stock it takes in one transaction.
+// Stock is reduced with conditional updates, so concurrent checkouts cannot oversell.
+func (r *OrderRepositoryImpl) Place(ctx context.Context, order *entity.Order) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, item := range order.Items() {
+		if err := r.reduceStock(ctx, tx, item, order.CreatedAt()); err != nil {
+			return err
+		}
+	}
+
+	if err := r.insertOrder(ctx, tx, order); err != nil {
+		return err
+	}
+
+	return commitTx(ctx, tx, "order.place", order.ID())
+}
+
+// reduceStock takes an order item's quantity from its product's or variant's stock within a transaction
+func (r *OrderRepositoryImpl) reduceStock(ctx context.Context, tx *sql.Tx, item *entity.OrderItem, at time.Time) error {
+	var result sql.Result
+	var err error
+	if item.VariantID() == "" {
+		result, err = tx.ExecContext(ctx, `
+			UPDATE products
+			SET stock = stock - $2, updated_at = $3
+			WHERE id = $1 AND deleted_at IS NULL AND stock >= $2
+		`, item.ProductID(), item.Quantity().Value(), at)
+	} else {
+		result, err = tx.ExecContext(ctx, `
+			UPDATE product_variants v
+			SET stock = v.stock - $3, updated_at = $4
+			FROM products p
+			WHERE v.id = $2 AND v.product_id = $1 AND p.id = v.product_id
+				AND p.deleted_at IS NULL AND v.stock >= $3
+		`, item.ProductID(), item.VariantID(), item.Quantity().Value(), at)
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w for product: %s", repository.ErrInsufficientStock, item.Product().Name())
+	}
+
+	return nil
+}
+
+// insertOrder inserts an order and its items within a transaction
+func (r *OrderRepositoryImpl) insertOrder(ctx context.Context, tx *sql.Tx, order *entity.Order) error {
 	query := `
 		INSERT INTO orders (id, total_amount, total_currency, status, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6)
 	`
-	_, err = tx.ExecContext(ctx, query,
+	_, err := tx.ExecContext(ctx, query,
 		order.ID(),
 		order.Total().Amount(),
 		order.Total().Currency(),
@@ -44,12 +110,7 @@ func (r *OrderRepositoryImpl) Save(ctx context.Context, order *entity.Order) err
 		return err
 	}
 
-	// Insert order items
-	if err := r.saveOrderItems(ctx, tx, order); err != nil {
-		return err
-	}
-
-	return tx.Commit()
+	return r.saveOrderItems(ctx, tx, order)
 }
 
 // FindByID retrieves an order by ID
@@ -185,17 +246,22 @@ func (r *OrderRepositoryImpl) saveOrderItems(ctx context.Context, tx *sql.Tx, or
 	}
 
 	query := `
-		INSERT INTO order_items (order_id, product_id, quantity, price_amount, price_currency)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO order_items (order_id, product_id, variant_id, quantity, price_amount, price_currency,
+			product_name, product_sku, product_description)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	for _, item := range order.Items() {
 		_, err := tx.ExecContext(ctx, query,
 			order.ID(),
 			item.ProductID(),
+			item.VariantID(),
 			item.Quantity().Value(),
 			item.Price().Amount(),
 			item.Price().Currency(),
+			item.Product().Name(),
+			item.Product().SKU(),
+			item.Product().Description(),
 		)
 		if err != nil {
 			return err
@@ -208,9 +274,11 @@ func (r *OrderRepositoryImpl) saveOrderItems(ctx context.Context, tx *sql.Tx, or
 // findOrderItems retrieves order items
 func (r *OrderRepositoryImpl) findOrderItems(ctx context.Context, orderID string) ([]*entity.OrderItem, error) {
 	query := `
-		SELECT product_id, quantity, price_amount, price_currency
+		SELECT product_id, variant_id, quantity, price_amount, price_currency,
+			product_name, product_sku, product_description
 		FROM order_items
 		WHERE order_id = $1
+		ORDER BY id
 	`
 
 	rows, err := r.db.QueryContext(ctx, query, orderID)
@@ -222,11 +290,11 @@ func (r *OrderRepositoryImpl) findOrderItems(ctx context.Context, orderID string
 	items := make([]*entity.OrderItem, 0)
 
 	for rows.Next() {
-		var productID, currency string
+		var productID, variantID, currency, name, sku, description string
 		var quantity int
 		var priceAmount int64
 
-		if err := rows.Scan(&productID, &quantity, &priceAmount, &currency); err != nil {
+		if err := rows.Scan(&productID, &variantID, &quantity, &priceAmount, &currency, &name, &sku, &description); err != nil {
 			return nil, err
 		}
 
@@ -240,7 +308,8 @@ func (r *OrderRepositoryImpl) findOrderItems(ctx context.Context, orderID string
 			return nil, err
 		}
 
-		item, err := entity.NewOrderItem(productID, qty, price)
+		item, err := entity.NewOrderVariantItem(productID, variantID, qty, price,
+			entity.ReconstructProductSnapshot(name, sku, description))
 		if err != nil {
 			return nil, err
 		}
@@ -6,7 +6,12 @@ import (
 	"ecom-backend/domain/entity"
 	"ecom-backend/domain/repository"
 	"ecom-backend/domain/value"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 // ProductRepositoryImpl implements ProductRepository using PostgreSQL
@@ -21,134 +26,190 @@ func NewProductRepository(db *sql.DB) repository.ProductRepository {
 
 // Save persists a new product
 func (r *ProductRepositoryImpl) Save(ctx context.Context, product *entity.Product) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO products (id, name, description, price_amount, price_currency, stock, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO products (id, sku, name, description, category, price_amount, price_currency, stock,
+			low_stock_threshold, reorder_quantity, created_at, updated_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		product.ID(),
+		product.SKU(),
 		product.Name(),
 		product.Description(),
+		product.Category(),
 		product.Price().Amount(),
 		product.Price().Currency(),
 		product.Stock().Value(),
+		product.LowStockThreshold(),
+		product.ReorderQuantity(),
 		product.CreatedAt(),
 		product.UpdatedAt(),
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := r.saveOptions(ctx, tx, product); err != nil {
+		return err
+	}
+
+	if err := r.saveVariants(ctx, tx, product); err != nil {
+		return err
+	}
+
+	if err := r.saveImages(ctx, tx, product); err != nil {
+		return err
+	}
+
+	return commitTx(ctx, tx, "product.save", product.ID())
 }
 
 // FindByID retrieves a product by ID
 func (r *ProductRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.Product, error) {
 	query := `
-		SELECT id, name, description, price_amount, price_currency, stock, created_at, updated_at
+		SELECT id, sku, name, description, category, price_amount, price_currency, stock, created_at, updated_at, deleted_at,
+			rating_count, rating_total, low_stock_threshold, reorder_quantity
 		FROM products
 		WHERE id = $1
 	`
 
-	var (
-		productID, name, description, currency string
-		priceAmount                            int64
-		stock                                  int
-		createdAt, updatedAt                   sql.NullTime
-	)
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&productID, &name, &description, &priceAmount, &currency, &stock, &createdAt, &updatedAt,
-	)
-
+	product, err := r.findProduct(ctx, query, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("product not found")
+			return nil, fmt.Errorf("product %w", repository.ErrNotFound)
 		}
 		return nil, err
 	}
 
-	price, err := value.NewMoney(priceAmount, currency)
-	if err != nil {
-		return nil, err
-	}
+	return product, nil
+}
 
-	stockQty, err := value.NewQuantity(stock)
-	if err != nil {
-		return nil, err
-	}
+// FindByIDs retrieves the products with the given IDs, loading their options, variants and
+// images with one query each however many IDs are given
+func (r *ProductRepositoryImpl) FindByIDs(ctx context.Context, ids []string) ([]*entity.Product, error) {
+	query := `
+		SELECT id, sku, name, description, category, price_amount, price_currency, stock, created_at, updated_at, deleted_at,
+			rating_count, rating_total, low_stock_threshold, reorder_quantity
+		FROM products
+		WHERE id = ANY($1)
+	`
 
-	return entity.ReconstructProduct(
-		productID, name, description, price, stockQty,
-		createdAt.Time, updatedAt.Time,
-	), nil
+	return r.queryProducts(ctx, query, pq.Array(ids))
 }
 
 // FindAll retrieves all products
 func (r *ProductRepositoryImpl) FindAll(ctx context.Context) ([]*entity.Product, error) {
 	query := `
-		SELECT id, name, description, price_amount, price_currency, stock, created_at, updated_at
+		SELECT id, sku, name, description, category, price_amount, price_currency, stock, created_at, updated_at, deleted_at,
+			rating_count, rating_total, low_stock_threshold, reorder_quantity
 		FROM products
+		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	return r.queryProducts(ctx, query)
+}
 
-	products := make([]*entity.Product, 0)
+// FindArchived retrieves all archived products
+func (r *ProductRepositoryImpl) FindArchived(ctx context.Context) ([]*entity.Product, error) {
+	query := `
+		SELECT id, sku, name, description, category, price_amount, price_currency, stock, created_at, updated_at, deleted_at,
+			rating_count, rating_total, low_stock_threshold, reorder_quantity
+		FROM products
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
 
-	for rows.Next() {
-		var (
-			productID, name, description, currency string
-			priceAmount                            int64
-			stock                                  int
-			createdAt, updatedAt                   sql.NullTime
-		)
+	return r.queryProducts(ctx, query)
+}
 
-		if err := rows.Scan(
-			&productID, &name, &description, &priceAmount, &currency, &stock, &createdAt, &updatedAt,
-		); err != nil {
-			return nil, err
-		}
+// FindBySKU retrieves a product by its own SKU
+func (r *ProductRepositoryImpl) FindBySKU(ctx context.Context, sku string) (*entity.Product, error) {
+	query := `
+		SELECT id, sku, name, description, category, price_amount, price_currency, stock, created_at, updated_at, deleted_at,
+			rating_count, rating_total, low_stock_threshold, reorder_quantity
+		FROM products
+		WHERE sku = $1
+	`
 
-		price, err := value.NewMoney(priceAmount, currency)
-		if err != nil {
-			return nil, err
+	product, err := r.findProduct(ctx, query, sku)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("product %w", repository.ErrNotFound)
 		}
+		return nil, err
+	}
 
-		stockQty, err := value.NewQuantity(stock)
-		if err != nil {
-			return nil, err
-		}
+	return product, nil
+}
 
-		product := entity.ReconstructProduct(
-			productID, name, description, price, stockQty,
-			createdAt.Time, updatedAt.Time,
-		)
+// FindAfter retrieves up to limit products ordered by ID, starting after afterID
+func (r *ProductRepositoryImpl) FindAfter(ctx context.Context, afterID string, limit int) ([]*entity.Product, error) {
+	query := `
+		SELECT id, sku, name, description, category, price_amount, price_currency, stock, created_at, updated_at, deleted_at,
+			rating_count, rating_total, low_stock_threshold, reorder_quantity
+		FROM products
+		WHERE id > $1 AND deleted_at IS NULL
+		ORDER BY id
+		LIMIT $2
+	`
 
-		products = append(products, product)
-	}
+	return r.queryProducts(ctx, query, afterID, limit)
+}
+
+// FindLowStock retrieves active products at or below their low-stock threshold, by name
+func (r *ProductRepositoryImpl) FindLowStock(ctx context.Context) ([]*entity.Product, error) {
+	query := `
+		SELECT id, sku, name, description, category, price_amount, price_currency, stock, created_at, updated_at, deleted_at,
+			rating_count, rating_total, low_stock_threshold, reorder_quantity
+		FROM products p
+		WHERE p.deleted_at IS NULL AND p.low_stock_threshold > 0
+			AND CASE
+				WHEN EXISTS(SELECT 1 FROM product_variants v WHERE v.product_id = p.id)
+				THEN EXISTS(SELECT 1 FROM product_variants v WHERE v.product_id = p.id AND v.stock <= p.low_stock_threshold)
+				ELSE p.stock <= p.low_stock_threshold
+			END
+		ORDER BY p.name, p.id
+	`
 
-	return products, rows.Err()
+	return r.queryProducts(ctx, query)
 }
 
 // Update updates an existing product
 func (r *ProductRepositoryImpl) Update(ctx context.Context, product *entity.Product) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE products
-		SET name = $2, description = $3, price_amount = $4, price_currency = $5, stock = $6, updated_at = $7
+		SET sku = NULLIF($2, ''), name = $3, description = $4, category = $5, price_amount = $6, price_currency = $7, stock = $8,
+			low_stock_threshold = $9, reorder_quantity = $10, updated_at = $11, deleted_at = $12
 		WHERE id = $1
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		product.ID(),
+		product.SKU(),
 		product.Name(),
 		product.Description(),
+		product.Category(),
 		product.Price().Amount(),
 		product.Price().Currency(),
 		product.Stock().Value(),
+		product.LowStockThreshold(),
+		product.ReorderQuantity(),
 		product.UpdatedAt(),
+		product.DeletedAt(),
 	)
 
 	if err != nil {
@@ -161,13 +222,368 @@ func (r *ProductRepositoryImpl) Update(ctx context.Context, product *entity.Prod
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("product not found")
+		return fmt.Errorf("product %w", repository.ErrNotFound)
+	}
+
+	// Replace options
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_options WHERE product_id = $1`, product.ID()); err != nil {
+		return err
+	}
+	if err := r.saveOptions(ctx, tx, product); err != nil {
+		return err
+	}
+
+	// Drop variants that were removed, then upsert the remaining ones
+	variantIDs := make([]string, 0, len(product.Variants()))
+	for _, v := range product.Variants() {
+		variantIDs = append(variantIDs, v.ID())
+	}
+	deleteQuery := `DELETE FROM product_variants WHERE product_id = $1 AND NOT (id = ANY($2))`
+	if _, err := tx.ExecContext(ctx, deleteQuery, product.ID(), pq.Array(variantIDs)); err != nil {
+		return err
+	}
+	if err := r.saveVariants(ctx, tx, product); err != nil {
+		return err
+	}
+
+	// Same for images
+	imageIDs := make([]string, 0, len(product.Images()))
+	for _, img := range product.Images() {
+		imageIDs = append(imageIDs, img.ID())
+	}
+	deleteImagesQuery := `DELETE FROM product_images WHERE product_id = $1 AND NOT (id = ANY($2))`
+	if _, err := tx.ExecContext(ctx, deleteImagesQuery, product.ID(), pq.Array(imageIDs)); err != nil {
+		return err
+	}
+	if err := r.saveImages(ctx, tx, product); err != nil {
+		return err
+	}
+
+	return commitTx(ctx, tx, "product.update", product.ID())
+}
+
+// AddVariant saves a new variant of a product
+func (r *ProductRepositoryImpl) AddVariant(ctx context.Context, productID string, variant *entity.ProductVariant) error {
+	query := `
+		INSERT INTO product_variants (id, product_id, sku, barcode, options, price_amount, price_currency, stock, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10)
+	`
+
+	options, err := json.Marshal(variant.Options())
+	if err != nil {
+		return err
+	}
+
+	priceAmount, priceCurrency := variantPrice(variant)
+	_, err = r.db.ExecContext(ctx, query,
+		variant.ID(),
+		productID,
+		variant.SKU(),
+		variant.Barcode(),
+		options,
+		priceAmount,
+		priceCurrency,
+		variant.Stock().Value(),
+		variant.CreatedAt(),
+		variant.UpdatedAt(),
+	)
+	return err
+}
+
+// UpdateVariant saves a variant's barcode and price override without touching its stock
+func (r *ProductRepositoryImpl) UpdateVariant(ctx context.Context, productID string, variant *entity.ProductVariant) error {
+	query := `
+		UPDATE product_variants
+		SET barcode = NULLIF($3, ''), price_amount = $4, price_currency = $5, updated_at = $6
+		WHERE id = $1 AND product_id = $2
+	`
+
+	priceAmount, priceCurrency := variantPrice(variant)
+	result, err := r.db.ExecContext(ctx, query,
+		variant.ID(),
+		productID,
+		variant.Barcode(),
+		priceAmount,
+		priceCurrency,
+		variant.UpdatedAt(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return variantAffected(result)
+}
+
+// UpdateVariantStock saves the stock of one variant
+func (r *ProductRepositoryImpl) UpdateVariantStock(ctx context.Context, productID string, variant *entity.ProductVariant) error {
+	query := `UPDATE product_variants SET stock = $3, updated_at = $4 WHERE id = $1 AND product_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, variant.ID(), productID, variant.Stock().Value(), variant.UpdatedAt())
+	if err != nil {
+		return err
+	}
+
+	return variantAffected(result)
+}
+
+// RemoveVariant deletes a variant of a product
+func (r *ProductRepositoryImpl) RemoveVariant(ctx context.Context, productID, variantID string) error {
+	query := `DELETE FROM product_variants WHERE id = $1 AND product_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, variantID, productID)
+	if err != nil {
+		return err
+	}
+
+	return variantAffected(result)
+}
+
+// variantAffected reports a variant write that matched no row as not found
+func variantAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("variant not found")
+	}
+
+	return nil
+}
+
+// UpdateReorderPolicy saves a product's low-stock threshold and reorder quantity
+// without touching its stock
+func (r *ProductRepositoryImpl) UpdateReorderPolicy(ctx context.Context, product *entity.Product) error {
+	query := `UPDATE products SET low_stock_threshold = $2, reorder_quantity = $3, updated_at = $4 WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		product.ID(),
+		product.LowStockThreshold(),
+		product.ReorderQuantity(),
+		product.UpdatedAt(),
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("product %w", repository.ErrNotFound)
 	}
 
 	return nil
 }
 
-// Delete removes a product
+// AddImage appends an image to a product's images, making it primary when the product has none.
+// Only product_images is written, so concurrent stock or variant changes are not overwritten.
+func (r *ProductRepositoryImpl) AddImage(ctx context.Context, productID string, image *entity.ProductImage) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(ctx, tx, productID); err != nil {
+		return err
+	}
+
+	thumbnails, err := marshalThumbnails(image)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO product_images (id, product_id, position, is_primary, alt_text, content_type, size_bytes,
+			original_key, original_url, width, height, thumbnails, created_at)
+		SELECT $1, $2, COALESCE(MAX(position) + 1, 0), NOT COALESCE(BOOL_OR(is_primary), FALSE),
+			$3, $4, $5, $6, $7, $8, $9, $10, $11
+		FROM product_images
+		WHERE product_id = $2
+	`
+
+	original := image.Original()
+	_, err = tx.ExecContext(ctx, query,
+		image.ID(),
+		productID,
+		image.AltText(),
+		image.ContentType(),
+		image.Size(),
+		original.Key,
+		original.URL,
+		original.Width,
+		original.Height,
+		thumbnails,
+		image.CreatedAt(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return commitTx(ctx, tx, "product.image.add", image.ID())
+}
+
+// DeleteImage removes an image from a product; if it was primary, the next image in order
+// becomes primary
+func (r *ProductRepositoryImpl) DeleteImage(ctx context.Context, productID, imageID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(ctx, tx, productID); err != nil {
+		return err
+	}
+
+	var wasPrimary bool
+	query := `DELETE FROM product_images WHERE product_id = $1 AND id = $2 RETURNING is_primary`
+	err = tx.QueryRowContext(ctx, query, productID, imageID).Scan(&wasPrimary)
+	if err == sql.ErrNoRows {
+		return errors.New("image not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	if wasPrimary {
+		promoteQuery := `
+			UPDATE product_images SET is_primary = TRUE
+			WHERE id = (SELECT id FROM product_images WHERE product_id = $1 ORDER BY position, id LIMIT 1)
+		`
+		if _, err := tx.ExecContext(ctx, promoteQuery, productID); err != nil {
+			return err
+		}
+	}
+
+	return commitTx(ctx, tx, "product.image.delete", imageID)
+}
+
+// ReorderImages sets the display order of a product's images. Images not listed, such as one
+// uploaded since the caller read the product, keep their relative order after the listed ones.
+func (r *ProductRepositoryImpl) ReorderImages(ctx context.Context, productID string, imageIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(ctx, tx, productID); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE product_images
+		SET position = COALESCE(array_position($2::text[], id::text) - 1, cardinality($2::text[]) + position)
+		WHERE product_id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, productID, pq.Array(imageIDs)); err != nil {
+		return err
+	}
+
+	return commitTx(ctx, tx, "product.image.reorder", productID)
+}
+
+// SetPrimaryImage marks one of a product's images as primary
+func (r *ProductRepositoryImpl) SetPrimaryImage(ctx context.Context, productID, imageID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockProduct(ctx, tx, productID); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE product_images SET is_primary = (id = $2)
+		WHERE product_id = $1 AND EXISTS (SELECT 1 FROM product_images WHERE product_id = $1 AND id = $2)
+	`
+	result, err := tx.ExecContext(ctx, query, productID, imageID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("image not found")
+	}
+
+	return commitTx(ctx, tx, "product.image.primary", imageID)
+}
+
+// lockProduct locks a product row for the rest of the transaction so concurrent image
+// changes to the same product apply one after another
+func lockProduct(ctx context.Context, tx *sql.Tx, productID string) error {
+	var id string
+	err := tx.QueryRowContext(ctx, `SELECT id FROM products WHERE id = $1 FOR UPDATE`, productID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("product %w", repository.ErrNotFound)
+	}
+	return err
+}
+
+// Archive marks a product archived and removes it from every basket in one transaction,
+// returning the number of baskets changed
+func (r *ProductRepositoryImpl) Archive(ctx context.Context, product *entity.Product) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE products SET updated_at = $2, deleted_at = $3 WHERE id = $1`
+
+	result, err := tx.ExecContext(ctx, query, product.ID(), product.UpdatedAt(), product.DeletedAt())
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if rowsAffected == 0 {
+		return 0, fmt.Errorf("product %w", repository.ErrNotFound)
+	}
+
+	basketsQuery := `
+		WITH removed AS (
+			DELETE FROM basket_items WHERE product_id = $1
+			RETURNING basket_id
+		)
+		UPDATE baskets SET updated_at = $2
+		WHERE id IN (SELECT basket_id FROM removed)
+	`
+
+	result, err = tx.ExecContext(ctx, basketsQuery, product.ID(), time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	baskets, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := commitTx(ctx, tx, "product.archive", product.ID()); err != nil {
+		return 0, err
+	}
+
+	return int(baskets), nil
+}
+
+// Delete permanently removes a product
 func (r *ProductRepositoryImpl) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM products WHERE id = $1`
 
@@ -182,7 +598,7 @@ func (r *ProductRepositoryImpl) Delete(ctx context.Context, id string) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("product not found")
+		return fmt.Errorf("product %w", repository.ErrNotFound)
 	}
 
 	return nil
@@ -197,3 +613,444 @@ func (r *ProductRepositoryImpl) ExistsByID(ctx context.Context, id string) (bool
 
 	return exists, err
 }
+
+// IsReferencedByOrders checks if any order item refers to the product
+func (r *ProductRepositoryImpl) IsReferencedByOrders(ctx context.Context, id string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM order_items WHERE product_id = $1)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&exists)
+
+	return exists, err
+}
+
+// ExistsBySKU checks if a product or variant uses the given SKU
+func (r *ProductRepositoryImpl) ExistsBySKU(ctx context.Context, sku string) (bool, error) {
+	query := `
+		SELECT EXISTS(SELECT 1 FROM products WHERE sku = $1)
+			OR EXISTS(SELECT 1 FROM product_variants WHERE sku = $1)
+	`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, sku).Scan(&exists)
+
+	return exists, err
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// productRow holds the products columns of a product before its options, variants and
+// images are attached
+type productRow struct {
+	id, name, description, category, currency string
+	sku                                       sql.NullString
+	priceAmount                               int64
+	stock                                     int
+	createdAt, updatedAt, deletedAt           sql.NullTime
+	ratingCount, ratingTotal                  int
+	lowStockThreshold, reorderQuantity        int
+}
+
+// scanProductRow scans the products columns of a row
+func scanProductRow(row rowScanner) (*productRow, error) {
+	var p productRow
+	if err := row.Scan(
+		&p.id, &p.sku, &p.name, &p.description, &p.category, &p.priceAmount, &p.currency, &p.stock,
+		&p.createdAt, &p.updatedAt, &p.deletedAt, &p.ratingCount, &p.ratingTotal, &p.lowStockThreshold, &p.reorderQuantity,
+	); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// findProduct runs a query for at most one product and loads its options, variants and images
+func (r *ProductRepositoryImpl) findProduct(ctx context.Context, query string, args ...interface{}) (*entity.Product, error) {
+	row, err := scanProductRow(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := r.loadProducts(ctx, []*productRow{row})
+	if err != nil {
+		return nil, err
+	}
+
+	return products[0], nil
+}
+
+// queryProducts runs a product query, then loads the options, variants and images of every
+// product with one query each. The product rows are closed first, so loading never holds
+// a second connection.
+func (r *ProductRepositoryImpl) queryProducts(ctx context.Context, query string, args ...interface{}) ([]*entity.Product, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	productRows := make([]*productRow, 0)
+
+	for rows.Next() {
+		row, err := scanProductRow(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		productRows = append(productRows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	return r.loadProducts(ctx, productRows)
+}
+
+// loadProducts attaches options, variants and images to product rows, keeping their order
+func (r *ProductRepositoryImpl) loadProducts(ctx context.Context, productRows []*productRow) ([]*entity.Product, error) {
+	products := make([]*entity.Product, 0, len(productRows))
+	if len(productRows) == 0 {
+		return products, nil
+	}
+
+	ids := make([]string, 0, len(productRows))
+	for _, row := range productRows {
+		ids = append(ids, row.id)
+	}
+
+	options, err := r.findOptions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := r.findVariants(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	images, primaryImageIDs, err := r.findImages(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range productRows {
+		price, err := value.NewMoney(row.priceAmount, row.currency)
+		if err != nil {
+			return nil, err
+		}
+
+		stockQty, err := value.NewQuantity(row.stock)
+		if err != nil {
+			return nil, err
+		}
+
+		var archivedAt *time.Time
+		if row.deletedAt.Valid {
+			archivedAt = &row.deletedAt.Time
+		}
+
+		products = append(products, entity.ReconstructProduct(
+			row.id, row.sku.String, row.name, row.description, row.category, price, stockQty,
+			orEmpty(options[row.id]), orEmpty(variants[row.id]), orEmpty(images[row.id]), primaryImageIDs[row.id],
+			row.createdAt.Time, row.updatedAt.Time, archivedAt,
+			row.ratingCount, row.ratingTotal, row.lowStockThreshold, row.reorderQuantity,
+		))
+	}
+
+	return products, nil
+}
+
+// orEmpty returns an empty slice in place of nil, so products without children match
+// what the entity constructors produce
+func orEmpty[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// saveOptions saves product options within a transaction
+func (r *ProductRepositoryImpl) saveOptions(ctx context.Context, tx *sql.Tx, product *entity.Product) error {
+	query := `
+		INSERT INTO product_options (product_id, position, name, option_values)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	for i, option := range product.Options() {
+		_, err := tx.ExecContext(ctx, query, product.ID(), i, option.Name(), pq.Array(option.Values()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveVariants upserts product variants within a transaction
+func (r *ProductRepositoryImpl) saveVariants(ctx context.Context, tx *sql.Tx, product *entity.Product) error {
+	query := `
+		INSERT INTO product_variants (id, product_id, sku, barcode, options, price_amount, price_currency, stock, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE
+		SET sku = EXCLUDED.sku, barcode = EXCLUDED.barcode, options = EXCLUDED.options,
+			price_amount = EXCLUDED.price_amount, price_currency = EXCLUDED.price_currency,
+			stock = EXCLUDED.stock, updated_at = EXCLUDED.updated_at
+	`
+
+	for _, variant := range product.Variants() {
+		options, err := json.Marshal(variant.Options())
+		if err != nil {
+			return err
+		}
+
+		priceAmount, priceCurrency := variantPrice(variant)
+		_, err = tx.ExecContext(ctx, query,
+			variant.ID(),
+			product.ID(),
+			variant.SKU(),
+			variant.Barcode(),
+			options,
+			priceAmount,
+			priceCurrency,
+			variant.Stock().Value(),
+			variant.CreatedAt(),
+			variant.UpdatedAt(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// variantPrice returns a variant's price override as nullable columns
+func variantPrice(variant *entity.ProductVariant) (sql.NullInt64, sql.NullString) {
+	override := variant.PriceOverride()
+	if override == nil {
+		return sql.NullInt64{}, sql.NullString{}
+	}
+	return sql.NullInt64{Int64: override.Amount(), Valid: true}, sql.NullString{String: override.Currency(), Valid: true}
+}
+
+// findOptions retrieves the options of the given products, keyed by product ID
+func (r *ProductRepositoryImpl) findOptions(ctx context.Context, productIDs []string) (map[string][]*entity.ProductOption, error) {
+	query := `
+		SELECT product_id, name, option_values
+		FROM product_options
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	options := make(map[string][]*entity.ProductOption)
+
+	for rows.Next() {
+		var productID, name string
+		var values []string
+
+		if err := rows.Scan(&productID, &name, pq.Array(&values)); err != nil {
+			return nil, err
+		}
+
+		option, err := entity.NewProductOption(name, values)
+		if err != nil {
+			return nil, err
+		}
+
+		options[productID] = append(options[productID], option)
+	}
+
+	return options, rows.Err()
+}
+
+// findVariants retrieves the variants of the given products, keyed by product ID
+func (r *ProductRepositoryImpl) findVariants(ctx context.Context, productIDs []string) (map[string][]*entity.ProductVariant, error) {
+	query := `
+		SELECT product_id, id, sku, barcode, options, price_amount, price_currency, stock, created_at, updated_at
+		FROM product_variants
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := make(map[string][]*entity.ProductVariant)
+
+	for rows.Next() {
+		var (
+			productID, id, sku   string
+			barcode              sql.NullString
+			rawOptions           []byte
+			priceAmount          sql.NullInt64
+			priceCurrency        sql.NullString
+			stock                int
+			createdAt, updatedAt sql.NullTime
+		)
+
+		if err := rows.Scan(
+			&productID, &id, &sku, &barcode, &rawOptions, &priceAmount, &priceCurrency, &stock, &createdAt, &updatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		options := make(map[string]string)
+		if err := json.Unmarshal(rawOptions, &options); err != nil {
+			return nil, err
+		}
+
+		var price *value.Money
+		if priceAmount.Valid {
+			price, err = value.NewMoney(priceAmount.Int64, priceCurrency.String)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		stockQty, err := value.NewQuantity(stock)
+		if err != nil {
+			return nil, err
+		}
+
+		variants[productID] = append(variants[productID], entity.ReconstructProductVariant(
+			id, sku, barcode.String, options, price, stockQty,
+			createdAt.Time, updatedAt.Time,
+		))
+	}
+
+	return variants, rows.Err()
+}
+
+// imageRenditionRecord is the JSON form of a thumbnail rendition
+type imageRenditionRecord struct {
+	Name   string `json:"name"`
+	Key    string `json:"key"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// saveImages upserts product images and their order within a transaction
+func (r *ProductRepositoryImpl) saveImages(ctx context.Context, tx *sql.Tx, product *entity.Product) error {
+	query := `
+		INSERT INTO product_images (id, product_id, position, is_primary, alt_text, content_type, size_bytes,
+			original_key, original_url, width, height, thumbnails, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (id) DO UPDATE
+		SET position = EXCLUDED.position, is_primary = EXCLUDED.is_primary, alt_text = EXCLUDED.alt_text
+	`
+
+	primary := product.PrimaryImage()
+
+	for i, img := range product.Images() {
+		thumbnails, err := marshalThumbnails(img)
+		if err != nil {
+			return err
+		}
+
+		original := img.Original()
+		_, err = tx.ExecContext(ctx, query,
+			img.ID(),
+			product.ID(),
+			i,
+			primary != nil && primary.ID() == img.ID(),
+			img.AltText(),
+			img.ContentType(),
+			img.Size(),
+			original.Key,
+			original.URL,
+			original.Width,
+			original.Height,
+			thumbnails,
+			img.CreatedAt(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// marshalThumbnails encodes an image's thumbnails for the thumbnails column
+func marshalThumbnails(img *entity.ProductImage) ([]byte, error) {
+	records := make([]imageRenditionRecord, 0, len(img.Thumbnails()))
+	for _, t := range img.Thumbnails() {
+		records = append(records, imageRenditionRecord(t))
+	}
+	return json.Marshal(records)
+}
+
+// findImages retrieves the images of the given products in display order along with their
+// primary image IDs, both keyed by product ID
+func (r *ProductRepositoryImpl) findImages(ctx context.Context, productIDs []string) (map[string][]*entity.ProductImage, map[string]string, error) {
+	query := `
+		SELECT product_id, id, is_primary, alt_text, content_type, size_bytes, original_key, original_url, width, height,
+			thumbnails, created_at
+		FROM product_images
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	images := make(map[string][]*entity.ProductImage)
+	primaryIDs := make(map[string]string)
+
+	for rows.Next() {
+		var (
+			productID, id        string
+			altText, contentType string
+			isPrimary            bool
+			size                 int64
+			original             entity.ImageRendition
+			rawThumbnails        []byte
+			createdAt            sql.NullTime
+		)
+
+		if err := rows.Scan(
+			&productID, &id, &isPrimary, &altText, &contentType, &size,
+			&original.Key, &original.URL, &original.Width, &original.Height,
+			&rawThumbnails, &createdAt,
+		); err != nil {
+			return nil, nil, err
+		}
+
+		var records []imageRenditionRecord
+		if err := json.Unmarshal(rawThumbnails, &records); err != nil {
+			return nil, nil, err
+		}
+
+		thumbnails := make([]entity.ImageRendition, 0, len(records))
+		for _, rec := range records {
+			thumbnails = append(thumbnails, entity.ImageRendition(rec))
+		}
+
+		original.Name = "original"
+		if isPrimary {
+			primaryIDs[productID] = id
+		}
+
+		images[productID] = append(images[productID], entity.ReconstructProductImage(
+			id, altText, contentType, size, original, thumbnails, createdAt.Time,
+		))
+	}
+
+	return images, primaryIDs, rows.Err()
+}
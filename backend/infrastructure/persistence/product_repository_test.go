@@ -3,9 +3,13 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"ecom-backend/domain/entity"
 	"ecom-backend/domain/value"
+	"ecom-backend/infrastructure/database"
+	"strings"
 	"testing"
+	"time"
 
 	_ "github.com/lib/pq"
 )
@@ -27,20 +31,8 @@ func setupTestDB(t *testing.T) *sql.DB {
 	}
 
 	// Create tables for testing
-	_, err = db.Exec(`
-		CREATE TABLE IF NOT EXISTS products (
-			id VARCHAR(36) PRIMARY KEY,
-			name VARCHAR(255) NOT NULL,
-			description TEXT,
-			price_amount BIGINT NOT NULL,
-			price_currency VARCHAR(3) NOT NULL,
-			stock INTEGER NOT NULL,
-			created_at TIMESTAMP NOT NULL,
-			updated_at TIMESTAMP NOT NULL
-		)
-	`)
-	if err != nil {
-		t.Fatalf("Failed to create table: %v", err)
+	if err := database.RunMigrations(db); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
 	}
 
 	return db
@@ -193,3 +185,62 @@ func TestProductRepository_Integration(t *testing.T) {
 		}
 	})
 }
+
+func TestProductRepository_FindByIDs_Batched(t *testing.T) {
+	now := time.Now()
+	product := func(id, name string) []driver.Value {
+		return []driver.Value{id, nil, name, "", "", int64(1999), "USD", int64(0), now, now, nil,
+			int64(0), int64(0), int64(0), int64(0)}
+	}
+
+	fake := &fakeDB{answer: func(query string) fakeResult {
+		switch {
+		case strings.Contains(query, "FROM product_options"):
+			return fakeResult{
+				columns: []string{"product_id", "name", "option_values"},
+				rows:    [][]driver.Value{{"p2", "size", []byte("{S,M}")}},
+			}
+		case strings.Contains(query, "FROM product_variants"):
+			return fakeResult{
+				columns: []string{"product_id", "id", "sku", "barcode", "options", "price_amount", "price_currency", "stock", "created_at", "updated_at"},
+				rows: [][]driver.Value{
+					{"p2", "v1", "SHIRT-S", nil, []byte(`{"size":"S"}`), nil, nil, int64(3), now, now},
+					{"p2", "v2", "SHIRT-M", nil, []byte(`{"size":"M"}`), nil, nil, int64(0), now, now},
+				},
+			}
+		case strings.Contains(query, "FROM product_images"):
+			return fakeResult{columns: []string{"product_id", "id", "is_primary", "alt_text", "content_type", "size_bytes",
+				"original_key", "original_url", "width", "height", "thumbnails", "created_at"}}
+		default:
+			return fakeResult{
+				columns: []string{"id", "sku", "name", "description", "category", "price_amount", "price_currency", "stock",
+					"created_at", "updated_at", "deleted_at", "rating_count", "rating_total", "low_stock_threshold", "reorder_quantity"},
+				rows: [][]driver.Value{product("p1", "Mug"), product("p2", "Shirt"), product("p3", "Lamp")},
+			}
+		}
+	}}
+	db := fake.open()
+	defer db.Close()
+
+	// A nested query on the single connection would block until the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	products, err := NewProductRepository(db).FindByIDs(ctx, []string{"p1", "p2", "p3"})
+	if err != nil {
+		t.Fatalf("FindByIDs failed: %v", err)
+	}
+
+	if queries := fake.statements(); len(queries) != 4 {
+		t.Errorf("expected 4 queries for 3 products, got %d:\n%s", len(queries), strings.Join(queries, "\n"))
+	}
+	if len(products) != 3 || products[0].ID() != "p1" || products[1].ID() != "p2" || products[2].ID() != "p3" {
+		t.Fatalf("expected the 3 products in query order, got %d", len(products))
+	}
+	if len(products[1].Options()) != 1 || len(products[1].Variants()) != 2 {
+		t.Errorf("expected the shirt's option and variants, got %d and %d", len(products[1].Options()), len(products[1].Variants()))
+	}
+	if products[0].HasVariants() || len(products[2].Options()) != 0 {
+		t.Error("expected children to be attached only to their own product")
+	}
+}
@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"ecom-backend/domain/repository"
+	"sort"
+)
+
+// facetCounter accumulates search facet counts over matching products
+type facetCounter struct {
+	bands      []repository.PriceBand
+	categories map[string]int
+	bandCounts []int
+	inStock    int
+	outOfStock int
+}
+
+func newFacetCounter(bands []repository.PriceBand) *facetCounter {
+	return &facetCounter{
+		bands:      bands,
+		categories: make(map[string]int),
+		bandCounts: make([]int, len(bands)),
+	}
+}
+
+// add records a matching product
+func (c *facetCounter) add(category string, price int64, available bool) {
+	band := -1
+	for i, b := range c.bands {
+		if b.Contains(price) {
+			band = i
+			break
+		}
+	}
+
+	c.addGroup(category, band, available, 1)
+}
+
+// addGroup records count matching products sharing a category, price band and availability.
+// A band of -1 means the price falls in no band.
+func (c *facetCounter) addGroup(category string, band int, available bool, count int) {
+	if category != "" {
+		c.categories[category] += count
+	}
+
+	if band >= 0 && band < len(c.bandCounts) {
+		c.bandCounts[band] += count
+	}
+
+	if available {
+		c.inStock += count
+	} else {
+		c.outOfStock += count
+	}
+}
+
+// facets returns the accumulated counts, with categories ordered by count
+func (c *facetCounter) facets() repository.ProductSearchFacets {
+	categories := make([]repository.FacetCount, 0, len(c.categories))
+	for category, count := range c.categories {
+		categories = append(categories, repository.FacetCount{Value: category, Count: count})
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Count != categories[j].Count {
+			return categories[i].Count > categories[j].Count
+		}
+		return categories[i].Value < categories[j].Value
+	})
+
+	bands := make([]repository.FacetCount, 0, len(c.bands))
+	for i, band := range c.bands {
+		bands = append(bands, repository.FacetCount{Value: band.Label, Count: c.bandCounts[i]})
+	}
+
+	return repository.ProductSearchFacets{
+		Categories: categories,
+		PriceBands: bands,
+		Availability: []repository.FacetCount{
+			{Value: repository.AvailabilityInStock, Count: c.inStock},
+			{Value: repository.AvailabilityOutOfStock, Count: c.outOfStock},
+		},
+	}
+}
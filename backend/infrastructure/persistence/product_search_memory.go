@@ -0,0 +1,211 @@
+package persistence
+
+import (
+	"context"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Relative weight of matches in the product name versus the description
+const (
+	nameMatchWeight        = 1.0
+	descriptionMatchWeight = 0.4
+)
+
+// snippetWords is the maximum number of words in a description snippet
+const snippetWords = 25
+
+// InMemoryProductSearch implements ProductSearchRepository by scanning a ProductRepository.
+// It is intended for tests and small catalogs. Query terms are crudely stemmed and
+// match words by prefix, so simple plurals behave like the Postgres implementation.
+type InMemoryProductSearch struct {
+	products repository.ProductRepository
+	bands    []repository.PriceBand
+}
+
+// NewInMemoryProductSearch creates a new InMemoryProductSearch over the given repository
+func NewInMemoryProductSearch(products repository.ProductRepository) repository.ProductSearchRepository {
+	return &InMemoryProductSearch{
+		products: products,
+		bands:    repository.DefaultPriceBands,
+	}
+}
+
+// Search returns products matching every query term, ordered by relevance
+func (s *InMemoryProductSearch) Search(ctx context.Context, query *repository.ProductSearchQuery) (*repository.ProductSearchResult, error) {
+	products, err := s.products.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := queryTerms(query.Text)
+	counter := newFacetCounter(s.bands)
+	hits := make([]*repository.ProductSearchHit, 0)
+
+	for _, product := range products {
+		if !matchesFilters(product, query) {
+			continue
+		}
+
+		rank, ok := rankProduct(product, terms)
+		if !ok {
+			continue
+		}
+
+		counter.add(product.Category(), product.Price().Amount(), product.IsAvailable())
+		hits = append(hits, &repository.ProductSearchHit{
+			Product:            product,
+			Rank:               rank,
+			NameSnippet:        highlight(strings.Fields(product.Name()), terms),
+			DescriptionSnippet: snippet(product.Description(), terms),
+		})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hits[i].Product.Name() < hits[j].Product.Name()
+	})
+
+	total := len(hits)
+	start := min(query.Offset, total)
+	end := total
+	if query.Limit > 0 {
+		end = min(start+query.Limit, total)
+	}
+
+	return &repository.ProductSearchResult{
+		Hits:   hits[start:end],
+		Total:  total,
+		Facets: counter.facets(),
+	}, nil
+}
+
+// matchesFilters checks the non-text filters of a query
+func matchesFilters(product *entity.Product, query *repository.ProductSearchQuery) bool {
+	price := product.Price().Amount()
+
+	if product.IsArchived() {
+		return false
+	}
+	if query.Category != "" && product.Category() != query.Category {
+		return false
+	}
+	if query.MinPrice != nil && price < *query.MinPrice {
+		return false
+	}
+	if query.MaxPrice != nil && price > *query.MaxPrice {
+		return false
+	}
+	if query.InStock != nil && product.IsAvailable() != *query.InStock {
+		return false
+	}
+
+	return true
+}
+
+// rankProduct scores a product against the query terms; every term must match
+func rankProduct(product *entity.Product, terms []string) (float64, bool) {
+	if len(terms) == 0 {
+		return 0, true
+	}
+
+	nameTokens := tokenize(product.Name())
+	descriptionTokens := tokenize(product.Description())
+
+	rank := 0.0
+	for _, term := range terms {
+		nameMatches := countMatches(nameTokens, term)
+		descriptionMatches := countMatches(descriptionTokens, term)
+		if nameMatches == 0 && descriptionMatches == 0 {
+			return 0, false
+		}
+		rank += float64(nameMatches)*nameMatchWeight + float64(descriptionMatches)*descriptionMatchWeight
+	}
+
+	return rank, true
+}
+
+// tokenize splits text into lowercase words
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// queryTerms tokenizes query text and strips plural suffixes
+func queryTerms(text string) []string {
+	terms := tokenize(text)
+	for i, term := range terms {
+		if len(term) > 3 && strings.HasSuffix(term, "s") && !strings.HasSuffix(term, "ss") {
+			terms[i] = strings.TrimSuffix(term, "s")
+		}
+	}
+	return terms
+}
+
+// countMatches counts the tokens that start with term
+func countMatches(tokens []string, term string) int {
+	count := 0
+	for _, token := range tokens {
+		if strings.HasPrefix(token, term) {
+			count++
+		}
+	}
+	return count
+}
+
+// matchesAny checks if a word matches any of the query terms
+func matchesAny(word string, terms []string) bool {
+	tokens := tokenize(word)
+	for _, term := range terms {
+		if countMatches(tokens, term) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// highlight joins HTML-escaped words, wrapping those that match a term in <mark></mark>
+func highlight(words []string, terms []string) string {
+	out := make([]string, len(words))
+	for i, word := range words {
+		if matchesAny(word, terms) {
+			out[i] = "<mark>" + html.EscapeString(word) + "</mark>"
+		} else {
+			out[i] = html.EscapeString(word)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// snippet returns a highlighted fragment of text around the first matching word
+func snippet(text string, terms []string) string {
+	words := strings.Fields(text)
+	if len(words) <= snippetWords {
+		return highlight(words, terms)
+	}
+
+	start := 0
+	for i, word := range words {
+		if matchesAny(word, terms) {
+			start = max(i-snippetWords/3, 0)
+			break
+		}
+	}
+	end := min(start+snippetWords, len(words))
+
+	fragment := highlight(words[start:end], terms)
+	if start > 0 {
+		fragment = "... " + fragment
+	}
+	if end < len(words) {
+		fragment += " ..."
+	}
+	return fragment
+}
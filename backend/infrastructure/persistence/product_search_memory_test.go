@@ -0,0 +1,124 @@
+package persistence
+
+import (
+	"context"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"ecom-backend/domain/value"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// stubProductRepository is a minimal in-memory ProductRepository for search tests
+type stubProductRepository struct {
+	repository.ProductRepository
+	products []*entity.Product
+}
+
+func (s *stubProductRepository) FindAll(ctx context.Context) ([]*entity.Product, error) {
+	return s.products, nil
+}
+
+func (s *stubProductRepository) FindByID(ctx context.Context, id string) (*entity.Product, error) {
+	for _, p := range s.products {
+		if p.ID() == id {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("product %w", repository.ErrNotFound)
+}
+
+func newSearchFixture(t *testing.T) *stubProductRepository {
+	t.Helper()
+
+	create := func(name, description, category string, price int64, stock int) *entity.Product {
+		money, _ := value.NewMoney(price, "USD")
+		qty, _ := value.NewQuantity(stock)
+		product, err := entity.NewProduct(name, description, money, qty)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		product.Categorize(category)
+		return product
+	}
+
+	return &stubProductRepository{products: []*entity.Product{
+		create("Red Shirt", "A bright cotton shirt", "apparel", 1999, 5),
+		create("Blue Jeans", "Denim jeans that go with any shirt", "apparel", 4999, 0),
+		create("Coffee Mug", "Ceramic mug", "kitchen", 899, 12),
+	}}
+}
+
+func TestInMemoryProductSearch_Search(t *testing.T) {
+	search := NewInMemoryProductSearch(newSearchFixture(t))
+	ctx := context.Background()
+
+	t.Run("Ranks name matches above description matches", func(t *testing.T) {
+		result, err := search.Search(ctx, &repository.ProductSearchQuery{Text: "shirts"})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if result.Total != 2 {
+			t.Fatalf("Expected 2 results, got %d", result.Total)
+		}
+		if result.Hits[0].Product.Name() != "Red Shirt" {
+			t.Errorf("Expected Red Shirt first, got %s", result.Hits[0].Product.Name())
+		}
+		if !strings.Contains(result.Hits[0].NameSnippet, "<mark>Shirt</mark>") {
+			t.Errorf("Expected highlighted name, got %q", result.Hits[0].NameSnippet)
+		}
+	})
+
+	t.Run("Escapes markup in product text", func(t *testing.T) {
+		money, _ := value.NewMoney(500, "USD")
+		qty, _ := value.NewQuantity(1)
+		product, _ := entity.NewProduct("<script>alert(1)</script> Shirt", "<b>bold</b> shirt", money, qty)
+		search := NewInMemoryProductSearch(&stubProductRepository{products: []*entity.Product{product}})
+
+		result, err := search.Search(ctx, &repository.ProductSearchQuery{Text: "shirt"})
+		if err != nil || len(result.Hits) != 1 {
+			t.Fatalf("Search failed: %v, %+v", err, result)
+		}
+		hit := result.Hits[0]
+		if hit.NameSnippet != "&lt;script&gt;alert(1)&lt;/script&gt; <mark>Shirt</mark>" {
+			t.Errorf("Expected an escaped name, got %q", hit.NameSnippet)
+		}
+		if hit.DescriptionSnippet != "&lt;b&gt;bold&lt;/b&gt; <mark>shirt</mark>" {
+			t.Errorf("Expected an escaped description, got %q", hit.DescriptionSnippet)
+		}
+	})
+
+	t.Run("Requires every term to match", func(t *testing.T) {
+		result, _ := search.Search(ctx, &repository.ProductSearchQuery{Text: "cotton jeans"})
+		if result.Total != 0 {
+			t.Errorf("Expected no results, got %d", result.Total)
+		}
+	})
+
+	t.Run("Applies filters and computes facets", func(t *testing.T) {
+		inStock := true
+		result, _ := search.Search(ctx, &repository.ProductSearchQuery{InStock: &inStock})
+		if result.Total != 2 {
+			t.Fatalf("Expected 2 in-stock results, got %d", result.Total)
+		}
+
+		result, _ = search.Search(ctx, &repository.ProductSearchQuery{})
+		if result.Facets.Categories[0].Value != "apparel" || result.Facets.Categories[0].Count != 2 {
+			t.Errorf("Expected apparel facet with 2 products, got %+v", result.Facets.Categories[0])
+		}
+		if result.Facets.PriceBands[0].Count != 1 || result.Facets.PriceBands[1].Count != 1 {
+			t.Errorf("Unexpected price band counts: %+v", result.Facets.PriceBands)
+		}
+		if result.Facets.Availability[1].Count != 1 {
+			t.Errorf("Expected 1 out-of-stock product, got %d", result.Facets.Availability[1].Count)
+		}
+	})
+
+	t.Run("Paginates", func(t *testing.T) {
+		result, _ := search.Search(ctx, &repository.ProductSearchQuery{Limit: 2, Offset: 2})
+		if result.Total != 3 || len(result.Hits) != 1 {
+			t.Errorf("Expected 1 hit of 3, got %d of %d", len(result.Hits), result.Total)
+		}
+	})
+}
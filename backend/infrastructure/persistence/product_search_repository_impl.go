@@ -0,0 +1,209 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// ts_headline marks matches with these private-use characters rather than <mark></mark>, so
+// the snippet can be HTML-escaped before the marks are put in; see markSnippet
+const (
+	headlineStart = "\ue000"
+	headlineStop  = "\ue001"
+)
+
+var headlineMarks = strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>")
+
+// availableExpr is true when an active product has stock; a product with variants is
+// available when any variant has stock, like entity.Product.IsAvailable
+const availableExpr = `(p.deleted_at IS NULL AND CASE
+	WHEN EXISTS(SELECT 1 FROM product_variants v WHERE v.product_id = p.id)
+	THEN EXISTS(SELECT 1 FROM product_variants v WHERE v.product_id = p.id AND v.stock > 0)
+	ELSE p.stock > 0
+END)`
+
+// ProductSearchRepositoryImpl implements ProductSearchRepository using PostgreSQL full-text search
+type ProductSearchRepositoryImpl struct {
+	db       *sql.DB
+	products repository.ProductRepository
+	bands    []repository.PriceBand
+}
+
+// NewProductSearchRepository creates a new ProductSearchRepositoryImpl
+func NewProductSearchRepository(db *sql.DB) repository.ProductSearchRepository {
+	return &ProductSearchRepositoryImpl{
+		db:       db,
+		products: NewProductRepository(db),
+		bands:    repository.DefaultPriceBands,
+	}
+}
+
+// Search returns products matching the query, ranked with ts_rank over the search_vector column
+func (r *ProductSearchRepositoryImpl) Search(ctx context.Context, query *repository.ProductSearchQuery) (*repository.ProductSearchResult, error) {
+	from, where, args := r.buildFilter(query)
+
+	// Facets and total are counted over every match, grouped in the database
+	facetQuery := fmt.Sprintf(`
+		SELECT p.category, %s, %s, COUNT(*)
+		FROM %s
+		WHERE %s
+		GROUP BY 1, 2, 3
+	`, r.bandExpr(), availableExpr, from, where)
+
+	rows, err := r.db.QueryContext(ctx, facetQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counter := newFacetCounter(r.bands)
+	total := 0
+	for rows.Next() {
+		var category string
+		var band, count int
+		var available bool
+
+		if err := rows.Scan(&category, &band, &available, &count); err != nil {
+			return nil, err
+		}
+
+		counter.addGroup(category, band, available, count)
+		total += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Ranked page of hits
+	rank := `0::real`
+	nameSnippet := `p.name`
+	descriptionSnippet := `coalesce(p.description, '')`
+	if query.Text != "" {
+		rank = `ts_rank(p.search_vector, q)`
+		selectors := "StartSel=" + headlineStart + ", StopSel=" + headlineStop
+		nameSnippet = `ts_headline('english', p.name, q, '` + selectors + `, HighlightAll=true')`
+		descriptionSnippet = `ts_headline('english', coalesce(p.description, ''), q,
+			'` + selectors + `, MaxFragments=2, MaxWords=25, MinWords=10')`
+	}
+
+	hitArgs := append(args, query.Limit, query.Offset)
+	hitQuery := fmt.Sprintf(`
+		SELECT p.id, %s, %s, %s
+		FROM %s
+		WHERE %s
+		ORDER BY 2 DESC, p.name
+		LIMIT $%d OFFSET $%d
+	`, rank, nameSnippet, descriptionSnippet, from, where, len(args)+1, len(args)+2)
+
+	hitRows, err := r.db.QueryContext(ctx, hitQuery, hitArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer hitRows.Close()
+
+	ids := make([]string, 0)
+	hits := make([]*repository.ProductSearchHit, 0)
+	for hitRows.Next() {
+		var id string
+		hit := &repository.ProductSearchHit{}
+
+		if err := hitRows.Scan(&id, &hit.Rank, &hit.NameSnippet, &hit.DescriptionSnippet); err != nil {
+			return nil, err
+		}
+		hit.NameSnippet = markSnippet(hit.NameSnippet)
+		hit.DescriptionSnippet = markSnippet(hit.DescriptionSnippet)
+
+		ids = append(ids, id)
+		hits = append(hits, hit)
+	}
+	if err := hitRows.Err(); err != nil {
+		return nil, err
+	}
+	hitRows.Close()
+
+	// Load the page's products in one batch, keeping the rank order
+	products, err := r.products.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entity.Product, len(products))
+	for _, product := range products {
+		byID[product.ID()] = product
+	}
+
+	loaded := hits[:0]
+	for i, hit := range hits {
+		// A product purged since the hits were read is left out
+		if hit.Product = byID[ids[i]]; hit.Product != nil {
+			loaded = append(loaded, hit)
+		}
+	}
+	hits = loaded
+
+	return &repository.ProductSearchResult{
+		Hits:   hits,
+		Total:  total,
+		Facets: counter.facets(),
+	}, nil
+}
+
+// markSnippet HTML-escapes a ts_headline snippet and turns its match markers into <mark></mark>,
+// so markup in a product's own text is shown as text
+func markSnippet(snippet string) string {
+	return headlineMarks.Replace(html.EscapeString(snippet))
+}
+
+// bandExpr returns an expression for the index of the price band a product falls in, or -1
+func (r *ProductSearchRepositoryImpl) bandExpr() string {
+	var expr strings.Builder
+	expr.WriteString("CASE")
+	for i, band := range r.bands {
+		if band.Max == 0 {
+			fmt.Fprintf(&expr, " WHEN p.price_amount >= %d THEN %d", band.Min, i)
+		} else {
+			fmt.Fprintf(&expr, " WHEN p.price_amount >= %d AND p.price_amount < %d THEN %d", band.Min, band.Max, i)
+		}
+	}
+	expr.WriteString(" ELSE -1 END")
+	return expr.String()
+}
+
+// buildFilter returns the FROM and WHERE clauses and their arguments for a query
+func (r *ProductSearchRepositoryImpl) buildFilter(query *repository.ProductSearchQuery) (string, string, []interface{}) {
+	from := `products p`
+	conditions := []string{"p.deleted_at IS NULL"}
+	args := make([]interface{}, 0)
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.Text != "" {
+		from = fmt.Sprintf(`products p, websearch_to_tsquery('english', %s) q`, arg(query.Text))
+		conditions = append(conditions, `p.search_vector @@ q`)
+	}
+	if query.Category != "" {
+		conditions = append(conditions, `p.category = `+arg(query.Category))
+	}
+	if query.MinPrice != nil {
+		conditions = append(conditions, `p.price_amount >= `+arg(*query.MinPrice))
+	}
+	if query.MaxPrice != nil {
+		conditions = append(conditions, `p.price_amount <= `+arg(*query.MaxPrice))
+	}
+	if query.InStock != nil {
+		if *query.InStock {
+			conditions = append(conditions, availableExpr)
+		} else {
+			conditions = append(conditions, `NOT `+availableExpr)
+		}
+	}
+
+	return from, strings.Join(conditions, " AND "), args
+}
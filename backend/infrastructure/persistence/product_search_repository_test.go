@@ -0,0 +1,27 @@
+package persistence
+
+import "testing"
+
+func TestMarkSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		want    string
+	}{
+		{"plain text", "Red Shirt", "Red Shirt"},
+		{"match", "Red " + headlineStart + "Shirt" + headlineStop, "Red <mark>Shirt</mark>"},
+		{
+			"markup in the product text",
+			headlineStart + "<script>" + headlineStop + "alert(1)</script> & <mark>",
+			"<mark>&lt;script&gt;</mark>alert(1)&lt;/script&gt; &amp; &lt;mark&gt;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markSnippet(tt.snippet); got != tt.want {
+				t.Errorf("markSnippet(%q) = %q, want %q", tt.snippet, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// reviewOrderBy maps each review sort to its ORDER BY clause; ties fall back to the ID
+// so pages are stable
+var reviewOrderBy = map[repository.ReviewSort]string{
+	repository.ReviewSortNewest:  "created_at DESC, id",
+	repository.ReviewSortOldest:  "created_at, id",
+	repository.ReviewSortHighest: "rating DESC, created_at DESC, id",
+	repository.ReviewSortLowest:  "rating, created_at DESC, id",
+}
+
+// ReviewRepositoryImpl implements ReviewRepository using PostgreSQL
+type ReviewRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewReviewRepository creates a new ReviewRepositoryImpl
+func NewReviewRepository(db *sql.DB) repository.ReviewRepository {
+	return &ReviewRepositoryImpl{db: db}
+}
+
+// Save persists a new review
+func (r *ReviewRepositoryImpl) Save(ctx context.Context, review *entity.Review) error {
+	query := `
+		INSERT INTO reviews (id, product_id, order_id, author, rating, title, body, verified_purchase,
+			status, moderation_note, created_at, moderated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		review.ID(),
+		review.ProductID(),
+		review.OrderID(),
+		review.Author(),
+		review.Rating(),
+		review.Title(),
+		review.Body(),
+		review.VerifiedPurchase(),
+		string(review.Status()),
+		review.ModerationNote(),
+		review.CreatedAt(),
+		review.ModeratedAt(),
+	)
+	return err
+}
+
+// FindByID retrieves a review by ID
+func (r *ReviewRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.Review, error) {
+	query := `
+		SELECT id, product_id, order_id, author, rating, title, body, verified_purchase,
+			status, moderation_note, created_at, moderated_at
+		FROM reviews
+		WHERE id = $1
+	`
+
+	review, err := scanReview(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("review not found")
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// Find returns a page of the reviews matching the query and the number of matches
+func (r *ReviewRepositoryImpl) Find(ctx context.Context, query repository.ReviewQuery) ([]*entity.Review, int, error) {
+	orderBy, ok := reviewOrderBy[query.Sort]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown review sort %q", query.Sort)
+	}
+
+	conditions := make([]string, 0, 2)
+	args := make([]interface{}, 0, 4)
+	if query.ProductID != "" {
+		args = append(args, query.ProductID)
+		conditions = append(conditions, fmt.Sprintf("product_id = $%d", len(args)))
+	}
+	if query.Status != "" {
+		args = append(args, string(query.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	where := "TRUE"
+	if len(conditions) > 0 {
+		where = strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM reviews WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, query.Limit, query.Offset)
+	pageQuery := fmt.Sprintf(`
+		SELECT id, product_id, order_id, author, rating, title, body, verified_purchase,
+			status, moderation_note, created_at, moderated_at
+		FROM reviews
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d
+	`, where, orderBy, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, pageQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	reviews := make([]*entity.Review, 0)
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// ExistsForOrder checks if a review of the product was already written for the order
+func (r *ReviewRepositoryImpl) ExistsForOrder(ctx context.Context, productID, orderID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM reviews WHERE product_id = $1 AND order_id = $2)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, productID, orderID).Scan(&exists)
+
+	return exists, err
+}
+
+// UpdateStatus saves a moderation decision and recalculates the product's rating from its
+// approved reviews. The product row is locked first, so concurrent decisions on reviews
+// of the same product each see the other's result.
+func (r *ReviewRepositoryImpl) UpdateStatus(ctx context.Context, review *entity.Review) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM products WHERE id = $1 FOR UPDATE`, review.ProductID()); err != nil {
+		return err
+	}
+
+	query := `UPDATE reviews SET status = $2, moderation_note = $3, moderated_at = $4 WHERE id = $1`
+	result, err := tx.ExecContext(ctx, query, review.ID(), string(review.Status()), review.ModerationNote(), review.ModeratedAt())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("review not found")
+	}
+
+	ratingQuery := `
+		UPDATE products p
+		SET rating_count = r.count, rating_total = r.total
+		FROM (
+			SELECT COUNT(*) AS count, COALESCE(SUM(rating), 0) AS total
+			FROM reviews
+			WHERE product_id = $1 AND status = $2
+		) r
+		WHERE p.id = $1
+	`
+	if _, err := tx.ExecContext(ctx, ratingQuery, review.ProductID(), string(entity.ReviewApproved)); err != nil {
+		return err
+	}
+
+	return commitTx(ctx, tx, "review.moderate", review.ID())
+}
+
+// scanReview scans a review row
+func scanReview(row rowScanner) (*entity.Review, error) {
+	var (
+		id, productID, orderID, author, title, body, status, note string
+		rating                                                    int
+		verified                                                  bool
+		createdAt                                                 time.Time
+		moderatedAt                                               sql.NullTime
+	)
+
+	if err := row.Scan(&id, &productID, &orderID, &author, &rating, &title, &body, &verified,
+		&status, &note, &createdAt, &moderatedAt); err != nil {
+		return nil, err
+	}
+
+	var moderated *time.Time
+	if moderatedAt.Valid {
+		moderated = &moderatedAt.Time
+	}
+
+	return entity.ReconstructReview(id, productID, orderID, author, rating, title, body, verified,
+		entity.ReviewStatus(status), note, createdAt, moderated), nil
+}
@@ -0,0 +1,18 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+)
+
+// commitTx commits a write transaction, logging the outcome with the request context
+func commitTx(ctx context.Context, tx *sql.Tx, op, id string) error {
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "repository commit failed", "op", op, "id", id, "error", err)
+		return err
+	}
+
+	slog.DebugContext(ctx, "repository write committed", "op", op, "id", id)
+	return nil
+}
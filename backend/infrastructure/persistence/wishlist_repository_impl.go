@@ -0,0 +1,302 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"ecom-backend/domain/entity"
+	"ecom-backend/domain/repository"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// WishlistRepositoryImpl implements WishlistRepository using PostgreSQL
+type WishlistRepositoryImpl struct {
+	db *sql.DB
+}
+
+// NewWishlistRepository creates a new WishlistRepositoryImpl
+func NewWishlistRepository(db *sql.DB) repository.WishlistRepository {
+	return &WishlistRepositoryImpl{db: db}
+}
+
+// Save persists a new wishlist
+func (r *WishlistRepositoryImpl) Save(ctx context.Context, wishlist *entity.Wishlist) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO wishlists (id, created_at, updated_at) VALUES ($1, $2, $3)`
+	_, err = tx.ExecContext(ctx, query, wishlist.ID(), wishlist.CreatedAt(), wishlist.UpdatedAt())
+	if err != nil {
+		return err
+	}
+
+	if err := r.saveLists(ctx, tx, wishlist); err != nil {
+		return err
+	}
+
+	return commitTx(ctx, tx, "wishlist.save", wishlist.ID())
+}
+
+// FindByID retrieves a wishlist by ID
+func (r *WishlistRepositoryImpl) FindByID(ctx context.Context, id string) (*entity.Wishlist, error) {
+	query := `SELECT id, created_at, updated_at FROM wishlists WHERE id = $1`
+	return r.findOne(ctx, query, id)
+}
+
+// FindByShareToken retrieves the wishlist holding the list shared with token
+func (r *WishlistRepositoryImpl) FindByShareToken(ctx context.Context, token string) (*entity.Wishlist, error) {
+	query := `
+		SELECT w.id, w.created_at, w.updated_at
+		FROM wishlists w
+		JOIN wishlist_lists l ON l.wishlist_id = w.id
+		WHERE l.share_token = $1
+	`
+	return r.findOne(ctx, query, token)
+}
+
+// Update updates an existing wishlist
+func (r *WishlistRepositoryImpl) Update(ctx context.Context, wishlist *entity.Wishlist) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE wishlists SET updated_at = $2 WHERE id = $1`
+	result, err := tx.ExecContext(ctx, query, wishlist.ID(), wishlist.UpdatedAt())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("wishlist not found")
+	}
+
+	// Replace the lists; their items go with them
+	if _, err := tx.ExecContext(ctx, `DELETE FROM wishlist_lists WHERE wishlist_id = $1`, wishlist.ID()); err != nil {
+		return err
+	}
+	if err := r.saveLists(ctx, tx, wishlist); err != nil {
+		return err
+	}
+
+	return commitTx(ctx, tx, "wishlist.update", wishlist.ID())
+}
+
+// QueueBackInStockAlerts turns off every back-in-stock alert for a product or variant and
+// queues a notice for each list that had one. A single statement keeps concurrent stock
+// updates from queueing the same alert twice, and no alert is turned off without its notice.
+func (r *WishlistRepositoryImpl) QueueBackInStockAlerts(ctx context.Context, productID, variantID string, stock int) (int, error) {
+	query := `
+		WITH taken AS (
+			UPDATE wishlist_items i SET notify_back_in_stock = FALSE
+			FROM wishlist_lists l
+			WHERE l.id = i.list_id
+				AND i.product_id = $1 AND i.variant_id = $2
+				AND i.notify_back_in_stock
+			RETURNING l.wishlist_id, i.list_id
+		)
+		INSERT INTO back_in_stock_notifications (wishlist_id, list_id, product_id, variant_id, stock, queued_at)
+		SELECT wishlist_id, list_id, $1, $2, $3, $4 FROM taken
+	`
+
+	result, err := r.db.ExecContext(ctx, query, productID, variantID, stock, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	queued, err := result.RowsAffected()
+	return int(queued), err
+}
+
+// ProcessBackInStockAlerts locks a batch of queued notices with FOR UPDATE SKIP LOCKED while
+// deliver runs, so instances running the delivery job at once never send the same notice.
+// A crash before commit releases the locks and leaves every notice queued.
+func (r *WishlistRepositoryImpl) ProcessBackInStockAlerts(ctx context.Context, limit int, deliver func(notices []repository.BackInStockAlert) (done, failed []int64)) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id, wishlist_id, list_id, product_id, variant_id, stock, queued_at, attempts
+		FROM back_in_stock_notifications
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.QueryContext(ctx, query, limit)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	notices := make([]repository.BackInStockAlert, 0)
+	for rows.Next() {
+		var notice repository.BackInStockAlert
+		if err := rows.Scan(&notice.ID, &notice.WishlistID, &notice.ListID, &notice.ProductID, &notice.VariantID,
+			&notice.Stock, &notice.QueuedAt, &notice.Attempts); err != nil {
+			return err
+		}
+		notices = append(notices, notice)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	if len(notices) == 0 {
+		return nil
+	}
+
+	done, failed := deliver(notices)
+	if len(done) > 0 {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM back_in_stock_notifications WHERE id = ANY($1)`, pq.Array(done)); err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		if _, err := tx.ExecContext(ctx, `UPDATE back_in_stock_notifications SET attempts = attempts + 1 WHERE id = ANY($1)`, pq.Array(failed)); err != nil {
+			return err
+		}
+	}
+
+	return commitTx(ctx, tx, "wishlist.back_in_stock", fmt.Sprintf("%d-%d", notices[0].ID, notices[len(notices)-1].ID))
+}
+
+// findOne loads the wishlist selected by a query returning its id, created_at and updated_at
+func (r *WishlistRepositoryImpl) findOne(ctx context.Context, query string, arg string) (*entity.Wishlist, error) {
+	var id string
+	var createdAt, updatedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(&id, &createdAt, &updatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("wishlist not found")
+		}
+		return nil, err
+	}
+
+	lists, err := r.findLists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconstructWishlist(id, lists, createdAt.Time, updatedAt.Time), nil
+}
+
+// saveLists saves the lists and items of a wishlist within a transaction
+func (r *WishlistRepositoryImpl) saveLists(ctx context.Context, tx *sql.Tx, wishlist *entity.Wishlist) error {
+	listQuery := `
+		INSERT INTO wishlist_lists (id, wishlist_id, position, name, share_token, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	itemQuery := `
+		INSERT INTO wishlist_items (list_id, position, product_id, variant_id, notify_back_in_stock, added_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	for position, list := range wishlist.Lists() {
+		_, err := tx.ExecContext(ctx, listQuery,
+			list.ID(),
+			wishlist.ID(),
+			position,
+			list.Name(),
+			sql.NullString{String: list.ShareToken(), Valid: list.IsShared()},
+			list.CreatedAt(),
+		)
+		if err != nil {
+			return err
+		}
+
+		for itemPosition, item := range list.Items() {
+			_, err := tx.ExecContext(ctx, itemQuery,
+				list.ID(),
+				itemPosition,
+				item.ProductID(),
+				item.VariantID(),
+				item.NotifyBackInStock(),
+				item.AddedAt(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// findLists retrieves the lists of a wishlist with their items
+func (r *WishlistRepositoryImpl) findLists(ctx context.Context, wishlistID string) ([]*entity.WishlistList, error) {
+	query := `
+		SELECT l.id, l.name, l.share_token, l.created_at,
+			i.product_id, i.variant_id, i.notify_back_in_stock, i.added_at
+		FROM wishlist_lists l
+		LEFT JOIN wishlist_items i ON i.list_id = l.id
+		WHERE l.wishlist_id = $1
+		ORDER BY l.position, i.position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := make([]*entity.WishlistList, 0)
+	var current struct {
+		id, name, shareToken string
+		createdAt            sql.NullTime
+		items                []*entity.WishlistItem
+	}
+	flush := func() {
+		if current.id != "" {
+			lists = append(lists, entity.ReconstructWishlistList(
+				current.id, current.name, current.shareToken, current.items, current.createdAt.Time))
+		}
+	}
+
+	for rows.Next() {
+		var listID, name string
+		var shareToken, productID, variantID sql.NullString
+		var createdAt, addedAt sql.NullTime
+		var notify sql.NullBool
+
+		if err := rows.Scan(&listID, &name, &shareToken, &createdAt,
+			&productID, &variantID, &notify, &addedAt); err != nil {
+			return nil, err
+		}
+
+		if listID != current.id {
+			flush()
+			current.id, current.name, current.shareToken, current.createdAt = listID, name, shareToken.String, createdAt
+			current.items = make([]*entity.WishlistItem, 0)
+		}
+
+		// Empty lists join to a single row without an item
+		if !productID.Valid {
+			continue
+		}
+		current.items = append(current.items,
+			entity.ReconstructWishlistItem(productID.String, variantID.String, notify.Bool, addedAt.Time))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return lists, nil
+}
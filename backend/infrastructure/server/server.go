@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Config holds HTTP server settings
+type Config struct {
+	Addr              string
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	ShutdownTimeout   time.Duration
+
+	// DrainDelay keeps serving after shutdown begins so load balancers can observe
+	// failing readiness and stop routing traffic before the listener closes
+	DrainDelay time.Duration
+}
+
+// DefaultConfig returns conservative server settings listening on addr
+func DefaultConfig(addr string) Config {
+	return Config{
+		Addr:              addr,
+		ReadTimeout:       15 * time.Second,
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    1 << 20,
+		ShutdownTimeout:   30 * time.Second,
+	}
+}
+
+// Worker is a background task that runs until its context is cancelled
+type Worker func(ctx context.Context)
+
+// drainKey is the worker context key holding the context that ends at the shutdown deadline
+type drainKey struct{}
+
+// DrainContext returns a context that ends at the shutdown deadline, for a worker that lets
+// in-flight work finish after its own context is cancelled. Outside a worker it returns ctx.
+func DrainContext(ctx context.Context) context.Context {
+	if drain, ok := ctx.Value(drainKey{}).(context.Context); ok {
+		return drain
+	}
+	return ctx
+}
+
+// ShutdownHook releases a resource once the server and workers have stopped
+type ShutdownHook func(ctx context.Context) error
+
+// Server runs an HTTP server and background workers, and shuts them down together
+type Server struct {
+	cfg        Config
+	httpServer *http.Server
+
+	mu           sync.Mutex
+	workers      map[string]Worker
+	hooks        []namedHook
+	shuttingDown bool
+}
+
+type namedHook struct {
+	name string
+	hook ShutdownHook
+}
+
+// New creates a Server for the handler using the given configuration
+func New(cfg Config, handler http.Handler) *Server {
+	return &Server{
+		cfg: cfg,
+		httpServer: &http.Server{
+			Addr:              cfg.Addr,
+			Handler:           handler,
+			ReadTimeout:       cfg.ReadTimeout,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+			IdleTimeout:       cfg.IdleTimeout,
+			MaxHeaderBytes:    cfg.MaxHeaderBytes,
+			ErrorLog:          slog.NewLogLogger(slog.Default().Handler(), slog.LevelWarn),
+		},
+		workers: make(map[string]Worker),
+	}
+}
+
+// AddWorker registers a background worker started by Run and stopped on shutdown
+func (s *Server) AddWorker(name string, worker Worker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workers[name] = worker
+}
+
+// OnShutdown registers a hook run after the server and workers stop. Hooks run in reverse registration order.
+func (s *Server) OnShutdown(name string, hook ShutdownHook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, namedHook{name: name, hook: hook})
+}
+
+// RegisterOnShutdown registers a function called when the HTTP server begins to drain,
+// to end long-lived responses such as event streams that would otherwise hold it open
+func (s *Server) RegisterOnShutdown(f func()) {
+	s.httpServer.RegisterOnShutdown(f)
+}
+
+// ShuttingDown reports whether shutdown has begun
+func (s *Server) ShuttingDown() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.shuttingDown
+}
+
+// ReadinessCheck fails once shutdown has begun, so the instance is taken out of rotation while it drains
+func (s *Server) ReadinessCheck(ctx context.Context) error {
+	if s.ShuttingDown() {
+		return errors.New("server is shutting down")
+	}
+	return nil
+}
+
+// Run listens on the configured address and serves until ctx is cancelled, then shuts down
+func (s *Server) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.cfg.Addr, err)
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve starts the workers and serves HTTP on ln until ctx is cancelled or the server fails.
+// It then drains in-flight requests, stops the workers and runs the shutdown hooks,
+// all within the configured shutdown timeout.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	drainCtx, expireDrain := context.WithCancel(context.Background())
+	defer expireDrain()
+	workerCtx, stopWorkers := context.WithCancel(context.WithValue(context.Background(), drainKey{}, drainCtx))
+	defer stopWorkers()
+
+	var wg sync.WaitGroup
+	s.mu.Lock()
+	for name, worker := range s.workers {
+		wg.Add(1)
+		go func(name string, worker Worker) {
+			defer wg.Done()
+			slog.Info("worker started", "worker", name)
+			worker(workerCtx)
+			slog.Info("worker stopped", "worker", name)
+		}(name, worker)
+	}
+	s.mu.Unlock()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("Server starting", "addr", ln.Addr().String())
+		serveErr <- s.httpServer.Serve(ln)
+	}()
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("Shutdown signal received, draining connections", "timeout", s.cfg.ShutdownTimeout)
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			runErr = err
+		}
+	}
+
+	s.mu.Lock()
+	s.shuttingDown = true
+	s.mu.Unlock()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+	context.AfterFunc(shutdownCtx, expireDrain)
+
+	if runErr == nil && s.cfg.DrainDelay > 0 {
+		select {
+		case <-time.After(s.cfg.DrainDelay):
+		case <-shutdownCtx.Done():
+		}
+	}
+
+	if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("HTTP server did not drain in time", "error", err)
+		s.httpServer.Close()
+	}
+
+	stopWorkers()
+	workersDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(workersDone)
+	}()
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		slog.Warn("Background workers did not stop in time")
+	}
+
+	s.runHooks(shutdownCtx)
+
+	slog.Info("Server stopped")
+	return runErr
+}
+
+// runHooks runs the shutdown hooks in reverse registration order
+func (s *Server) runHooks(ctx context.Context) {
+	s.mu.Lock()
+	hooks := append([]namedHook(nil), s.hooks...)
+	s.mu.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].hook(ctx); err != nil {
+			slog.Warn("shutdown hook failed", "hook", hooks[i].name, "error", err)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestServer_GracefulShutdown(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("done"))
+	})
+
+	cfg := DefaultConfig("127.0.0.1:0")
+	cfg.ShutdownTimeout = 5 * time.Second
+	srv := New(cfg, handler)
+
+	var mu sync.Mutex
+	var events []string
+	record := func(e string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	}
+
+	srv.AddWorker("ticker", func(ctx context.Context) {
+		<-ctx.Done()
+		record("worker stopped")
+	})
+	srv.OnShutdown("first", func(ctx context.Context) error {
+		record("first hook")
+		return nil
+	})
+	srv.OnShutdown("second", func(ctx context.Context) error {
+		record("second hook")
+		return nil
+	})
+
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+
+	// Start a request that is still in flight when shutdown begins
+	body := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			body <- "error: " + err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		body <- string(b)
+	}()
+
+	<-started
+	cancel()
+
+	// Give Serve time to stop accepting before the in-flight request completes
+	time.Sleep(50 * time.Millisecond)
+	if srv.ReadinessCheck(context.Background()) == nil {
+		t.Error("expected readiness to fail while shutting down")
+	}
+	close(release)
+
+	if got := <-body; got != "done" {
+		t.Errorf("expected in-flight request to complete, got %q", got)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"worker stopped", "second hook", "first hook"}
+	if len(events) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("expected events %v, got %v", want, events)
+			break
+		}
+	}
+}
+
+func TestServer_AppliesTimeouts(t *testing.T) {
+	cfg := DefaultConfig(":0")
+	srv := New(cfg, http.NotFoundHandler())
+
+	if srv.httpServer.ReadHeaderTimeout != cfg.ReadHeaderTimeout || srv.httpServer.WriteTimeout != cfg.WriteTimeout ||
+		srv.httpServer.IdleTimeout != cfg.IdleTimeout || srv.httpServer.MaxHeaderBytes != cfg.MaxHeaderBytes {
+		t.Errorf("expected configured timeouts on http.Server, got %+v", srv.httpServer)
+	}
+}
+
+func TestServer_DrainDelayKeepsServing(t *testing.T) {
+	cfg := DefaultConfig("127.0.0.1:0")
+	cfg.DrainDelay = 300 * time.Millisecond
+
+	var srv *Server
+	srv = New(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := srv.ReadinessCheck(r.Context()); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+
+	url := "http://" + ln.Addr().String()
+	resp, err := http.Get(url)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected ready before shutdown, got %v %v", resp, err)
+	}
+	resp.Body.Close()
+
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	// Still accepting connections during the drain delay, but reporting not ready
+	resp, err = http.Get(url)
+	if err != nil {
+		t.Fatalf("expected server to keep serving during drain delay: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 during drain, got %d", resp.StatusCode)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestServer_DrainContextEndsAtShutdownDeadline(t *testing.T) {
+	cfg := DefaultConfig("127.0.0.1:0")
+	cfg.ShutdownTimeout = 200 * time.Millisecond
+	srv := New(cfg, http.NotFoundHandler())
+
+	drained := make(chan error, 1)
+	srv.AddWorker("draining", func(ctx context.Context) {
+		<-ctx.Done()
+		drain := DrainContext(ctx)
+		if err := drain.Err(); err != nil {
+			drained <- err
+			return
+		}
+		<-drain.Done()
+		drained <- nil
+	})
+
+	var hookRan time.Time
+	srv.OnShutdown("database", func(ctx context.Context) error {
+		hookRan = time.Now()
+		return nil
+	})
+
+	ln, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+
+	start := time.Now()
+	cancel()
+
+	select {
+	case err := <-drained:
+		if err != nil {
+			t.Fatalf("expected the drain context to outlive the worker context, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the drain context to end at the shutdown deadline")
+	}
+	<-done
+
+	if elapsed := hookRan.Sub(start); elapsed < cfg.ShutdownTimeout {
+		t.Errorf("expected hooks to run after the drain deadline, ran after %v", elapsed)
+	}
+	if DrainContext(ctx) != ctx {
+		t.Error("expected DrainContext outside a worker to return its argument")
+	}
+}
@@ -0,0 +1,130 @@
+// Package alert delivers operational alerts, such as low stock, to the people running
+// the shop through pluggable notifiers: the log, a webhook and email.
+//
+// A Dispatcher queues alerts and delivers them in the background, so a slow webhook
+// or mail server never holds up the request that raised the alert. Callers that must
+// know an alert arrived, such as a retrying job, deliver it synchronously instead.
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is returned when an alert is raised while the dispatcher's queue is full
+var ErrQueueFull = errors.New("alert: queue is full")
+
+// Alert is a notice that needs someone's attention
+type Alert struct {
+	// Type names the alert, such as "inventory.low_stock"
+	Type string `json:"type"`
+
+	// Subject is a one-line summary, used as the email subject
+	Subject string `json:"subject"`
+
+	// Text describes the alert in plain text, used as the email body
+	Text string `json:"text"`
+
+	Data interface{} `json:"data,omitempty"`
+	Time time.Time   `json:"time"`
+}
+
+// Notifier delivers an alert to one destination
+type Notifier interface {
+	Notify(ctx context.Context, a Alert) error
+}
+
+type namedNotifier struct {
+	name     string
+	notifier Notifier
+}
+
+// Dispatcher queues alerts and delivers each one to every registered notifier
+type Dispatcher struct {
+	mu        sync.Mutex
+	notifiers []namedNotifier
+	timeout   time.Duration
+	queue     chan Alert
+}
+
+// NewDispatcher creates a Dispatcher that holds up to queueSize undelivered alerts and
+// gives each notifier timeout to deliver one
+func NewDispatcher(queueSize int, timeout time.Duration) *Dispatcher {
+	return &Dispatcher{
+		timeout: timeout,
+		queue:   make(chan Alert, queueSize),
+	}
+}
+
+// Add registers a notifier under a name used in logs
+func (d *Dispatcher) Add(name string, notifier Notifier) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.notifiers = append(d.notifiers, namedNotifier{name: name, notifier: notifier})
+}
+
+// Notify timestamps the alert and queues it for delivery. It never blocks: when the
+// queue is full the alert is dropped and ErrQueueFull returned.
+func (d *Dispatcher) Notify(ctx context.Context, a Alert) error {
+	if a.Time.IsZero() {
+		a.Time = time.Now()
+	}
+	select {
+	case d.queue <- a:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Run delivers queued alerts until ctx is cancelled, then delivers the alerts still
+// queued before returning
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case a := <-d.queue:
+			d.deliver(ctx, a)
+		case <-ctx.Done():
+			for {
+				select {
+				case a := <-d.queue:
+					d.deliver(context.WithoutCancel(ctx), a)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// Deliver timestamps the alert and sends it to every notifier before returning, for callers
+// that must know it arrived. Every notifier is tried; the failures are returned joined.
+func (d *Dispatcher) Deliver(ctx context.Context, a Alert) error {
+	if a.Time.IsZero() {
+		a.Time = time.Now()
+	}
+	return d.deliver(ctx, a)
+}
+
+// deliver sends an alert to every notifier, logging the ones that fail
+func (d *Dispatcher) deliver(ctx context.Context, a Alert) error {
+	d.mu.Lock()
+	notifiers := d.notifiers
+	d.mu.Unlock()
+
+	var errs []error
+	for _, n := range notifiers {
+		notifyCtx, cancel := context.WithTimeout(ctx, d.timeout)
+		err := n.notifier.Notify(notifyCtx, a)
+		cancel()
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to deliver alert", "notifier", n.name, "type", a.Type, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", n.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,244 @@
+package alert
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingNotifier records the alerts it receives
+type recordingNotifier struct {
+	mu     sync.Mutex
+	alerts []Alert
+	err    error
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, a Alert) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.alerts = append(n.alerts, a)
+	return n.err
+}
+
+func (n *recordingNotifier) count() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.alerts)
+}
+
+func TestDispatcher_DeliversToEveryNotifier(t *testing.T) {
+	d := NewDispatcher(10, time.Second)
+	failing := &recordingNotifier{err: errors.New("unreachable")}
+	working := &recordingNotifier{}
+	d.Add("failing", failing)
+	d.Add("working", working)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+
+	if err := d.Notify(ctx, Alert{Type: "inventory.low_stock", Subject: "Low stock"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for working.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if failing.count() != 1 || working.count() != 1 {
+		t.Fatalf("expected each notifier to get the alert once, got %d and %d", failing.count(), working.count())
+	}
+	if working.alerts[0].Time.IsZero() {
+		t.Error("expected the alert to be timestamped")
+	}
+}
+
+func TestDispatcher_QueueFullAndDrain(t *testing.T) {
+	d := NewDispatcher(1, time.Second)
+	notifier := &recordingNotifier{}
+	d.Add("log", notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := d.Notify(ctx, Alert{Subject: "first"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if err := d.Notify(ctx, Alert{Subject: "second"}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+
+	// A dispatcher stopped before it ran still delivers what was queued
+	cancel()
+	d.Run(ctx)
+	if notifier.count() != 1 || notifier.alerts[0].Subject != "first" {
+		t.Errorf("expected the queued alert to be delivered on shutdown, got %+v", notifier.alerts)
+	}
+}
+
+func TestDispatcher_DeliverReportsFailures(t *testing.T) {
+	d := NewDispatcher(1, time.Second)
+	unreachable := errors.New("unreachable")
+	failing := &recordingNotifier{err: unreachable}
+	working := &recordingNotifier{}
+	d.Add("webhook", failing)
+	d.Add("log", working)
+
+	// Delivered before returning, without the queue or a running dispatcher
+	err := d.Deliver(context.Background(), Alert{Subject: "Back in stock"})
+	if !errors.Is(err, unreachable) || !strings.Contains(err.Error(), "webhook") {
+		t.Errorf("expected the webhook failure, got %v", err)
+	}
+	if failing.count() != 1 || working.count() != 1 {
+		t.Errorf("expected every notifier to be tried, got %d and %d", failing.count(), working.count())
+	}
+
+	failing.err = nil
+	if err := d.Deliver(context.Background(), Alert{Subject: "Back in stock"}); err != nil {
+		t.Errorf("expected delivery to succeed, got %v", err)
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received Alert
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s with %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, nil)
+	a := Alert{Type: "inventory.low_stock", Subject: "Low stock: Mug", Data: map[string]int{"stock": 2}}
+
+	if err := notifier.Notify(context.Background(), a); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if received.Type != a.Type || received.Subject != a.Subject {
+		t.Errorf("expected the alert as JSON, got %+v", received)
+	}
+
+	status = http.StatusBadGateway
+	if err := notifier.Notify(context.Background(), a); err == nil {
+		t.Error("expected error for a failed webhook")
+	}
+}
+
+// fakeSMTPServer accepts one message and records the envelope and data
+type fakeSMTPServer struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     string
+	done     chan struct{}
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeSMTPServer{listener: listener, done: make(chan struct{})}
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeSMTPServer) serve() {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); {
+		case cmd == "EHLO" || cmd == "HELO":
+			reply("250 localhost")
+		case strings.HasPrefix(strings.ToUpper(line), "MAIL FROM:"):
+			s.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(strings.ToUpper(line), "RCPT TO:"):
+			s.to = append(s.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil || l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.data = data.String()
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestEmailNotifier(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	notifier := NewEmailNotifier(EmailConfig{
+		Addr: server.listener.Addr().String(),
+		From: "inventory@shop.test",
+		To:   []string{"buyer@shop.test", "ops@shop.test"},
+	})
+
+	a := Alert{
+		Subject: "Low stock:\r\nBcc: someone@else.test",
+		Text:    "Mug is down to 2.\nReorder 20.",
+		Time:    time.Now(),
+	}
+	if err := notifier.Notify(context.Background(), a); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	<-server.done
+
+	if server.from != "inventory@shop.test" || len(server.to) != 2 {
+		t.Errorf("unexpected envelope from %q to %v", server.from, server.to)
+	}
+	if !strings.Contains(server.data, "Subject: Low stock: Bcc: someone@else.test\r\n") {
+		t.Errorf("expected the subject on one line, got:\n%s", server.data)
+	}
+	if !strings.Contains(server.data, "\r\n\r\nMug is down to 2.\r\nReorder 20.\r\n") {
+		t.Errorf("expected the text as the body, got:\n%s", server.data)
+	}
+}
+
+func TestEmailNotifier_NoRecipients(t *testing.T) {
+	notifier := NewEmailNotifier(EmailConfig{Addr: "localhost:1025", From: "inventory@shop.test"})
+	if err := notifier.Notify(context.Background(), Alert{Subject: "Low stock"}); err == nil {
+		t.Error("expected error without recipients")
+	}
+}
@@ -0,0 +1,175 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// LogNotifier writes alerts to a structured logger at warning level
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+// NewLogNotifier creates a LogNotifier
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// Notify logs the alert
+func (n *LogNotifier) Notify(ctx context.Context, a Alert) error {
+	n.logger.WarnContext(ctx, a.Subject, "alert", a.Type, "data", a.Data)
+	return nil
+}
+
+// WebhookNotifier posts each alert as JSON to a URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a WebhookNotifier; a nil client uses http.DefaultClient
+func NewWebhookNotifier(url string, client *http.Client) *WebhookNotifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &WebhookNotifier{url: url, client: client}
+}
+
+// Notify posts the alert and fails unless the webhook answers with a 2xx status
+func (n *WebhookNotifier) Notify(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// EmailConfig holds the SMTP server and addresses used by EmailNotifier
+type EmailConfig struct {
+	// Addr is the SMTP server's host:port
+	Addr string
+
+	// Username and Password authenticate with PLAIN auth when Username is set
+	Username string
+	Password string
+
+	From string
+	To   []string
+}
+
+// EmailNotifier sends each alert as a plain-text email over SMTP, upgrading to TLS
+// when the server offers STARTTLS
+type EmailNotifier struct {
+	cfg EmailConfig
+}
+
+// NewEmailNotifier creates an EmailNotifier
+func NewEmailNotifier(cfg EmailConfig) *EmailNotifier {
+	return &EmailNotifier{cfg: cfg}
+}
+
+// Notify sends the alert to every recipient
+func (n *EmailNotifier) Notify(ctx context.Context, a Alert) error {
+	if len(n.cfg.To) == 0 {
+		return errors.New("email has no recipients")
+	}
+
+	host, _, err := net.SplitHostPort(n.cfg.Addr)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if n.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(n.cfg.From); err != nil {
+		return err
+	}
+	for _, to := range n.cfg.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(n.message(a)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// message builds the email headers and body for an alert
+func (n *EmailNotifier) message(a Alert) []byte {
+	// Line breaks in the subject would start new headers
+	subject := strings.Join(strings.Fields(a.Subject), " ")
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.cfg.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", a.Time.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(a.Text, "\n", "\r\n"))
+	msg.WriteString("\r\n")
+	return msg.Bytes()
+}
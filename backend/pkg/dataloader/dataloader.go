@@ -0,0 +1,112 @@
+// Package dataloader batches and caches lookups by key.
+//
+// Load registers a key and returns a thunk. The first thunk to be called fetches every
+// key registered so far in one batch, so callers that collect thunks before resolving
+// them, such as a GraphQL executor resolving one level of a query, issue a single
+// lookup per level instead of one per item. Results are cached for the loader's lifetime,
+// which is normally a single request.
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrNotFound is returned by a thunk whose key was missing from the batch result
+var ErrNotFound = errors.New("dataloader: not found")
+
+// BatchFunc fetches the values for keys. Keys without a value may be left out of the map.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Thunk returns the value loaded for a key
+type Thunk[V any] func() (V, error)
+
+// Loader batches and caches lookups for one key type
+type Loader[K comparable, V any] struct {
+	ctx   context.Context
+	batch BatchFunc[K, V]
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	values  map[K]V
+	errs    map[K]error
+	batches int
+}
+
+// New creates a Loader that fetches values with batch using ctx
+func New[K comparable, V any](ctx context.Context, batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		ctx:    ctx,
+		batch:  batch,
+		queued: make(map[K]bool),
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+// Load registers key for the next batch and returns a thunk resolving its value
+func (l *Loader[K, V]) Load(key K) Thunk[V] {
+	l.mu.Lock()
+	_, loaded := l.values[key]
+	_, failed := l.errs[key]
+	if !loaded && !failed && !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		return l.resolve(key)
+	}
+}
+
+// Batches returns how many batches have been fetched
+func (l *Loader[K, V]) Batches() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.batches
+}
+
+// resolve dispatches pending keys if needed and returns the value of key
+func (l *Loader[K, V]) resolve(key K) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.queued[key] {
+		l.dispatch()
+	}
+
+	if err, ok := l.errs[key]; ok {
+		var zero V
+		return zero, err
+	}
+	if v, ok := l.values[key]; ok {
+		return v, nil
+	}
+	var zero V
+	return zero, ErrNotFound
+}
+
+// dispatch fetches every pending key in one batch. It is called with mu held.
+func (l *Loader[K, V]) dispatch() {
+	keys := l.pending
+	l.pending = nil
+	for _, k := range keys {
+		delete(l.queued, k)
+	}
+	l.batches++
+
+	found, err := l.batch(l.ctx, keys)
+	for _, k := range keys {
+		switch v, ok := found[k]; {
+		case err != nil:
+			l.errs[k] = err
+		case ok:
+			l.values[k] = v
+		default:
+			l.errs[k] = ErrNotFound
+		}
+	}
+}
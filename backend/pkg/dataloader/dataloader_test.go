@@ -0,0 +1,78 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestLoader_BatchesPendingKeys(t *testing.T) {
+	var calls [][]string
+	l := New(context.Background(), func(ctx context.Context, keys []string) (map[string]int, error) {
+		calls = append(calls, append([]string(nil), keys...))
+		out := make(map[string]int)
+		for _, k := range keys {
+			if k != "missing" {
+				out[k] = len(k)
+			}
+		}
+		return out, nil
+	})
+
+	a := l.Load("a")
+	bb := l.Load("bb")
+	again := l.Load("a")
+	missing := l.Load("missing")
+
+	if v, err := bb(); err != nil || v != 2 {
+		t.Errorf("bb = %d, %v", v, err)
+	}
+	if v, err := a(); err != nil || v != 1 {
+		t.Errorf("a = %d, %v", v, err)
+	}
+	if v, err := again(); err != nil || v != 1 {
+		t.Errorf("a again = %d, %v", v, err)
+	}
+	if _, err := missing(); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing err = %v, want ErrNotFound", err)
+	}
+
+	if len(calls) != 1 {
+		t.Fatalf("expected one batch, got %v", calls)
+	}
+	sort.Strings(calls[0])
+	if !reflect.DeepEqual(calls[0], []string{"a", "bb", "missing"}) {
+		t.Errorf("batch keys = %v", calls[0])
+	}
+
+	// Cached keys are not fetched again; new keys start a new batch
+	if v, _ := l.Load("bb")(); v != 2 {
+		t.Errorf("cached bb = %d", v)
+	}
+	if v, _ := l.Load("ccc")(); v != 3 {
+		t.Errorf("ccc = %d", v)
+	}
+	if l.Batches() != 2 || len(calls[1]) != 1 || calls[1][0] != "ccc" {
+		t.Errorf("expected a second batch of [ccc], got %v", calls)
+	}
+}
+
+func TestLoader_BatchError(t *testing.T) {
+	boom := errors.New("boom")
+	l := New(context.Background(), func(ctx context.Context, keys []int) (map[int]string, error) {
+		return nil, boom
+	})
+
+	one, two := l.Load(1), l.Load(2)
+	if _, err := one(); !errors.Is(err, boom) {
+		t.Errorf("err = %v, want boom", err)
+	}
+	if _, err := two(); !errors.Is(err, boom) {
+		t.Errorf("err = %v, want boom", err)
+	}
+	if l.Batches() != 1 {
+		t.Errorf("batches = %d, want 1", l.Batches())
+	}
+}
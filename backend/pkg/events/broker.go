@@ -0,0 +1,208 @@
+// Package events provides an in-process publish/subscribe broker with a bounded
+// replay buffer, so subscribers that reconnect can resume where they left off.
+package events
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned when subscribing to a closed broker
+var ErrClosed = errors.New("events: broker is closed")
+
+// subscriberBuffer is the number of events queued for a subscriber before it is dropped
+const subscriberBuffer = 64
+
+// Event is a published change to a resource
+type Event struct {
+	// ID increases by one with every event published to the broker
+	ID uint64
+
+	// Topic groups related events, such as "orders"
+	Topic string
+
+	// Type names the change, such as "order.status_changed"
+	Type string
+
+	// ResourceID identifies the changed resource
+	ResourceID string
+
+	Data interface{}
+	Time time.Time
+}
+
+// Filter selects events by topic and resource. An empty list matches everything.
+type Filter struct {
+	Topics      []string
+	ResourceIDs []string
+}
+
+// Match reports whether the event passes the filter
+func (f Filter) Match(e Event) bool {
+	if len(f.Topics) > 0 && !slices.Contains(f.Topics, e.Topic) {
+		return false
+	}
+	if len(f.ResourceIDs) > 0 && !slices.Contains(f.ResourceIDs, e.ResourceID) {
+		return false
+	}
+	return true
+}
+
+// Broker fans published events out to subscribers and keeps the most recent ones for replay
+type Broker struct {
+	mu     sync.Mutex
+	lastID uint64
+	replay []Event // ring buffer of the most recent events
+	next   int     // position of the next write in replay
+	full   bool
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// NewBroker creates a Broker that keeps the last replaySize events for resuming subscribers
+func NewBroker(replaySize int) *Broker {
+	return &Broker{
+		replay: make([]Event, replaySize),
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish assigns the event an ID and timestamp and delivers it to matching subscribers.
+// A subscriber whose queue is full is dropped rather than blocking the publisher.
+func (b *Broker) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return e
+	}
+
+	b.lastID++
+	e.ID = b.lastID
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	if len(b.replay) > 0 {
+		b.replay[b.next] = e
+		b.next = (b.next + 1) % len(b.replay)
+		if b.next == 0 {
+			b.full = true
+		}
+	}
+
+	for sub := range b.subs {
+		if !sub.filter.Match(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			sub.dropped = true
+			b.remove(sub)
+		}
+	}
+
+	return e
+}
+
+// Subscribe registers a subscriber for events matching the filter. When resuming is set,
+// buffered events published after lastID are returned for replay; missed reports that
+// lastID is no longer in the buffer, so some events can no longer be replayed.
+func (b *Broker) Subscribe(filter Filter, lastID uint64, resuming bool) (sub *Subscription, replay []Event, missed bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, nil, false, ErrClosed
+	}
+
+	if resuming {
+		replay, missed = b.since(lastID, filter)
+	}
+
+	sub = &Subscription{broker: b, filter: filter, ch: make(chan Event, subscriberBuffer)}
+	b.subs[sub] = struct{}{}
+	return sub, replay, missed, nil
+}
+
+// since returns the buffered events after lastID that match the filter
+func (b *Broker) since(lastID uint64, filter Filter) ([]Event, bool) {
+	buffered := b.buffered()
+
+	// An ID ahead of the broker comes from before a restart
+	if lastID > b.lastID {
+		return nil, true
+	}
+
+	oldest := b.lastID + 1
+	if len(buffered) > 0 {
+		oldest = buffered[0].ID
+	}
+	missed := lastID+1 < oldest
+
+	var replay []Event
+	for _, e := range buffered {
+		if e.ID > lastID && filter.Match(e) {
+			replay = append(replay, e)
+		}
+	}
+	return replay, missed
+}
+
+// buffered returns the replay buffer in publication order
+func (b *Broker) buffered() []Event {
+	if !b.full {
+		return b.replay[:b.next]
+	}
+	return append(slices.Clone(b.replay[b.next:]), b.replay[:b.next]...)
+}
+
+// Close ends every subscription and stops accepting new ones
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subs {
+		b.remove(sub)
+	}
+}
+
+// remove unregisters a subscription and closes its channel; callers hold b.mu
+func (b *Broker) remove(sub *Subscription) {
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Subscription receives the events published after it was created
+type Subscription struct {
+	broker  *Broker
+	filter  Filter
+	ch      chan Event
+	dropped bool
+}
+
+// Events returns the channel of matching events. It is closed when the subscription
+// ends: on Close, when the broker closes, or when the subscriber falls too far behind.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Dropped reports whether the subscription ended because the subscriber fell behind
+func (s *Subscription) Dropped() bool {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.dropped
+}
+
+// Close ends the subscription
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
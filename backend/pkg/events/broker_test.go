@@ -0,0 +1,130 @@
+package events
+
+import (
+	"testing"
+)
+
+func publish(b *Broker, topic, resource string) Event {
+	return b.Publish(Event{Topic: topic, Type: topic + ".changed", ResourceID: resource})
+}
+
+func ids(events []Event) []uint64 {
+	out := make([]uint64, len(events))
+	for i, e := range events {
+		out[i] = e.ID
+	}
+	return out
+}
+
+func TestBroker_DeliversMatchingEvents(t *testing.T) {
+	b := NewBroker(10)
+	sub, _, _, err := b.Subscribe(Filter{Topics: []string{"orders"}, ResourceIDs: []string{"o1"}}, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publish(b, "stock", "o1")
+	publish(b, "orders", "o2")
+	want := publish(b, "orders", "o1")
+
+	select {
+	case got := <-sub.Events():
+		if got.ID != want.ID || got.Time.IsZero() {
+			t.Errorf("expected event %d, got %+v", want.ID, got)
+		}
+	default:
+		t.Fatal("expected a matching event")
+	}
+	select {
+	case e := <-sub.Events():
+		t.Errorf("unexpected event %+v", e)
+	default:
+	}
+}
+
+func TestBroker_Replay(t *testing.T) {
+	b := NewBroker(3)
+	for i := 0; i < 5; i++ {
+		publish(b, "orders", "o1")
+	}
+
+	tests := []struct {
+		name       string
+		lastID     uint64
+		wantIDs    []uint64
+		wantMissed bool
+	}{
+		{"within buffer", 3, []uint64{4, 5}, false},
+		{"oldest buffered", 2, []uint64{3, 4, 5}, false},
+		{"up to date", 5, nil, false},
+		{"evicted", 1, []uint64{3, 4, 5}, true},
+		{"from before restart", 9, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, replay, missed, err := b.Subscribe(Filter{}, tt.lastID, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sub.Close()
+
+			if got := ids(replay); len(got) != len(tt.wantIDs) || (len(got) > 0 && got[0] != tt.wantIDs[0]) {
+				t.Errorf("expected replay %v, got %v", tt.wantIDs, got)
+			}
+			if missed != tt.wantMissed {
+				t.Errorf("expected missed=%v, got %v", tt.wantMissed, missed)
+			}
+		})
+	}
+}
+
+func TestBroker_ReplayIsFiltered(t *testing.T) {
+	b := NewBroker(10)
+	publish(b, "orders", "o1")
+	publish(b, "stock", "p1")
+	publish(b, "orders", "o2")
+
+	_, replay, _, _ := b.Subscribe(Filter{Topics: []string{"orders"}}, 0, true)
+	if got := ids(replay); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("expected replay [1 3], got %v", got)
+	}
+}
+
+func TestBroker_DropsSlowSubscribers(t *testing.T) {
+	b := NewBroker(0)
+	sub, _, _, _ := b.Subscribe(Filter{}, 0, false)
+
+	for i := 0; i < subscriberBuffer+1; i++ {
+		publish(b, "orders", "o1")
+	}
+
+	n := 0
+	for range sub.Events() {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Errorf("expected %d queued events before the drop, got %d", subscriberBuffer, n)
+	}
+	if !sub.Dropped() {
+		t.Error("expected the subscription to be dropped")
+	}
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := NewBroker(10)
+	sub, _, _, _ := b.Subscribe(Filter{}, 0, false)
+
+	b.Close()
+
+	if _, ok := <-sub.Events(); ok {
+		t.Error("expected the subscription channel to be closed")
+	}
+	if sub.Dropped() {
+		t.Error("closing the broker is not a drop")
+	}
+	if _, _, _, err := b.Subscribe(Filter{}, 0, false); err != ErrClosed {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+	sub.Close() // closing twice is safe
+}
@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit is a token bucket: Burst tokens at most, refilled at RequestsPerMinute
+type Limit struct {
+	RequestsPerMinute int
+	Burst             int
+}
+
+// rate returns the refill rate in tokens per second
+func (l Limit) rate() float64 {
+	return float64(l.RequestsPerMinute) / 60
+}
+
+// Result is the outcome of taking a token
+type Result struct {
+	// Allowed reports whether the request may proceed
+	Allowed bool
+
+	// Limit is the bucket capacity
+	Limit int
+
+	// Remaining is the number of whole tokens left after this request
+	Remaining int
+
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+
+	// RetryAfter is how long until a token is available; zero when allowed
+	RetryAfter time.Duration
+}
+
+// Store takes tokens from buckets identified by key. Implementations backed by a
+// shared store let several instances enforce one limit.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// bucket is the state of one token bucket
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// MemoryStore keeps token buckets in process memory
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// sweepInterval is how often idle, full buckets are evicted
+const sweepInterval = time.Minute
+
+// NewMemoryStore creates an in-memory Store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Take removes one token from the key's bucket if one is available
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	capacity := float64(limit.Burst)
+	rate := limit.rate()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+
+	// Refill for the time elapsed since the last request
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+	b.limit = limit
+
+	result := Result{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else if rate > 0 {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / rate)
+	} else {
+		result.RetryAfter = time.Duration(math.MaxInt64)
+	}
+
+	result.Remaining = int(b.tokens)
+	if rate > 0 {
+		result.Reset = secondsToDuration((capacity - b.tokens) / rate)
+	}
+	return result, nil
+}
+
+// sweep evicts buckets that have refilled completely, since they are equivalent to new ones
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*b.limit.rate() >= float64(b.limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newTestStore returns a store whose clock is advanced by the returned function
+func newTestStore() (*MemoryStore, func(time.Duration)) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	return s, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestMemoryStore_TokenBucket(t *testing.T) {
+	s, advance := newTestStore()
+	ctx := context.Background()
+	limit := Limit{RequestsPerMinute: 60, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		r, _ := s.Take(ctx, "client", limit)
+		if !r.Allowed || r.Remaining != 2-i || r.Limit != 3 {
+			t.Fatalf("request %d: unexpected result %+v", i, r)
+		}
+	}
+
+	r, _ := s.Take(ctx, "client", limit)
+	if r.Allowed || r.RetryAfter != time.Second || r.Remaining != 0 {
+		t.Fatalf("expected rejection with 1s retry, got %+v", r)
+	}
+	if r.Reset != 3*time.Second {
+		t.Errorf("expected bucket to refill in 3s, got %s", r.Reset)
+	}
+
+	// Other keys have their own bucket
+	if r, _ := s.Take(ctx, "other", limit); !r.Allowed {
+		t.Error("expected a separate bucket per key")
+	}
+
+	advance(time.Second)
+	if r, _ := s.Take(ctx, "client", limit); !r.Allowed {
+		t.Errorf("expected a token after refill, got %+v", r)
+	}
+
+	advance(time.Hour)
+	if r, _ := s.Take(ctx, "client", limit); !r.Allowed || r.Remaining != 2 {
+		t.Errorf("expected refill capped at burst, got %+v", r)
+	}
+}
+
+func TestMemoryStore_SweepsFullBuckets(t *testing.T) {
+	s, advance := newTestStore()
+	ctx := context.Background()
+
+	s.Take(ctx, "fast", Limit{RequestsPerMinute: 600, Burst: 1})
+	for i := 0; i < 5; i++ {
+		s.Take(ctx, "slow", Limit{RequestsPerMinute: 1, Burst: 5})
+	}
+
+	advance(2 * sweepInterval)
+	s.Take(ctx, "trigger", Limit{RequestsPerMinute: 60, Burst: 1})
+
+	if _, ok := s.buckets["fast"]; ok {
+		t.Error("expected refilled bucket to be evicted")
+	}
+	if _, ok := s.buckets["slow"]; !ok {
+		t.Error("expected partially drained bucket to be kept")
+	}
+}
@@ -0,0 +1,207 @@
+// Package scheduler runs named background jobs at fixed intervals and records the
+// outcome of every run, so operators can see when each job last ran, how much work
+// it did and why it last failed.
+//
+// Jobs run once when the scheduler starts and then every interval. A run that is still
+// going when the next one is due delays it rather than overlapping with it.
+package scheduler
+
+import (
+	"context"
+	"ecom-backend/pkg/tracing"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Job performs one run and reports how many items it processed
+type Job func(ctx context.Context) (int, error)
+
+// Result describes one finished run of a job
+type Result struct {
+	Job       string
+	Started   time.Time
+	Duration  time.Duration
+	Processed int
+	Err       error
+}
+
+// Stats summarises the runs of one job
+type Stats struct {
+	Name           string
+	Interval       time.Duration
+	Running        bool
+	Runs           int
+	Failures       int
+	TotalProcessed int
+
+	// LastRun is zero until the job has finished a run
+	LastRun       time.Time
+	LastDuration  time.Duration
+	LastProcessed int
+	LastError     string // empty when the last run succeeded
+	NextRun       time.Time
+}
+
+type entry struct {
+	name     string
+	interval time.Duration
+	job      Job
+	stats    Stats
+}
+
+// Scheduler runs registered jobs until its context is cancelled
+type Scheduler struct {
+	mu        sync.Mutex
+	jobs      map[string]*entry
+	observers []func(Result)
+	started   bool
+}
+
+// New creates an empty Scheduler
+func New() *Scheduler {
+	return &Scheduler{jobs: make(map[string]*entry)}
+}
+
+// Add registers a job run every interval. It panics if the name is taken, the interval
+// is not positive or the scheduler is already running.
+func (s *Scheduler) Add(name string, interval time.Duration, job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		panic("scheduler: Add called after Run")
+	}
+	if interval <= 0 {
+		panic(fmt.Sprintf("scheduler: job %q needs a positive interval", name))
+	}
+	if _, ok := s.jobs[name]; ok {
+		panic(fmt.Sprintf("scheduler: job %q registered twice", name))
+	}
+	s.jobs[name] = &entry{
+		name:     name,
+		interval: interval,
+		job:      job,
+		stats:    Stats{Name: name, Interval: interval},
+	}
+}
+
+// Observe calls f after every run of every job, such as to record metrics or beat a heartbeat
+func (s *Scheduler) Observe(f func(Result)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observers = append(s.observers, f)
+}
+
+// Run starts every job and blocks until ctx is cancelled and running jobs have returned.
+// Each run's context is cancelled with ctx, so jobs should stop promptly on shutdown.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.started = true
+	entries := make([]*entry, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		entries = append(entries, e)
+	}
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, e)
+		}()
+	}
+	wg.Wait()
+}
+
+// Stats returns a snapshot of every job's statistics, ordered by name
+func (s *Scheduler) Stats() []Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make([]Stats, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		stats = append(stats, e.stats)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// loop runs the job immediately and then every interval until ctx is cancelled
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, e)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs the job, recovering a panic as a failed run, and records the result
+func (s *Scheduler) runOnce(ctx context.Context, e *entry) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.mu.Lock()
+	e.stats.Running = true
+	s.mu.Unlock()
+
+	result := Result{Job: e.name, Started: time.Now()}
+	result.Processed, result.Err = s.call(ctx, e)
+	result.Duration = time.Since(result.Started)
+
+	s.mu.Lock()
+	st := &e.stats
+	st.Running = false
+	st.Runs++
+	st.TotalProcessed += result.Processed
+	st.LastRun = result.Started
+	st.LastDuration = result.Duration
+	st.LastProcessed = result.Processed
+	st.LastError = ""
+	if result.Err != nil {
+		st.Failures++
+		st.LastError = result.Err.Error()
+	}
+	st.NextRun = result.Started.Add(e.interval)
+	observers := s.observers
+	s.mu.Unlock()
+
+	if result.Err != nil {
+		slog.ErrorContext(ctx, "Job failed", "job", e.name, "processed", result.Processed,
+			"duration", result.Duration, "error", result.Err)
+	} else {
+		slog.InfoContext(ctx, "Job completed", "job", e.name, "processed", result.Processed, "duration", result.Duration)
+	}
+
+	for _, observe := range observers {
+		observe(result)
+	}
+}
+
+// call runs the job in its own span, turning a panic into an error
+func (s *Scheduler) call(ctx context.Context, e *entry) (processed int, err error) {
+	ctx, span := tracing.Start(ctx, "job."+e.name)
+	defer span.End()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+	}()
+
+	return e.job(ctx)
+}
@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduler_RunsJobsAndRecordsStats(t *testing.T) {
+	s := New()
+
+	var mu sync.Mutex
+	calls := 0
+	s.Add("cleanup", 10*time.Millisecond, func(ctx context.Context) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 2 {
+			return 1, errors.New("database unavailable")
+		}
+		return 5, nil
+	})
+
+	results := make(chan Result, 10)
+	s.Observe(func(r Result) {
+		select {
+		case results <- r:
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-results:
+		case <-time.After(time.Second):
+			t.Fatalf("expected run %d within a second", i+1)
+		}
+	}
+	cancel()
+	<-done
+
+	stats := s.Stats()
+	if len(stats) != 1 || stats[0].Name != "cleanup" {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	st := stats[0]
+	if st.Runs < 3 || st.Failures != 1 {
+		t.Errorf("expected at least 3 runs with 1 failure, got %d runs and %d failures", st.Runs, st.Failures)
+	}
+	if st.TotalProcessed < 11 || st.LastProcessed != 5 {
+		t.Errorf("expected processed totals to accumulate, got total %d and last %d", st.TotalProcessed, st.LastProcessed)
+	}
+	if st.LastError != "" {
+		t.Errorf("expected a successful last run to clear the error, got %q", st.LastError)
+	}
+	if st.LastRun.IsZero() || !st.NextRun.After(st.LastRun) || st.Running {
+		t.Errorf("unexpected run times %+v", st)
+	}
+}
+
+func TestScheduler_RecoversPanics(t *testing.T) {
+	s := New()
+	s.Add("broken", time.Hour, func(ctx context.Context) (int, error) {
+		panic("boom")
+	})
+
+	results := make(chan Result, 1)
+	s.Observe(func(r Result) { results <- r })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	select {
+	case r := <-results:
+		if r.Err == nil || r.Job != "broken" {
+			t.Errorf("expected the panic as a failed run, got %+v", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the job to run at start")
+	}
+
+	if st := s.Stats()[0]; st.Failures != 1 || st.LastError == "" {
+		t.Errorf("expected the failure in stats, got %+v", st)
+	}
+}
+
+func TestScheduler_AddValidates(t *testing.T) {
+	job := func(ctx context.Context) (int, error) { return 0, nil }
+
+	tests := []struct {
+		name string
+		add  func(s *Scheduler)
+	}{
+		{"zero interval", func(s *Scheduler) { s.Add("a", 0, job) }},
+		{"duplicate", func(s *Scheduler) { s.Add("a", time.Second, job); s.Add("a", time.Second, job) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic")
+				}
+			}()
+			tt.add(New())
+		})
+	}
+}
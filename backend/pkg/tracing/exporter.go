@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// JSONExporter writes each span as a line of JSON, for stdout or a local file
+type JSONExporter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+// NewJSONExporter creates an exporter writing JSON lines to w
+func NewJSONExporter(w io.Writer) *JSONExporter {
+	return &JSONExporter{encoder: json.NewEncoder(w)}
+}
+
+// NewFileExporter creates an exporter appending JSON lines to the file at path
+func NewFileExporter(path string) (*JSONExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter := NewJSONExporter(file)
+	exporter.closer = file
+	return exporter, nil
+}
+
+// ExportSpan writes the span as one JSON line
+func (e *JSONExporter) ExportSpan(span *SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.encoder.Encode(span)
+}
+
+// Shutdown closes the underlying file, if the exporter owns one
+func (e *JSONExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closer == nil {
+		return nil
+	}
+	err := e.closer.Close()
+	e.closer = nil
+	return err
+}
@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// TraceparentHeader is the W3C Trace Context header
+const TraceparentHeader = "traceparent"
+
+// flagSampled is the sampled bit of the traceparent trace-flags
+const flagSampled = 0x01
+
+// ParseTraceparent parses a W3C traceparent header such as
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func ParseTraceparent(header string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, errors.New("invalid traceparent: expected version-traceid-spanid-flags")
+	}
+
+	version, traceHex, spanHex, flagsHex := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || version == "ff" || (version == "00" && len(parts) != 4) {
+		return SpanContext{}, errors.New("invalid traceparent version")
+	}
+	if len(traceHex) != 32 || len(spanHex) != 16 || len(flagsHex) != 2 {
+		return SpanContext{}, errors.New("invalid traceparent field length")
+	}
+	if strings.ToLower(header) != header {
+		return SpanContext{}, errors.New("invalid traceparent: must be lowercase hex")
+	}
+
+	var sc SpanContext
+	if _, err := hex.Decode(sc.TraceID[:], []byte(traceHex)); err != nil {
+		return SpanContext{}, errors.New("invalid traceparent trace ID")
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(spanHex)); err != nil {
+		return SpanContext{}, errors.New("invalid traceparent span ID")
+	}
+	var flags [1]byte
+	if _, err := hex.Decode(flags[:], []byte(flagsHex)); err != nil {
+		return SpanContext{}, errors.New("invalid traceparent flags")
+	}
+	if !sc.IsValid() {
+		return SpanContext{}, errors.New("invalid traceparent: all-zero ID")
+	}
+
+	sc.Sampled = flags[0]&flagSampled != 0
+	return sc, nil
+}
+
+// FormatTraceparent formats a span context as a W3C traceparent header
+func FormatTraceparent(sc SpanContext) string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// Extract returns a context continuing the trace in the request headers, if they carry a valid traceparent
+func Extract(ctx context.Context, header http.Header) context.Context {
+	sc, err := ParseTraceparent(header.Get(TraceparentHeader))
+	if err != nil {
+		return ctx
+	}
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Inject writes the current span context into outgoing request headers
+func Inject(ctx context.Context, header http.Header) {
+	sc := SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		header.Set(TraceparentHeader, FormatTraceparent(sc))
+	}
+}
@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// Exporter receives finished, sampled spans
+type Exporter interface {
+	// ExportSpan records a finished span
+	ExportSpan(span *SpanData) error
+
+	// Shutdown flushes and releases the exporter
+	Shutdown(ctx context.Context) error
+}
+
+// Tracer creates spans and sends finished ones to an exporter
+type Tracer struct {
+	exporter Exporter
+}
+
+// NewTracer creates a Tracer. A nil exporter creates a tracer that propagates context but records nothing.
+func NewTracer(exporter Exporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+// StartOption configures a new span
+type StartOption func(*Span)
+
+// WithKind sets the span kind
+func WithKind(kind SpanKind) StartOption {
+	return func(s *Span) {
+		s.kind = kind
+	}
+}
+
+// WithAttributes sets initial attributes from alternating key/value arguments
+func WithAttributes(keyValues ...interface{}) StartOption {
+	return func(s *Span) {
+		for i := 0; i+1 < len(keyValues); i += 2 {
+			if key, ok := keyValues[i].(string); ok {
+				s.SetAttribute(key, keyValues[i+1])
+			}
+		}
+	}
+}
+
+// Start begins a span as a child of the span or remote parent in ctx
+func (t *Tracer) Start(ctx context.Context, name string, opts ...StartOption) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+
+	span := &Span{
+		tracer: t,
+		name:   name,
+		kind:   SpanKindInternal,
+		start:  time.Now(),
+		status: StatusUnset,
+	}
+
+	if parent.IsValid() {
+		span.sc = SpanContext{TraceID: parent.TraceID, SpanID: newSpanID(), Sampled: parent.Sampled}
+		span.parent = parent.SpanID
+	} else {
+		span.sc = SpanContext{TraceID: newTraceID(), SpanID: newSpanID(), Sampled: t.exporter != nil}
+	}
+
+	for _, opt := range opts {
+		opt(span)
+	}
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// Shutdown flushes and releases the exporter
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t.exporter == nil {
+		return nil
+	}
+	return t.exporter.Shutdown(ctx)
+}
+
+// export sends a finished span to the exporter
+func (t *Tracer) export(data *SpanData) {
+	if t.exporter == nil {
+		return
+	}
+	if err := t.exporter.ExportSpan(data); err != nil {
+		slog.Warn("failed to export span", "span", data.Name, "error", err)
+	}
+}
+
+var defaultTracer atomic.Pointer[Tracer]
+
+func init() {
+	defaultTracer.Store(NewTracer(nil))
+}
+
+// SetDefault makes t the tracer used by the package-level Start
+func SetDefault(t *Tracer) {
+	defaultTracer.Store(t)
+}
+
+// Default returns the default tracer
+func Default() *Tracer {
+	return defaultTracer.Load()
+}
+
+// Start begins a span using the default tracer
+func Start(ctx context.Context, name string, opts ...StartOption) (context.Context, *Span) {
+	return Default().Start(ctx, name, opts...)
+}
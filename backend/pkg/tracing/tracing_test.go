@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// recordingExporter keeps exported spans in memory
+type recordingExporter struct {
+	spans []*SpanData
+}
+
+func (e *recordingExporter) ExportSpan(span *SpanData) error {
+	e.spans = append(e.spans, span)
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func TestParseTraceparent(t *testing.T) {
+	const header = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	sc, err := ParseTraceparent(header)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" || !sc.Sampled {
+		t.Errorf("unexpected span context: %+v", sc)
+	}
+	if got := FormatTraceparent(sc); got != header {
+		t.Errorf("expected round trip to %q, got %q", header, got)
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}
+	for _, h := range invalid {
+		if _, err := ParseTraceparent(h); err == nil {
+			t.Errorf("expected %q to be rejected", h)
+		}
+	}
+}
+
+func TestTracer_ParentsSpans(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter)
+
+	ctx, root := tracer.Start(context.Background(), "root", WithKind(SpanKindServer))
+	_, child := tracer.Start(ctx, "child", WithAttributes("key", "value"))
+	child.RecordError(errors.New("boom"))
+	child.End()
+	root.End()
+	root.End()
+
+	if len(exporter.spans) != 2 {
+		t.Fatalf("expected 2 exported spans, got %d", len(exporter.spans))
+	}
+
+	c, r := exporter.spans[0], exporter.spans[1]
+	if c.TraceID != r.TraceID {
+		t.Errorf("expected child to share trace ID %s, got %s", r.TraceID, c.TraceID)
+	}
+	if c.ParentSpanID != r.SpanID || r.ParentSpanID != "" {
+		t.Errorf("unexpected parenting: child parent %q, root %q", c.ParentSpanID, r.SpanID)
+	}
+	if c.Status != StatusError || c.StatusMessage != "boom" || c.Attributes["key"] != "value" {
+		t.Errorf("unexpected child span: %+v", c)
+	}
+	if r.Kind != SpanKindServer || c.Kind != SpanKindInternal {
+		t.Errorf("unexpected kinds: root %s, child %s", r.Kind, c.Kind)
+	}
+}
+
+func TestTracer_ContinuesRemoteTrace(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter)
+
+	header := http.Header{}
+	header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	ctx, span := tracer.Start(Extract(context.Background(), header), "handler")
+	span.End()
+
+	if len(exporter.spans) != 1 {
+		t.Fatalf("expected 1 exported span, got %d", len(exporter.spans))
+	}
+	if exporter.spans[0].TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || exporter.spans[0].ParentSpanID != "00f067aa0ba902b7" {
+		t.Errorf("expected span to continue remote trace, got %+v", exporter.spans[0])
+	}
+
+	out := http.Header{}
+	Inject(ctx, out)
+	if want := "00-4bf92f3577b34da6a3ce929d0e0e4736-" + span.SpanContext().SpanID.String() + "-01"; out.Get(TraceparentHeader) != want {
+		t.Errorf("expected injected %q, got %q", want, out.Get(TraceparentHeader))
+	}
+}
+
+func TestTracer_UnsampledRemoteTraceIsNotExported(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter)
+
+	header := http.Header{}
+	header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+
+	_, span := tracer.Start(Extract(context.Background(), header), "handler")
+	span.End()
+
+	if len(exporter.spans) != 0 {
+		t.Errorf("expected unsampled span not to be exported, got %d", len(exporter.spans))
+	}
+}
+
+func TestJSONExporter(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := NewTracer(NewJSONExporter(&buf))
+
+	_, span := tracer.Start(context.Background(), "op", WithAttributes("n", 1))
+	span.End()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one JSON line, got %d", len(lines))
+	}
+
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if record["name"] != "op" || len(record["trace_id"].(string)) != 32 || len(record["span_id"].(string)) != 16 {
+		t.Errorf("unexpected record: %v", record)
+	}
+}
+
+func TestNilSpanIsNoop(t *testing.T) {
+	var span *Span
+	span.SetAttribute("k", "v")
+	span.RecordError(errors.New("ignored"))
+	span.End()
+
+	if span.SpanContext().IsValid() {
+		t.Error("expected nil span to have an invalid span context")
+	}
+}
@@ -0,0 +1,272 @@
+// Package validate checks structs against declarative rules in `validate` struct tags.
+//
+// Rules are comma-separated:
+//
+//	required   the value is not empty: non-blank string, non-nil pointer, non-empty slice or map
+//	min=N      a number, or the number a pointer refers to, is at least N
+//	max=N      a number is at most N
+//	len=N      a string has exactly N characters, or a slice or map N elements
+//	minlen=N   a string, slice or map has at least N characters or elements
+//	maxlen=N   a string, slice or map has at most N characters or elements
+//	currency   a string is a three-letter upper-case ISO 4217 code
+//	uuid       a string is a canonical UUID
+//
+// Rules other than required are skipped for empty values, so optional fields are only
+// checked when present. Nested structs, pointers to structs and slices of structs are
+// validated recursively. Field names in errors follow the field's json tag.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FieldError describes a field that failed a rule
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Errors lists every field that failed validation
+type Errors []FieldError
+
+// Error joins the field errors into one message
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Field + " " + fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Rule is a parsed validation rule
+type Rule struct {
+	Name string
+	Arg  string
+}
+
+var (
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// ParseTag parses a validate tag into rules
+func ParseTag(tag string) ([]Rule, error) {
+	if tag == "" {
+		return nil, nil
+	}
+
+	var rules []Rule
+	for _, part := range strings.Split(tag, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(part), "=")
+		switch name {
+		case "required", "currency", "uuid":
+			if hasArg {
+				return nil, fmt.Errorf("rule %s takes no argument", name)
+			}
+		case "min", "max":
+			if _, err := strconv.ParseFloat(arg, 64); err != nil {
+				return nil, fmt.Errorf("rule %s needs a number, got %q", name, arg)
+			}
+		case "len", "minlen", "maxlen":
+			if n, err := strconv.Atoi(arg); err != nil || n < 0 {
+				return nil, fmt.Errorf("rule %s needs a non-negative integer, got %q", name, arg)
+			}
+		default:
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		rules = append(rules, Rule{Name: name, Arg: arg})
+	}
+	return rules, nil
+}
+
+// field is a struct field with its parsed rules
+type field struct {
+	index int
+	name  string
+	rules []Rule
+}
+
+var cache sync.Map // reflect.Type -> []field
+
+// fieldsOf returns the validated fields of a struct type. Malformed tags are programming
+// errors and panic on first use.
+func fieldsOf(t reflect.Type) []field {
+	if cached, ok := cache.Load(t); ok {
+		return cached.([]field)
+	}
+
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		rules, err := ParseTag(f.Tag.Get("validate"))
+		if err != nil {
+			panic(fmt.Sprintf("validate: %s.%s: %v", t, f.Name, err))
+		}
+		fields = append(fields, field{index: i, name: jsonName(f), rules: rules})
+	}
+
+	cache.Store(t, fields)
+	return fields
+}
+
+// jsonName returns the name a field is encoded as
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+// Struct validates v, which must be a struct or a pointer to one. It returns Errors
+// listing every failed rule, or nil.
+func Struct(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: Struct called with %s", rv.Type()))
+	}
+
+	var errs Errors
+	validateStruct(rv, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func validateStruct(rv reflect.Value, prefix string, errs *Errors) {
+	for _, f := range fieldsOf(rv.Type()) {
+		fv := rv.Field(f.index)
+		path := prefix + f.name
+
+		for _, rule := range f.rules {
+			if msg := check(rule, fv); msg != "" {
+				*errs = append(*errs, FieldError{Field: path, Rule: rule.Name, Message: msg})
+			}
+		}
+
+		validateNested(fv, path, errs)
+	}
+}
+
+// validateNested descends into struct values held by a field
+func validateNested(fv reflect.Value, path string, errs *Errors) {
+	switch fv.Kind() {
+	case reflect.Ptr:
+		if !fv.IsNil() {
+			validateNested(fv.Elem(), path, errs)
+		}
+	case reflect.Struct:
+		validateStruct(fv, path+".", errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < fv.Len(); i++ {
+			validateNested(fv.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	}
+}
+
+// check applies a rule to a value and returns a message when it fails
+func check(rule Rule, v reflect.Value) string {
+	if rule.Name == "required" {
+		if isEmpty(v) {
+			return "is required"
+		}
+		return ""
+	}
+
+	if isEmpty(v) {
+		return ""
+	}
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	switch rule.Name {
+	case "min", "max":
+		n, ok := number(v)
+		if !ok {
+			panic(fmt.Sprintf("validate: rule %s applied to %s", rule.Name, v.Type()))
+		}
+		bound, _ := strconv.ParseFloat(rule.Arg, 64)
+		if rule.Name == "min" && n < bound {
+			return "must be at least " + rule.Arg
+		}
+		if rule.Name == "max" && n > bound {
+			return "must be at most " + rule.Arg
+		}
+	case "len", "minlen", "maxlen":
+		n, unit := length(v)
+		want, _ := strconv.Atoi(rule.Arg)
+		switch {
+		case rule.Name == "len" && n != want:
+			return fmt.Sprintf("must have exactly %d %s", want, unit)
+		case rule.Name == "minlen" && n < want:
+			return fmt.Sprintf("must have at least %d %s", want, unit)
+		case rule.Name == "maxlen" && n > want:
+			return fmt.Sprintf("must have at most %d %s", want, unit)
+		}
+	case "currency":
+		if !currencyPattern.MatchString(v.String()) {
+			return "must be a three-letter ISO 4217 currency code"
+		}
+	case "uuid":
+		if !uuidPattern.MatchString(v.String()) {
+			return "must be a UUID"
+		}
+	}
+	return ""
+}
+
+// isEmpty reports whether a value counts as absent
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return false
+	}
+}
+
+func number(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func length(v reflect.Value) (int, string) {
+	switch v.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(v.String()), "characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), "items"
+	default:
+		panic(fmt.Sprintf("validate: length rule applied to %s", v.Type()))
+	}
+}
@@ -0,0 +1,122 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type line struct {
+	SKU      string `json:"sku" validate:"required,maxlen=5"`
+	Quantity int    `json:"quantity" validate:"min=1,max=10"`
+}
+
+type order struct {
+	ID       string            `json:"id,omitempty" validate:"uuid"`
+	Currency string            `json:"currency" validate:"required,currency"`
+	Code     string            `json:"code" validate:"len=3"`
+	Price    *int64            `json:"price,omitempty" validate:"min=0"`
+	Lines    []line            `json:"lines" validate:"required"`
+	Tags     map[string]string `json:"tags" validate:"maxlen=1"`
+	Note     *line             `json:"note,omitempty"`
+	internal string
+}
+
+func int64Ptr(n int64) *int64 { return &n }
+
+func TestStruct_Valid(t *testing.T) {
+	o := &order{
+		ID:       "3f2b8c1e-9d4a-4b7e-8f00-0123456789ab",
+		Currency: "USD",
+		Code:     "ÄBC",
+		Price:    int64Ptr(0),
+		Lines:    []line{{SKU: "A-1", Quantity: 1}},
+	}
+	if err := Struct(o); err != nil {
+		t.Fatalf("expected valid, got %v", err)
+	}
+}
+
+func TestStruct_ReportsEveryField(t *testing.T) {
+	o := order{
+		ID:       "not-a-uuid",
+		Currency: "usd",
+		Code:     "ab",
+		Price:    int64Ptr(-1),
+		Lines:    []line{{SKU: "TOO-LONG", Quantity: 0}, {SKU: " ", Quantity: 11}},
+		Tags:     map[string]string{"a": "1", "b": "2"},
+		Note:     &line{SKU: "N", Quantity: 1},
+	}
+
+	err := Struct(o)
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected Errors, got %v", err)
+	}
+
+	want := Errors{
+		{Field: "id", Rule: "uuid", Message: "must be a UUID"},
+		{Field: "currency", Rule: "currency", Message: "must be a three-letter ISO 4217 currency code"},
+		{Field: "code", Rule: "len", Message: "must have exactly 3 characters"},
+		{Field: "price", Rule: "min", Message: "must be at least 0"},
+		{Field: "lines[0].sku", Rule: "maxlen", Message: "must have at most 5 characters"},
+		{Field: "lines[0].quantity", Rule: "min", Message: "must be at least 1"},
+		{Field: "lines[1].sku", Rule: "required", Message: "is required"},
+		{Field: "lines[1].quantity", Rule: "max", Message: "must be at most 10"},
+		{Field: "tags", Rule: "maxlen", Message: "must have at most 1 items"},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("errors =\n%+v\nwant\n%+v", errs, want)
+	}
+}
+
+func TestStruct_RequiredAndOptional(t *testing.T) {
+	// Optional fields are only checked when present
+	err := Struct(&order{})
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected Errors, got %v", err)
+	}
+	if len(errs) != 2 || errs[0].Field != "currency" || errs[1].Field != "lines" {
+		t.Errorf("expected currency and lines to be required, got %+v", errs)
+	}
+	if got := errs.Error(); got != "currency is required; lines is required" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestStruct_NilPointer(t *testing.T) {
+	var o *order
+	if err := Struct(o); err != nil {
+		t.Errorf("expected nil for a nil pointer, got %v", err)
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	rules, err := ParseTag("required, min=1,maxlen=64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Rule{{Name: "required"}, {Name: "min", Arg: "1"}, {Name: "maxlen", Arg: "64"}}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("rules = %+v, want %+v", rules, want)
+	}
+
+	for _, tag := range []string{"bogus", "min=x", "maxlen=-1", "required=true", "uuid=4"} {
+		if _, err := ParseTag(tag); err == nil {
+			t.Errorf("ParseTag(%q) expected error", tag)
+		}
+	}
+}
+
+func TestStruct_MalformedTagPanics(t *testing.T) {
+	type bad struct {
+		Name string `validate:"mni=1"`
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for malformed tag")
+		}
+	}()
+	Struct(bad{})
+}
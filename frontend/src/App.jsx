@@ -45,13 +45,21 @@ function App() {
     }
   };
 
-  const handleCheckout = async (basketId) => {
+  const handleCheckout = async (basketId, confirmedTotal) => {
     try {
-      const order = await orderApi.create(basketId);
+      const order = await orderApi.create(basketId, confirmedTotal);
       showMessage(`Order created successfully! Order ID: ${order.id}`, 'success');
       localStorage.removeItem('basketId');
       await initializeBasket();
     } catch (err) {
+      const revalidation = err.status === 409 && err.body.revalidation;
+      if (revalidation && revalidation.can_checkout) {
+        const total = (revalidation.revalidated_total / 100).toFixed(2);
+        if (window.confirm(`Prices in your basket have changed. The new total is ${revalidation.currency} $${total}. Continue?`)) {
+          await handleCheckout(basketId, revalidation.revalidated_total);
+        }
+        return;
+      }
       showMessage('Checkout failed: ' + err.message, 'error');
     }
   };
@@ -14,7 +14,10 @@ async function apiRequest(endpoint, options = {}) {
 
   if (!response.ok) {
     const error = await response.json().catch(() => ({ error: 'Request failed' }));
-    throw new Error(error.error || `HTTP error! status: ${response.status}`);
+    const err = new Error(error.error || `HTTP error! status: ${response.status}`);
+    err.status = response.status;
+    err.body = error;
+    throw err;
   }
 
   if (response.status === 204) {
@@ -27,6 +30,7 @@ async function apiRequest(endpoint, options = {}) {
 // Product API
 export const productApi = {
   getAll: () => apiRequest('/products'),
+  search: (params) => apiRequest(`/products/search?${new URLSearchParams(params)}`),
   getById: (id) => apiRequest(`/products/${id}`),
   create: (data) => apiRequest('/products', {
     method: 'POST',
@@ -45,31 +49,40 @@ export const productApi = {
   }),
 };
 
+function variantQuery(variantId) {
+  return variantId ? `?variant_id=${encodeURIComponent(variantId)}` : '';
+}
+
 // Basket API
 export const basketApi = {
   create: () => apiRequest('/baskets', { method: 'POST' }),
   getById: (id) => apiRequest(`/baskets/${id}`),
-  addItem: (id, productId, quantity) => apiRequest(`/baskets/${id}/items`, {
+  revalidate: (id) => apiRequest(`/baskets/${id}/revalidation`),
+  addItem: (id, productId, quantity, variantId) => apiRequest(`/baskets/${id}/items`, {
     method: 'POST',
-    body: JSON.stringify({ product_id: productId, quantity }),
+    body: JSON.stringify({ product_id: productId, variant_id: variantId, quantity }),
   }),
-  updateItemQuantity: (id, productId, quantity) => apiRequest(`/baskets/${id}/items/${productId}`, {
+  updateItemQuantity: (id, productId, quantity, variantId) => apiRequest(`/baskets/${id}/items/${productId}${variantQuery(variantId)}`, {
     method: 'PATCH',
     body: JSON.stringify({ quantity }),
   }),
-  removeItem: (id, productId) => apiRequest(`/baskets/${id}/items/${productId}`, {
+  removeItem: (id, productId, variantId) => apiRequest(`/baskets/${id}/items/${productId}${variantQuery(variantId)}`, {
     method: 'DELETE',
   }),
   clear: (id) => apiRequest(`/baskets/${id}/items`, {
     method: 'DELETE',
   }),
+  merge: (id, sourceBasketId, strategy) => apiRequest(`/baskets/${id}/merge`, {
+    method: 'POST',
+    body: JSON.stringify({ source_basket_id: sourceBasketId, strategy }),
+  }),
 };
 
 // Order API
 export const orderApi = {
-  create: (basketId) => apiRequest('/orders', {
+  create: (basketId, confirmedTotal) => apiRequest('/orders', {
     method: 'POST',
-    body: JSON.stringify({ basket_id: basketId }),
+    body: JSON.stringify({ basket_id: basketId, confirmed_total: confirmedTotal }),
   }),
   getAll: () => apiRequest('/orders'),
   getById: (id) => apiRequest(`/orders/${id}`),
@@ -77,7 +77,7 @@ function Admin() {
   };
 
   const handleDelete = async (id) => {
-    if (window.confirm('Are you sure you want to delete this product?')) {
+    if (window.confirm('Archive this product? It will be hidden from the store and removed from baskets.')) {
       try {
         await productApi.delete(id);
         loadProducts();
@@ -92,9 +92,12 @@ function Admin() {
     return `${currency} $${dollars.toFixed(2)}`;
   };
 
-  const getProductName = (productId) => {
-    const product = products.find(p => p.id === productId);
-    return product ? product.name : `Product ${productId.substring(0, 8)}...`;
+  const getProductName = (item) => {
+    if (item.name) {
+      return item.name;
+    }
+    const product = products.find(p => p.id === item.product_id);
+    return product ? product.name : `Product ${item.product_id.substring(0, 8)}...`;
   };
 
   return (
@@ -190,7 +193,7 @@ function Admin() {
                   {order.items.map((item, index) => (
                     <div key={index} className="order-item">
                       <div className="order-item-details">
-                        <span className="item-name">{getProductName(item.product_id)}</span>
+                        <span className="item-name">{getProductName(item)}</span>
                         <span className="item-quantity">Qty: {item.quantity}</span>
                       </div>
                       <div className="order-item-price">
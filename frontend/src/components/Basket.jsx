@@ -75,7 +75,14 @@ function Basket({ basketId, onCheckout }) {
             {basket.items.map((item) => (
               <div key={item.product_id} className="basket-item">
                 <div className="item-details">
+                  <p className="item-name">{item.product ? item.product.name : 'Unavailable product'}</p>
                   <p className="item-price">{formatPrice(item.price, item.currency)}</p>
+                  {item.price_changed && item.product && (
+                    <p className="item-warning">
+                      Price is now {formatPrice(item.product.price, item.product.currency)}
+                    </p>
+                  )}
+                  {item.out_of_stock && <p className="item-warning">Out of stock</p>}
                   <p className="item-subtotal">
                     Subtotal: {formatPrice(item.subtotal, item.currency)}
                   </p>
@@ -39,6 +39,14 @@ function ProductList({ onAddToBasket }) {
         <div className="products-grid">
           {products.map((product) => (
             <div key={product.id} className="product-card">
+              {product.primary_image && (
+                <img
+                  className="product-image"
+                  src={product.primary_image.thumbnails?.medium?.url || product.primary_image.url}
+                  alt={product.primary_image.alt_text || product.name}
+                  loading="lazy"
+                />
+              )}
               <h3>{product.name}</h3>
               <p className="description">{product.description}</p>
               <p className="price">{formatPrice(product.price, product.currency)}</p>